@@ -1,10 +1,16 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"stet.codes/tui/clients"
+	"stet.codes/tui/config"
+	"stet.codes/tui/logging"
 	"stet.codes/tui/pages"
 
 	"github.com/charmbracelet/bubbles/help"
@@ -12,6 +18,7 @@ import (
 	"github.com/charmbracelet/bubbles/paginator"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // Styles for dim page titles in the navigation indicator.
@@ -20,14 +27,55 @@ var (
 			Foreground(lipgloss.Color("#888888"))
 	dimStyle2 = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#666666"))
+
+	// badgeStyle marks a page's actionable count next to its title.
+	badgeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F59E0B"))
+)
+
+// Background token refresh: every tokenRefreshInterval, refresh any tokens
+// that expire within tokenRefreshWindow so fetches never have to block on a
+// synchronous refresh (which kicks in 5 minutes before expiry).
+const (
+	tokenRefreshInterval = time.Minute
+	tokenRefreshWindow   = 10 * time.Minute
 )
 
+// tokenRefreshTickMsg triggers a background token refresh check.
+type tokenRefreshTickMsg time.Time
+
+// tokenRefreshDoneMsg reports the outcome of a background token refresh.
+type tokenRefreshDoneMsg struct {
+	err error
+}
+
+// refreshNoticeDuration is how long the "refreshed" notice stays visible
+// after an idle refresh.
+const refreshNoticeDuration = 3 * time.Second
+
+// clockTickMsg re-renders once a second so relative "updated" times stay live.
+type clockTickMsg time.Time
+
+// clearRefreshNoticeMsg hides the idle refresh notice.
+type clearRefreshNoticeMsg struct{}
+
+// dndExpiredMsg ends a timed do not disturb period. Messages from an earlier
+// period are ignored by comparing version.
+type dndExpiredMsg struct {
+	version int
+}
+
 // globalKeyMap defines application-wide key bindings.
 type globalKeyMap struct {
-	Left  key.Binding
-	Right key.Binding
-	Help  key.Binding
-	Quit  key.Binding
+	Left      key.Binding
+	Right     key.Binding
+	Help      key.Binding
+	Quit      key.Binding
+	DND       key.Binding
+	Refresh   key.Binding
+	Dashboard key.Binding
+	HideHelp  key.Binding
+	Debug     key.Binding
 }
 
 var globalKeys = globalKeyMap{
@@ -47,27 +95,87 @@ var globalKeys = globalKeyMap{
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
 	),
+	DND: key.NewBinding(
+		key.WithKeys("ctrl+n"),
+		key.WithHelp("ctrl+n", "do not disturb"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "refresh page"),
+	),
+	Dashboard: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "dashboard"),
+	),
+	HideHelp: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "hide help"),
+	),
+	// Debug is intentionally left out of the help views.
+	Debug: key.NewBinding(
+		key.WithKeys("ctrl+g"),
+	),
 }
 
 // AppModel is the root Bubble Tea model that manages pages and global state.
 type AppModel struct {
+	db             *sql.DB
+	ouraClient     *clients.OuraClient
+	plantaClient   *clients.PlantaClient
+	ouraTimeouts   config.ClientTimeouts
+	plantaTimeouts config.ClientTimeouts
+	logger         *logging.Logger
+
 	pages       []pages.Page
 	paginator   paginator.Model
 	help        help.Model
+	helpHidden  bool // no help bar at all; see help_bar.go
 	initialized map[pages.PageID]bool
 	width       int
 	height      int
+	debugLayout bool // show layout measurements instead of the paginator
+
+	// First-run introduction, shown in place of the active page until
+	// finished or skipped
+	onboarding *pages.Onboarding
+
+	// At-a-glance overlay, shown in place of the active page while open
+	dashboard *pages.Dashboard
+
+	// Idle refresh: the first keypress after idleRefreshAfter without input
+	// reloads the active page. Disabled when idleRefreshAfter is zero.
+	idleRefreshAfter time.Duration
+	lastInput        time.Time
+	refreshNotice    bool
+
+	liveClock bool // tick every second for relative fetch times
+
+	// Startup integration check. The banner, if enabled, replaces the
+	// paginator until the first keypress or integrationBannerTime.
+	startupCheck      config.StartupCheck
+	integrationBanner string
+
+	// Do not disturb suppresses transient notices (the startup banner, the
+	// idle "refreshed" notice, completion sounds and, later, reminders)
+	// until dndUntil, or until toggled off when dndUntil is zero.
+	dndFor     time.Duration
+	dndOn      bool
+	dndUntil   time.Time
+	dndVersion int
+	dndLayout  string // for dndUntil in the title bar
+
+	completionSound config.CompletionSound
 }
 
 // NewAppModel creates and initializes the application model with all pages.
-func NewAppModel(db *sql.DB, ouraClient *clients.OuraClient, plantaClient *clients.PlantaClient) AppModel {
+func NewAppModel(db *sql.DB, ouraClient *clients.OuraClient, plantaClient *clients.PlantaClient, cfg config.Config, logger *logging.Logger) AppModel {
 	allPages := []pages.Page{
-		pages.NewOuraPage(ouraClient),
-		pages.NewPlantaPage(plantaClient),
-		pages.NewTodayPage(db),
-		pages.NewJournalPage(db),
-		pages.NewHistoryPage(db),
-		pages.NewTaskCfgPage(db),
+		pages.NewOuraPage(ouraClient, cfg),
+		pages.NewPlantaPage(plantaClient, db, cfg),
+		pages.NewTodayPage(db, cfg),
+		pages.NewJournalPage(db, cfg),
+		pages.NewHistoryPage(db, cfg),
+		pages.NewTaskCfgPage(db, cfg),
 	}
 
 	pag := paginator.New()
@@ -77,12 +185,48 @@ func NewAppModel(db *sql.DB, ouraClient *clients.OuraClient, plantaClient *clien
 	pag.InactiveDot = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "250", Dark: "238"}).Render("•")
 	pag.SetTotalPages(len(allPages))
 
+	h := help.New()
+	h.ShowAll = cfg.HelpBar == config.HelpBarFull
+
 	return AppModel{
+		db:             db,
+		ouraClient:     ouraClient,
+		plantaClient:   plantaClient,
+		ouraTimeouts:   cfg.OuraTimeouts,
+		plantaTimeouts: cfg.PlantaTimeouts,
+		logger:         logger,
+
 		pages:       allPages,
 		paginator:   pag,
-		help:        help.New(),
+		help:        h,
+		helpHidden:  cfg.HelpBar == config.HelpBarHidden,
 		initialized: make(map[pages.PageID]bool),
+
+		dashboard: pages.NewDashboard(db, ouraClient, plantaClient, cfg),
+
+		idleRefreshAfter: cfg.IdleRefreshAfter,
+		lastInput:        time.Now(),
+
+		liveClock: cfg.LastUpdated == config.LastUpdatedRelative,
+
+		startupCheck: cfg.StartupCheck,
+
+		dndFor:    cfg.DoNotDisturbFor,
+		dndLayout: cfg.Formats.TimeShort,
+
+		completionSound: cfg.CompletionSound,
+	}
+}
+
+// pageIndex returns the position of the page with the given ID in m.pages,
+// which is not in PageID order.
+func (m AppModel) pageIndex(id pages.PageID) int {
+	for i, p := range m.pages {
+		if p.ID() == id {
+			return i
+		}
 	}
+	return -1
 }
 
 // activePage returns the currently active page.
@@ -94,6 +238,15 @@ func (m AppModel) activePage() pages.Page {
 	return m.pages[idx]
 }
 
+// capturesNavigation reports whether the active page is taking keys for
+// itself, e.g. while editing text, so navigation keys shouldn't act.
+func (m AppModel) capturesNavigation() bool {
+	if nc, ok := m.activePage().(pages.NavigationCapturer); ok {
+		return nc.CapturesNavigation()
+	}
+	return false
+}
+
 // visiblePage represents a page to display in the navigation indicator.
 type visiblePage struct {
 	index    int
@@ -148,11 +301,54 @@ func getVisiblePages(current, total int) visiblePagesResult {
 	}
 }
 
-// renderTitle renders the navigation indicator showing current and adjacent pages.
+// renderTitle renders the navigation indicator showing current and adjacent
+// pages. Page badges are dropped if they would make it wider than the screen.
 func (m AppModel) renderTitle() string {
+	title, _ := m.titleBar()
+	return title
+}
+
+// titleTab is a clickable region of the title bar, in cells from its left
+// edge, that navigates to page.
+type titleTab struct {
+	page       int
+	start, end int
+}
+
+// titleBar renders the title bar along with its clickable regions.
+func (m AppModel) titleBar() (string, []titleTab) {
+	title, tabs := m.buildTitle(true)
+	contentWidth := m.width - pages.DocStyle.GetHorizontalFrameSize()
+	if m.width > 0 && lipgloss.Width(title) > contentWidth {
+		title, tabs = m.buildTitle(false)
+	}
+	return title, tabs
+}
+
+// buildTitle builds the navigation indicator, optionally with page badges.
+func (m AppModel) buildTitle(badges bool) (string, []titleTab) {
 	result := getVisiblePages(m.paginator.Page, len(m.pages))
-	titles := make([]string, len(result.pages))
+	var tabs []titleTab
+
+	// Build the title bar with consistent spacing for arrows
+	var b strings.Builder
+	write := func(s string, page int) {
+		x := lipgloss.Width(b.String())
+		b.WriteString(s)
+		if page >= 0 {
+			tabs = append(tabs, titleTab{page: page, start: x, end: x + lipgloss.Width(s)})
+		}
+	}
 
+	// Left arrow slot (always same width for consistent spacing)
+	if result.hasLeft {
+		write("←", m.paginator.Page-1)
+	} else {
+		write(" ", -1)
+	}
+	write("   ", -1)
+
+	// Page titles
 	for i, vp := range result.pages {
 		t := m.pages[vp.index].Title()
 		var styled string
@@ -168,32 +364,71 @@ func (m AppModel) renderTitle() string {
 		default:
 			styled = dimStyle2.Render(t.Text)
 		}
-		titles[i] = styled
+		if bg, ok := m.pages[vp.index].(pages.Badger); ok && badges {
+			if badge := bg.Badge(); badge != "" {
+				styled += " " + badgeStyle.Render(badge)
+			}
+		}
+		if i > 0 {
+			write("   ", -1)
+		}
+		write(styled, vp.index)
 	}
 
-	// Build the title bar with consistent spacing for arrows
-	var b strings.Builder
-
-	// Left arrow slot (always same width for consistent spacing)
-	if result.hasLeft {
-		b.WriteString("←")
+	// Right arrow slot (always same width for consistent spacing)
+	write("   ", -1)
+	if result.hasRight {
+		write("→", m.paginator.Page+1)
 	} else {
-		b.WriteString(" ")
+		write(" ", -1)
 	}
-	b.WriteString("   ")
 
-	// Page titles
-	b.WriteString(strings.Join(titles, "   "))
+	if m.dndOn {
+		label := "DND"
+		if !m.dndUntil.IsZero() {
+			label += " until " + m.dndUntil.Format(m.dndLayout)
+		}
+		b.WriteString("   ")
+		b.WriteString(dimStyle1.Render(label))
+	}
 
-	// Right arrow slot (always same width for consistent spacing)
-	b.WriteString("   ")
-	if result.hasRight {
-		b.WriteString("→")
-	} else {
-		b.WriteString(" ")
+	return b.String(), tabs
+}
+
+// tabAt returns the page whose title bar tab contains the screen cell
+// (x, y), or -1. The title bar is the first line inside DocStyle's frame.
+func (m AppModel) tabAt(x, y int) int {
+	if y != pages.DocStyle.GetPaddingTop() {
+		return -1
+	}
+	x -= pages.DocStyle.GetPaddingLeft()
+	_, tabs := m.titleBar()
+	for _, t := range tabs {
+		if x >= t.start && x < t.end {
+			return t.page
+		}
 	}
+	return -1
+}
 
-	return b.String()
+// renderDebugLayout renders the window size, content height and the active
+// page's computed layout values on a single line.
+func (m AppModel) renderDebugLayout() string {
+	parts := []string{
+		fmt.Sprintf("win=%dx%d", m.width, m.height),
+		fmt.Sprintf("content=%d", m.contentHeight()),
+		fmt.Sprintf("help=%d", m.helpHeight()),
+	}
+	if ld, ok := m.activePage().(pages.LayoutDebugger); ok {
+		for _, v := range ld.DebugLayout() {
+			parts = append(parts, fmt.Sprintf("%s=%d", v.Name, v.Value))
+		}
+	}
+	line := strings.Join(parts, " ")
+	if contentWidth := m.width - pages.DocStyle.GetHorizontalFrameSize(); contentWidth > 0 {
+		line = ansi.Truncate(line, contentWidth, "…")
+	}
+	return dimStyle1.Render(line)
 }
 
 // combinedKeyMap implements help.KeyMap by combining page and global keys.
@@ -212,36 +447,168 @@ func (k combinedKeyMap) ShortHelp() []key.Binding {
 func (k combinedKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		k.pageKeys,
-		{globalKeys.Left, globalKeys.Right, globalKeys.Help, globalKeys.Quit},
+		{globalKeys.Left, globalKeys.Right, globalKeys.Refresh, globalKeys.Dashboard, globalKeys.DND, globalKeys.Help, globalKeys.HideHelp, globalKeys.Quit},
 	}
 }
 
 func (m AppModel) Init() tea.Cmd {
+	cmds := []tea.Cmd{m.refreshTokensCmd(), m.firstRunCmd(), m.loadHelpBarCmd()}
+	if m.liveClock {
+		cmds = append(cmds, clockTickCmd())
+	}
+	if m.startupCheck != config.StartupCheckOff {
+		cmds = append(cmds, m.integrationCheckCmd())
+	}
+
 	// Initialize the active page if it implements PageInitializer
 	page := m.activePage()
 	if pi, ok := page.(pages.PageInitializer); ok {
 		m.initialized[page.ID()] = true
-		return pi.InitCmd()
+		cmds = append(cmds, pi.InitCmd())
+	}
+	return tea.Batch(cmds...)
+}
+
+// tokenRefreshTickCmd schedules the next background token refresh check.
+func tokenRefreshTickCmd() tea.Cmd {
+	return tea.Tick(tokenRefreshInterval, func(t time.Time) tea.Msg {
+		return tokenRefreshTickMsg(t)
+	})
+}
+
+// clockTickCmd schedules the next clock tick.
+func clockTickCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return clockTickMsg(t)
+	})
+}
+
+// refreshTokensCmd refreshes integration tokens that are close to expiry.
+func (m AppModel) refreshTokensCmd() tea.Cmd {
+	oura, planta := m.ouraClient, m.plantaClient
+	ouraTimeout, plantaTimeout := m.ouraTimeouts.Background, m.plantaTimeouts.Background
+	return func() tea.Msg {
+		var errs []error
+		if oura.Auth().HasCredentials() {
+			ctx, cancel := context.WithTimeout(context.Background(), ouraTimeout)
+			_, err := oura.Auth().RefreshIfNeeded(ctx, tokenRefreshWindow)
+			cancel()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("oura: %w", err))
+			}
+		}
+		if planta.Auth().HasCredentials() {
+			ctx, cancel := context.WithTimeout(context.Background(), plantaTimeout)
+			_, err := planta.Auth().RefreshIfNeeded(ctx, tokenRefreshWindow)
+			cancel()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("planta: %w", err))
+			}
+		}
+		return tokenRefreshDoneMsg{err: errors.Join(errs...)}
 	}
-	return nil
+}
+
+// Shutdown lets pages persist unsaved state and stop background work. It is
+// called once the program has stopped, on every exit path.
+func (m AppModel) Shutdown() {
+	for _, page := range m.pages {
+		if s, ok := page.(pages.Shutdowner); ok {
+			if err := s.Shutdown(); err != nil {
+				m.logger.Warnf("shutdown %s: %v", page.Title().Text, err)
+			}
+		}
+	}
+}
+
+// checkIdle records a keypress and, if idle refresh is enabled and the app
+// has been idle past the threshold, returns a command that reloads the active
+// page and shows the "refreshed" notice.
+func (m *AppModel) checkIdle() tea.Cmd {
+	now := time.Now()
+	idle := now.Sub(m.lastInput)
+	m.lastInput = now
+	if m.idleRefreshAfter <= 0 || idle < m.idleRefreshAfter {
+		return nil
+	}
+	r, ok := m.activePage().(pages.Refresher)
+	if !ok {
+		return nil
+	}
+	m.logger.Infof("idle for %s, refreshing %s", idle.Round(time.Second), m.activePage().Title().Text)
+	if m.notificationsPaused() {
+		return r.RefreshCmd()
+	}
+	m.refreshNotice = true
+	return tea.Batch(
+		r.RefreshCmd(),
+		tea.Tick(refreshNoticeDuration, func(time.Time) tea.Msg {
+			return clearRefreshNoticeMsg{}
+		}),
+	)
+}
+
+// notificationsPaused reports whether do not disturb is on. Anything that
+// would interrupt the user unprompted checks this first.
+func (m AppModel) notificationsPaused() bool {
+	return m.dndOn
+}
+
+// toggleDND turns do not disturb on or off. When turning it on for a limited
+// time it returns the command that ends it.
+func (m *AppModel) toggleDND() tea.Cmd {
+	m.dndVersion++
+	if m.dndOn {
+		m.dndOn = false
+		m.logger.Infof("do not disturb off")
+		return nil
+	}
+
+	m.dndOn = true
+	m.integrationBanner = ""
+	m.refreshNotice = false
+	if m.dndFor <= 0 {
+		m.dndUntil = time.Time{}
+		m.logger.Infof("do not disturb on")
+		return nil
+	}
+	m.dndUntil = time.Now().Add(m.dndFor)
+	m.logger.Infof("do not disturb on until %s", m.dndUntil.Format("15:04"))
+	version := m.dndVersion
+	return tea.Tick(m.dndFor, func(time.Time) tea.Msg {
+		return dndExpiredMsg{version: version}
+	})
 }
 
 // helpHeight returns the number of lines the help component will use.
 func (m AppModel) helpHeight() int {
+	if m.helpHidden {
+		return 0
+	}
 	if m.help.ShowAll {
 		return 2 // Full help uses 2 rows (page keys + global keys)
 	}
 	return 1 // Short help uses 1 row
 }
 
+// contentTop returns the screen row on which page content starts, below the
+// title bar and a blank line.
+func (m AppModel) contentTop() int {
+	return pages.DocStyle.GetPaddingTop() + 2
+}
+
 // contentHeight returns the available height for page content.
 func (m AppModel) contentHeight() int {
 	if m.height == 0 {
 		return 0
 	}
 	// Layout: title(1) + \n\n(2) + content + \n\n(2) + help + \n\n(2) + paginator(1)
-	// Plus DocStyle vertical frame
-	chrome := 1 + 2 + 2 + m.helpHeight() + 2 + 1 + pages.DocStyle.GetVerticalFrameSize()
+	// Plus DocStyle vertical frame. Hidden help takes its gap with it.
+	helpRows := 0
+	if h := m.helpHeight(); h > 0 {
+		helpRows = h + 2
+	}
+	chrome := 1 + 2 + 2 + helpRows + 1 + pages.DocStyle.GetVerticalFrameSize()
 	return max(m.height-chrome, 0)
 }
 
@@ -251,6 +618,10 @@ func (m AppModel) updatePageSizes() {
 	for _, page := range m.pages {
 		page.SetSize(m.width, contentHeight)
 	}
+	if m.onboarding != nil {
+		m.onboarding.SetSize(m.width, contentHeight)
+	}
+	m.dashboard.SetSize(m.width, contentHeight)
 }
 
 func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -261,12 +632,130 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updatePageSizes()
 		return m, nil
 
+	case tokenRefreshTickMsg:
+		return m, m.refreshTokensCmd()
+
+	case tokenRefreshDoneMsg:
+		// Failures aren't surfaced in the UI: the fetch path still refreshes
+		// reactively and reports auth problems on the relevant page.
+		if msg.err != nil {
+			m.logger.Warnf("background token refresh: %v", msg.err)
+		}
+		return m, tokenRefreshTickCmd()
+
+	case clockTickMsg:
+		// Nothing to update; receiving the message triggers a re-render
+		return m, clockTickCmd()
+
+	case clearRefreshNoticeMsg:
+		m.refreshNotice = false
+		return m, nil
+
+	case integrationCheckMsg:
+		for _, s := range msg.statuses {
+			m.logger.Infof("startup check: %s", s)
+		}
+		if m.startupCheck != config.StartupCheckBanner || m.notificationsPaused() {
+			return m, nil
+		}
+		m.integrationBanner = renderIntegrationBanner(msg.statuses)
+		return m, tea.Tick(integrationBannerTime, func(time.Time) tea.Msg {
+			return clearIntegrationBannerMsg{}
+		})
+
+	case clearIntegrationBannerMsg:
+		m.integrationBanner = ""
+		return m, nil
+
+	case dndExpiredMsg:
+		if msg.version == m.dndVersion && m.dndOn {
+			m.dndOn = false
+			m.logger.Infof("do not disturb ended")
+		}
+		return m, nil
+
+	case firstRunMsg:
+		m.onboarding = pages.NewOnboarding(m.db)
+		m.onboarding.SetSize(m.width, m.contentHeight())
+		return m, nil
+
+	case pages.OnboardingFinishedMsg:
+		if msg.Err != nil {
+			m.logger.Errorf("onboarding: %v", msg.Err)
+			return m, nil
+		}
+		// Sample tasks were added behind pages that may already be loaded
+		for _, id := range []pages.PageID{pages.TodayPageID, pages.HistoryPageID, pages.TaskCfgPageID} {
+			delete(m.initialized, id)
+		}
+		if r, ok := m.activePage().(pages.Refresher); ok && msg.Tasks > 0 {
+			m.initialized[m.activePage().ID()] = true
+			return m, r.RefreshCmd()
+		}
+		return m, nil
+
+	case pages.CompletionSoundMsg:
+		if m.notificationsPaused() {
+			return m, nil
+		}
+		return m, m.completionSoundCmd(msg.Milestone)
+
+	case helpBarLoadedMsg:
+		m.setHelpBar(msg.state)
+		return m, nil
+
+	case pages.DashboardMsg:
+		return m, m.dashboard.Update(msg)
+
 	case pages.InvalidateTodayPageMsg:
-		// Reset Today page's initialized state so it refetches on next view
+		// Reset Today page's initialized state so it refetches on next view,
+		// or now if it is showing: a save started on another page can finish
+		// just after switching to it
 		delete(m.initialized, pages.TodayPageID)
+		if page := m.activePage(); page.ID() == pages.TodayPageID {
+			m.initialized[pages.TodayPageID] = true
+			if pi, ok := page.(pages.PageInitializer); ok {
+				return m, pi.InitCmd()
+			}
+		}
+		return m, nil
+
+	case pages.InvalidateHistoryPageMsg:
+		delete(m.initialized, pages.HistoryPageID)
 		return m, nil
 
+	case tea.MouseMsg:
+		if m.onboarding != nil || m.dashboard.IsOpen() {
+			return m, nil
+		}
+
+		// Clicking a title bar tab navigates to its page, unless the page
+		// has captured navigation (e.g. while editing)
+		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft && !m.capturesNavigation() {
+			if page := m.tabAt(msg.X, msg.Y); page >= 0 && page != m.paginator.Page {
+				prevPage := m.paginator.Page
+				m.paginator.Page = page
+				return m, m.pageChanged(prevPage)
+			}
+		}
+
+		// Anything else goes to the active page, relative to its content
+		msg.X -= pages.DocStyle.GetPaddingLeft()
+		msg.Y -= m.contentTop()
+		var cmd tea.Cmd
+		m.pages[m.paginator.Page], cmd = m.activePage().Update(msg)
+		return m, cmd
+
 	case tea.KeyMsg:
+		m.integrationBanner = ""
+
+		// Coming back after a long idle: reload the active page alongside
+		// handling the key so nothing is acted on with very stale data.
+		if cmd := m.checkIdle(); cmd != nil {
+			next, pageCmd := m.Update(msg)
+			return next, tea.Batch(cmd, pageCmd)
+		}
+
 		// Check if active page captures global keys (e.g., insert mode)
 		capturesGlobal := false
 		if nc, ok := m.activePage().(pages.NavigationCapturer); ok {
@@ -284,21 +773,56 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, globalKeys.Quit):
 				return m, tea.Quit
 			case key.Matches(msg, globalKeys.Help):
-				m.help.ShowAll = !m.help.ShowAll
-				m.updatePageSizes() // Recalculate since help height changed
+				// Hidden help comes back in full, since help was asked for
+				if m.helpBar() == config.HelpBarFull {
+					return m, m.changeHelpBar(config.HelpBarShort)
+				}
+				return m, m.changeHelpBar(config.HelpBarFull)
+			case key.Matches(msg, globalKeys.HideHelp) && !m.capturesNavigation():
+				if m.helpHidden {
+					return m, m.changeHelpBar(config.HelpBarShort)
+				}
+				return m, m.changeHelpBar(config.HelpBarHidden)
+			case key.Matches(msg, globalKeys.Debug):
+				m.debugLayout = !m.debugLayout
 				return m, nil
+			case key.Matches(msg, globalKeys.DND):
+				return m, m.toggleDND()
+			case key.Matches(msg, globalKeys.Refresh) && m.dashboard.IsOpen():
+				return m, m.dashboard.RefreshCmd()
+			case key.Matches(msg, globalKeys.Refresh):
+				// Pages that can't refresh (e.g. Journal, which uses ctrl+r
+				// itself) get the key as usual
+				if r, ok := m.activePage().(pages.Refresher); ok {
+					return m, r.RefreshCmd()
+				}
 			}
 		}
+
+		// Onboarding takes the keys left until it is done
+		if m.onboarding != nil {
+			cmd := m.onboarding.Update(msg)
+			if m.onboarding.Done() {
+				m.onboarding = nil
+			}
+			return m, cmd
+		}
+
+		// The dashboard takes the keys left while it is open. It opens
+		// unless the page is taking text, where D is just a letter.
+		if m.dashboard.IsOpen() {
+			return m, m.dashboard.Update(msg)
+		}
+		if key.Matches(msg, globalKeys.Dashboard) && !capturesGlobal && !m.capturesNavigation() {
+			return m, m.dashboard.Open()
+		}
 	}
 
 	// Track previous page to detect navigation
 	prevPage := m.paginator.Page
 
 	// Check if active page captures navigation keys (e.g., text input mode)
-	capturesNav := false
-	if nc, ok := m.activePage().(pages.NavigationCapturer); ok {
-		capturesNav = nc.CapturesNavigation()
-	}
+	capturesNav := m.capturesNavigation()
 
 	// Update paginator for navigation (left/right keys) unless page captures them
 	var paginatorCmd tea.Cmd
@@ -311,12 +835,23 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var pageCmd tea.Cmd
 	m.pages[idx], pageCmd = m.pages[idx].Update(msg)
 
-	// for background tasks we should still forward them to their respective pages
+	// for background tasks we should still forward them to their respective
+	// pages, unless that page is the active one and has just handled them
+	var backgroundCmd tea.Cmd
 	switch msg := msg.(type) {
 	case pages.OuraDataLoadedMsg, pages.OuraDataFailedMsg:
-		m.pages[pages.OuraPageID].Update(msg)
+		if i := m.pageIndex(pages.OuraPageID); i != idx {
+			m.pages[i].Update(msg)
+		}
 	case pages.PlantaDataLoadedMsg, pages.PlantaDataFailedMsg:
-		m.pages[pages.PlantaPageID].Update(msg)
+		if i := m.pageIndex(pages.PlantaPageID); i != idx {
+			m.pages[i].Update(msg)
+		}
+	case pages.HistoryCompletionsSavedMsg, pages.HistoryCompletionsSaveFailedMsg:
+		// Its commands are kept: a save may need the Today page reloaded
+		if i := m.pageIndex(pages.HistoryPageID); i != idx {
+			m.pages[i], backgroundCmd = m.pages[i].Update(msg)
+		}
 	}
 
 	var cmds []tea.Cmd
@@ -326,13 +861,13 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if pageCmd != nil {
 		cmds = append(cmds, pageCmd)
 	}
+	if backgroundCmd != nil {
+		cmds = append(cmds, backgroundCmd)
+	}
 
-	// If page changed, initialize the new page if it hasn't been initialized yet
 	if idx != prevPage {
-		page := m.pages[idx]
-		if pi, ok := page.(pages.PageInitializer); ok && !m.initialized[page.ID()] {
-			m.initialized[page.ID()] = true
-			cmds = append(cmds, pi.InitCmd())
+		if cmd := m.pageChanged(prevPage); cmd != nil {
+			cmds = append(cmds, cmd)
 		}
 	}
 
@@ -346,23 +881,45 @@ func (m AppModel) View() string {
 	b.WriteString(m.renderTitle())
 	b.WriteString("\n\n")
 
-	// View contents from active page
-	b.WriteString(m.activePage().View())
+	// View contents from active page, or onboarding or the dashboard over it
+	keyMap := combinedKeyMap{pageKeys: m.activePage().KeyMap()}
+	switch {
+	case m.onboarding != nil:
+		b.WriteString(m.onboarding.View())
+		keyMap.pageKeys = m.onboarding.KeyMap()
+	case m.dashboard.IsOpen():
+		b.WriteString(m.dashboard.View())
+		keyMap.pageKeys = m.dashboard.KeyMap()
+	default:
+		b.WriteString(m.activePage().View())
+	}
 	b.WriteString("\n\n")
 
-	// View help
+	// View help, unless hidden
 	if m.width > 0 {
 		contentWidth := max(m.width-pages.DocStyle.GetHorizontalFrameSize(), 0)
 		if contentWidth > 0 {
 			m.help.Width = contentWidth
 		}
 	}
-	keyMap := combinedKeyMap{pageKeys: m.activePage().KeyMap()}
-	b.WriteString(m.help.View(keyMap))
-	b.WriteString("\n\n")
+	if !m.helpHidden {
+		b.WriteString(m.help.View(keyMap))
+		b.WriteString("\n\n")
+	}
 
-	// View tab indicator (paginator)
+	// View tab indicator (paginator), or layout measurements in debug mode.
+	// The debug line takes the paginator's single row so the layout being
+	// inspected doesn't shift.
 	paginatorView := m.paginator.View()
+	if m.integrationBanner != "" {
+		paginatorView = m.integrationBanner
+	}
+	if m.refreshNotice {
+		paginatorView = dimStyle1.Render("refreshed")
+	}
+	if m.debugLayout {
+		paginatorView = m.renderDebugLayout()
+	}
 	if m.width > 0 {
 		contentWidth := max(m.width-pages.DocStyle.GetHorizontalFrameSize(), 0)
 		if contentWidth > 0 {
@@ -382,3 +939,18 @@ func (m AppModel) View() string {
 	}
 	return s.Render(b.String())
 }
+
+// pageChanged lets the previous page wrap up after navigating away from it
+// and initializes the new active page if it hasn't been initialized yet.
+func (m AppModel) pageChanged(prevPage int) tea.Cmd {
+	var leaveCmd tea.Cmd
+	if l, ok := m.pages[prevPage].(pages.Leaver); ok {
+		leaveCmd = l.Leave()
+	}
+	page := m.activePage()
+	if pi, ok := page.(pages.PageInitializer); ok && !m.initialized[page.ID()] {
+		m.initialized[page.ID()] = true
+		return tea.Batch(leaveCmd, pi.InitCmd())
+	}
+	return leaveCmd
+}
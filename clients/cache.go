@@ -0,0 +1,56 @@
+package clients
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheEntry is a cached value with its expiry time.
+type cacheEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// cache is a small concurrency-safe TTL cache keyed by request signature.
+// Fetch commands run on Bubble Tea's goroutines, so access is guarded by a mutex.
+type cache[T any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry[T]
+}
+
+// newCache creates a cache whose entries expire ttl after being stored.
+func newCache[T any](ttl time.Duration) *cache[T] {
+	return &cache[T]{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry[T]),
+	}
+}
+
+// get returns the cached value for key if present and not expired.
+func (c *cache[T]) get(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		delete(c.entries, key)
+		var zero T
+		return zero, false
+	}
+	return e.value, true
+}
+
+// set stores value under key for the cache's TTL.
+func (c *cache[T]) set(key string, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry[T]{value: value, expires: time.Now().Add(c.ttl)}
+}
+
+// clear removes all entries.
+func (c *cache[T]) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
@@ -0,0 +1,110 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// authCheckTimeout bounds the token refresh IsAuthenticated may need.
+const authCheckTimeout = 30 * time.Second
+
+const (
+	maxResponseSize = 10 << 20 // bodies larger than this are truncated before decoding
+	maxSnippetLen   = 200      // runes of a body quoted in an error
+)
+
+// HTTPError is returned when an API responds with an unexpected status.
+type HTTPError struct {
+	Op      string // what was being done, e.g. "token refresh"
+	Status  int
+	Snippet string // start of the response body, whitespace collapsed
+}
+
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("%s failed with status: %d", e.Op, e.Status)
+	if e.Snippet != "" {
+		msg += ": " + e.Snippet
+	}
+	return msg
+}
+
+// ErrRefreshFailed wraps a token refresh that failed in a way that may pass
+// on its own, e.g. a network error or a server error, while the refresh
+// token is still good. The tokens are kept, so the next fetch retries.
+var ErrRefreshFailed = errors.New("token refresh failed, will retry")
+
+// refreshRejected reports whether a token refresh failed because the
+// refresh token itself was refused, so signing in again is the only fix.
+func refreshRejected(err error) bool {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	switch httpErr.Status {
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return true
+	}
+	return false
+}
+
+// DecodeError is returned when a successful response can't be decoded, e.g.
+// because a proxy answered with an HTML page.
+type DecodeError struct {
+	What    string // what was being decoded, e.g. "token response"
+	Status  int
+	Snippet string
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode %s (status %d): %v; body: %q", e.What, e.Status, e.Err, e.Snippet)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// post is http.Post bounded by ctx.
+func post(ctx context.Context, url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return http.DefaultClient.Do(req)
+}
+
+// statusError returns an HTTPError for resp, quoting the start of its body.
+func statusError(op string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxSnippetLen*utf8.UTFMax))
+	return &HTTPError{Op: op, Status: resp.StatusCode, Snippet: snippet(body)}
+}
+
+// decodeJSON reads resp's body and decodes it into v, returning a
+// DecodeError if it isn't the JSON expected.
+func decodeJSON(what string, resp *http.Response, v any) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read %s (status %d): %w", what, resp.StatusCode, err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return &DecodeError{What: what, Status: resp.StatusCode, Snippet: snippet(body), Err: err}
+	}
+	return nil
+}
+
+// snippet returns the start of body on one line, for quoting in errors.
+func snippet(body []byte) string {
+	s := strings.Join(strings.Fields(strings.ToValidUTF8(string(body), "")), " ")
+	if utf8.RuneCountInString(s) <= maxSnippetLen {
+		return s
+	}
+	return string([]rune(s)[:maxSnippetLen]) + "…"
+}
@@ -0,0 +1,15 @@
+package clients
+
+import "stet.codes/tui/logging"
+
+// logger receives diagnostic output from the clients: failed requests and
+// token refreshes. It discards everything until SetLogger is called.
+var logger = logging.Discard()
+
+// SetLogger sets the logger used for client diagnostics.
+func SetLogger(l *logging.Logger) {
+	if l == nil {
+		l = logging.Discard()
+	}
+	logger = l
+}
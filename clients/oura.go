@@ -1,7 +1,8 @@
 package clients
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -9,6 +10,15 @@ import (
 
 const ouraAPIBaseURL = "https://api.ouraring.com/v2"
 
+// Cache lifetimes for Oura responses. Readiness is scored once a day so it
+// can be held longer, and yesterday's no longer changes; heart rate stays
+// under the page's poll interval so polling still picks up new samples.
+const (
+	ouraReadinessCacheTTL         = 10 * time.Minute
+	ouraPreviousReadinessCacheTTL = 6 * time.Hour
+	ouraHeartRateCacheTTL         = 15 * time.Second
+)
+
 // DailyReadiness represents a daily readiness score from the Oura API.
 type DailyReadiness struct {
 	ID                        string       `json:"id"`
@@ -53,34 +63,103 @@ type HeartRateResponse struct {
 
 // OuraClient is a client for the Oura API.
 type OuraClient struct {
-	auth       *OuraAuth
-	httpClient *http.Client
+	auth           *OuraAuth
+	httpClient     *http.Client
+	readinessCache *cache[*DailyReadiness]
+	previousCache  *cache[*DailyReadiness] // yesterday's readiness, nil if none
+	heartRateCache *cache[[]HeartRatePoint]
 }
 
-// NewOuraClient creates a new OuraClient.
-func NewOuraClient(clientID, clientSecret string) *OuraClient {
+// NewOuraClient creates a new OuraClient. See NewOuraAuth for tokenPassphrase.
+func NewOuraClient(clientID, clientSecret, tokenPassphrase string) *OuraClient {
 	return &OuraClient{
-		auth: NewOuraAuth(clientID, clientSecret),
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		auth: NewOuraAuth(clientID, clientSecret, tokenPassphrase),
+		// Requests are bounded by their contexts; see config.ClientTimeouts
+		httpClient:     &http.Client{},
+		readinessCache: newCache[*DailyReadiness](ouraReadinessCacheTTL),
+		previousCache:  newCache[*DailyReadiness](ouraPreviousReadinessCacheTTL),
+		heartRateCache: newCache[[]HeartRatePoint](ouraHeartRateCacheTTL),
 	}
 }
 
+// ClearCache drops cached responses so the next fetch goes to the network.
+func (c *OuraClient) ClearCache() {
+	c.readinessCache.clear()
+	c.previousCache.clear()
+	c.heartRateCache.clear()
+}
+
 // Auth returns the underlying OuraAuth for authentication operations.
 func (c *OuraClient) Auth() *OuraAuth {
 	return c.auth
 }
 
-// IsAuthenticated returns true if valid tokens are available.
+// IsAuthenticated returns true if valid tokens are available, or if
+// refreshing them failed in a way that may pass (see ErrRefreshFailed), so
+// a brief outage doesn't ask for signing in again.
 func (c *OuraClient) IsAuthenticated() bool {
-	tokens, err := c.auth.GetValidTokens()
+	ctx, cancel := context.WithTimeout(context.Background(), authCheckTimeout)
+	defer cancel()
+	tokens, err := c.auth.GetValidTokens(ctx)
+	if errors.Is(err, ErrRefreshFailed) {
+		return true
+	}
 	return err == nil && tokens != nil
 }
 
-// GetTodayReadiness fetches the readiness score for today.
-func (c *OuraClient) GetTodayReadiness() (*DailyReadiness, error) {
-	tokens, err := c.auth.GetValidTokens()
+// GetTodayReadiness returns the readiness score for today, from cache if fresh.
+func (c *OuraClient) GetTodayReadiness(ctx context.Context) (*DailyReadiness, error) {
+	readiness, _, err := c.GetRecentReadiness(ctx)
+	return readiness, err
+}
+
+// GetRecentReadiness returns the readiness scores for today and yesterday,
+// from cache if fresh, e.g. to show what changed overnight. Either is nil
+// if there is no score for that day. Yesterday's is fetched alongside
+// today's the first time and then cached for longer, so polling only asks
+// for today.
+func (c *OuraClient) GetRecentReadiness(ctx context.Context) (today, yesterday *DailyReadiness, err error) {
+	now := time.Now()
+	day, prevDay := now.Format("2006-01-02"), now.AddDate(0, 0, -1).Format("2006-01-02")
+
+	yesterday, haveYesterday := c.previousCache.get("readiness:" + prevDay)
+	if readiness, ok := c.readinessCache.get("readiness:" + day); ok && haveYesterday {
+		logger.Debugf("oura: readiness from cache")
+		return readiness, yesterday, nil
+	}
+
+	start := day
+	if !haveYesterday {
+		start = prevDay
+	}
+	days, err := c.fetchReadiness(ctx, start, day)
+	if err != nil {
+		logger.Warnf("oura: fetch readiness: %v", err)
+		return nil, nil, err
+	}
+	logger.Debugf("oura: fetched readiness from %s", start)
+
+	for i := range days {
+		switch days[i].Day {
+		case day:
+			today = &days[i]
+		case prevDay:
+			if !haveYesterday {
+				yesterday = &days[i]
+			}
+		}
+	}
+	c.readinessCache.set("readiness:"+day, today)
+	if !haveYesterday {
+		c.previousCache.set("readiness:"+prevDay, yesterday)
+	}
+	return today, yesterday, nil
+}
+
+// fetchReadiness fetches the readiness scores for the days from start to
+// end ("YYYY-MM-DD"), oldest first.
+func (c *OuraClient) fetchReadiness(ctx context.Context, start, end string) ([]DailyReadiness, error) {
+	tokens, err := c.auth.GetValidTokens(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get valid tokens: %w", err)
 	}
@@ -88,11 +167,10 @@ func (c *OuraClient) GetTodayReadiness() (*DailyReadiness, error) {
 		return nil, fmt.Errorf("not authenticated")
 	}
 
-	today := time.Now().Format("2006-01-02")
 	url := fmt.Sprintf("%s/usercollection/daily_readiness?start_date=%s&end_date=%s",
-		ouraAPIBaseURL, today, today)
+		ouraAPIBaseURL, start, end)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -107,7 +185,7 @@ func (c *OuraClient) GetTodayReadiness() (*DailyReadiness, error) {
 
 	// Handle 401 - try to refresh and retry once
 	if resp.StatusCode == http.StatusUnauthorized {
-		newTokens, err := c.auth.RefreshTokens(tokens.RefreshToken)
+		newTokens, err := c.auth.RefreshTokens(ctx, tokens.RefreshToken)
 		if err != nil {
 			return nil, fmt.Errorf("token refresh failed: %w", err)
 		}
@@ -129,25 +207,37 @@ func (c *OuraClient) GetTodayReadiness() (*DailyReadiness, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+		return nil, statusError("API request", resp)
 	}
 
 	var readinessResp ReadinessResponse
-	if err := json.NewDecoder(resp.Body).Decode(&readinessResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := decodeJSON("response", resp, &readinessResp); err != nil {
+		return nil, err
 	}
 
-	if len(readinessResp.Data) == 0 {
-		return nil, nil // No data for today yet
-	}
+	return readinessResp.Data, nil
+}
 
-	// Return the most recent readiness score
-	return &readinessResp.Data[len(readinessResp.Data)-1], nil
+// GetTodayHeartRate returns heart rate data for today, from cache if fresh.
+func (c *OuraClient) GetTodayHeartRate(ctx context.Context) ([]HeartRatePoint, error) {
+	key := "heartrate:" + time.Now().Format("2006-01-02")
+	if points, ok := c.heartRateCache.get(key); ok {
+		logger.Debugf("oura: heart rate from cache")
+		return points, nil
+	}
+	points, err := c.fetchTodayHeartRate(ctx)
+	if err != nil {
+		logger.Warnf("oura: fetch heart rate: %v", err)
+		return nil, err
+	}
+	logger.Debugf("oura: fetched %d heart rate points", len(points))
+	c.heartRateCache.set(key, points)
+	return points, nil
 }
 
-// GetTodayHeartRate fetches heart rate data for today.
-func (c *OuraClient) GetTodayHeartRate() ([]HeartRatePoint, error) {
-	tokens, err := c.auth.GetValidTokens()
+// fetchTodayHeartRate fetches heart rate data for today.
+func (c *OuraClient) fetchTodayHeartRate(ctx context.Context) ([]HeartRatePoint, error) {
+	tokens, err := c.auth.GetValidTokens(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get valid tokens: %w", err)
 	}
@@ -162,7 +252,7 @@ func (c *OuraClient) GetTodayHeartRate() ([]HeartRatePoint, error) {
 	url := fmt.Sprintf("%s/usercollection/heartrate?start_datetime=%s&end_datetime=%s",
 		ouraAPIBaseURL, startOfDay.Format(time.RFC3339), now.Format(time.RFC3339))
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -177,7 +267,7 @@ func (c *OuraClient) GetTodayHeartRate() ([]HeartRatePoint, error) {
 
 	// Handle 401 - try to refresh and retry once
 	if resp.StatusCode == http.StatusUnauthorized {
-		newTokens, err := c.auth.RefreshTokens(tokens.RefreshToken)
+		newTokens, err := c.auth.RefreshTokens(ctx, tokens.RefreshToken)
 		if err != nil {
 			return nil, fmt.Errorf("token refresh failed: %w", err)
 		}
@@ -199,12 +289,12 @@ func (c *OuraClient) GetTodayHeartRate() ([]HeartRatePoint, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+		return nil, statusError("API request", resp)
 	}
 
 	var hrResp HeartRateResponse
-	if err := json.NewDecoder(resp.Body).Decode(&hrResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := decodeJSON("response", resp, &hrResp); err != nil {
+		return nil, err
 	}
 
 	return hrResp.Data, nil
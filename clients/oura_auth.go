@@ -2,16 +2,16 @@ package clients
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
-	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strings"
+	"sync"
 	"time"
+
+	"stet.codes/tui/browser"
+	"stet.codes/tui/config"
 )
 
 const (
@@ -43,59 +43,51 @@ func (t *OuraTokens) IsExpired() bool {
 type OuraAuth struct {
 	ClientID     string
 	ClientSecret string
-	tokensPath   string
+	tokens       *tokenFile
+
+	// refreshMu serializes token refreshes so a background refresh and a
+	// fetch don't both spend the same refresh token.
+	refreshMu sync.Mutex
 }
 
-// NewOuraAuth creates a new OuraAuth instance.
-func NewOuraAuth(clientID, clientSecret string) *OuraAuth {
-	tokensPath := os.ExpandEnv("$HOME/.local/share/stet/oura_tokens.json")
+// NewOuraAuth creates a new OuraAuth instance. Tokens are stored
+// encrypted with tokenPassphrase, or in plaintext if it is empty.
+func NewOuraAuth(clientID, clientSecret, tokenPassphrase string) *OuraAuth {
+	tokensPath := filepath.Join(config.DataDir(), "oura_tokens.json")
 	return &OuraAuth{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
-		tokensPath:   tokensPath,
+		tokens:       newTokenFile(tokensPath, tokenPassphrase),
 	}
 }
 
-// LoadTokens loads tokens from disk.
+// LoadTokens loads tokens from disk, decrypting them if they were saved
+// with a passphrase.
 func (a *OuraAuth) LoadTokens() (*OuraTokens, error) {
-	data, err := os.ReadFile(a.tokensPath)
+	var tokens OuraTokens
+	ok, err := a.tokens.load(&tokens)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil // No tokens yet
-		}
-		return nil, fmt.Errorf("failed to read tokens: %w", err)
+		return nil, err
 	}
-
-	var tokens OuraTokens
-	if err := json.Unmarshal(data, &tokens); err != nil {
-		return nil, fmt.Errorf("failed to parse tokens: %w", err)
+	if !ok {
+		return nil, nil // No tokens yet
 	}
-
 	return &tokens, nil
 }
 
-// SaveTokens saves tokens to disk.
+// SaveTokens saves tokens to disk, encrypted if a passphrase is configured.
 func (a *OuraAuth) SaveTokens(tokens *OuraTokens) error {
-	// Ensure directory exists
-	dir := filepath.Dir(a.tokensPath)
-	if err := os.MkdirAll(dir, 0700); err != nil {
-		return fmt.Errorf("failed to create tokens directory: %w", err)
-	}
-
-	data, err := json.MarshalIndent(tokens, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal tokens: %w", err)
-	}
-
-	if err := os.WriteFile(a.tokensPath, data, 0600); err != nil {
-		return fmt.Errorf("failed to write tokens: %w", err)
-	}
-
-	return nil
+	return a.tokens.save(tokens)
 }
 
-// GetValidTokens returns valid tokens, refreshing if necessary.
-func (a *OuraAuth) GetValidTokens() (*OuraTokens, error) {
+// GetValidTokens returns valid tokens, refreshing if necessary. It returns
+// nil tokens when signing in is needed: there are none, or the refresh
+// token was refused. A refresh that fails otherwise returns an error
+// wrapping ErrRefreshFailed and leaves the tokens for the next attempt.
+func (a *OuraAuth) GetValidTokens(ctx context.Context) (*OuraTokens, error) {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
 	tokens, err := a.LoadTokens()
 	if err != nil {
 		return nil, err
@@ -105,10 +97,15 @@ func (a *OuraAuth) GetValidTokens() (*OuraTokens, error) {
 	}
 
 	if tokens.IsExpired() {
-		// Try to refresh
-		newTokens, err := a.RefreshTokens(tokens.RefreshToken)
+		if tokens.RefreshToken == "" {
+			return nil, nil // Nothing to refresh with, need to authenticate
+		}
+		newTokens, err := a.RefreshTokens(ctx, tokens.RefreshToken)
+		if refreshRejected(err) {
+			return nil, nil // Refresh token refused, need to re-authenticate
+		}
 		if err != nil {
-			return nil, nil // Refresh failed, need to re-authenticate
+			return nil, fmt.Errorf("%w: %w", ErrRefreshFailed, err)
 		}
 		return newTokens, nil
 	}
@@ -116,8 +113,31 @@ func (a *OuraAuth) GetValidTokens() (*OuraTokens, error) {
 	return tokens, nil
 }
 
+// RefreshIfNeeded refreshes the stored tokens if they expire within window.
+// It reports whether a refresh happened; having no tokens is not an error.
+func (a *OuraAuth) RefreshIfNeeded(ctx context.Context, window time.Duration) (bool, error) {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
+	tokens, err := a.LoadTokens()
+	if err != nil {
+		return false, err
+	}
+	if tokens == nil || tokens.RefreshToken == "" {
+		return false, nil
+	}
+	if time.Now().Add(window).Before(tokens.ExpiresAt) {
+		return false, nil
+	}
+
+	if _, err := a.RefreshTokens(ctx, tokens.RefreshToken); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // RefreshTokens exchanges a refresh token for new tokens.
-func (a *OuraAuth) RefreshTokens(refreshToken string) (*OuraTokens, error) {
+func (a *OuraAuth) RefreshTokens(ctx context.Context, refreshToken string) (*OuraTokens, error) {
 	data := url.Values{
 		"grant_type":    {"refresh_token"},
 		"refresh_token": {refreshToken},
@@ -125,19 +145,19 @@ func (a *OuraAuth) RefreshTokens(refreshToken string) (*OuraTokens, error) {
 		"client_secret": {a.ClientSecret},
 	}
 
-	resp, err := http.PostForm(ouraTokenURL, data)
+	resp, err := post(ctx, ouraTokenURL, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to refresh tokens: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("token refresh failed with status: %d", resp.StatusCode)
+		return nil, statusError("token refresh", resp)
 	}
 
 	var tokens OuraTokens
-	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
-		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	if err := decodeJSON("token response", resp, &tokens); err != nil {
+		return nil, err
 	}
 
 	// Calculate expiry time
@@ -147,6 +167,7 @@ func (a *OuraAuth) RefreshTokens(refreshToken string) (*OuraTokens, error) {
 	if err := a.SaveTokens(&tokens); err != nil {
 		return nil, err
 	}
+	logger.Infof("oura: refreshed tokens, valid until %s", tokens.ExpiresAt.Format(time.RFC3339))
 
 	return &tokens, nil
 }
@@ -207,7 +228,7 @@ func (a *OuraAuth) StartAuthFlow(ctx context.Context) (<-chan *OuraTokens, <-cha
 		)
 
 		// Open browser
-		if err := openBrowser(authURL); err != nil {
+		if err := browser.Open(authURL); err != nil {
 			errChan <- fmt.Errorf("failed to open browser: %w", err)
 			server.Shutdown(ctx)
 			return
@@ -217,7 +238,7 @@ func (a *OuraAuth) StartAuthFlow(ctx context.Context) (<-chan *OuraTokens, <-cha
 		select {
 		case code := <-codeChan:
 			// Exchange code for tokens
-			tokens, err := a.exchangeCode(code)
+			tokens, err := a.exchangeCode(ctx, code)
 			if err != nil {
 				errChan <- err
 			} else {
@@ -239,7 +260,7 @@ func (a *OuraAuth) StartAuthFlow(ctx context.Context) (<-chan *OuraTokens, <-cha
 }
 
 // exchangeCode exchanges an authorization code for tokens.
-func (a *OuraAuth) exchangeCode(code string) (*OuraTokens, error) {
+func (a *OuraAuth) exchangeCode(ctx context.Context, code string) (*OuraTokens, error) {
 	data := url.Values{
 		"grant_type":    {"authorization_code"},
 		"code":          {code},
@@ -248,19 +269,19 @@ func (a *OuraAuth) exchangeCode(code string) (*OuraTokens, error) {
 		"redirect_uri":  {ouraRedirectURI},
 	}
 
-	resp, err := http.PostForm(ouraTokenURL, data)
+	resp, err := post(ctx, ouraTokenURL, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to exchange code: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("code exchange failed with status: %d", resp.StatusCode)
+		return nil, statusError("code exchange", resp)
 	}
 
 	var tokens OuraTokens
-	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
-		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	if err := decodeJSON("token response", resp, &tokens); err != nil {
+		return nil, err
 	}
 
 	// Calculate expiry time
@@ -270,32 +291,11 @@ func (a *OuraAuth) exchangeCode(code string) (*OuraTokens, error) {
 	if err := a.SaveTokens(&tokens); err != nil {
 		return nil, err
 	}
+	logger.Infof("oura: signed in, tokens valid until %s", tokens.ExpiresAt.Format(time.RFC3339))
 
 	return &tokens, nil
 }
 
-// openBrowser opens the specified URL in the default browser.
-func openBrowser(url string) error {
-	var cmd string
-	var args []string
-
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = "open"
-		args = []string{url}
-	case "linux":
-		cmd = "xdg-open"
-		args = []string{url}
-	case "windows":
-		cmd = "rundll32"
-		args = []string{"url.dll,FileProtocolHandler", url}
-	default:
-		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
-	}
-
-	return exec.Command(cmd, args...).Start()
-}
-
 // HasCredentials returns true if OAuth2 client credentials are configured.
 func (a *OuraAuth) HasCredentials() bool {
 	return a.ClientID != "" && a.ClientSecret != "" &&
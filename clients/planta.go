@@ -2,15 +2,22 @@ package clients
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"sort"
 	"time"
 )
 
 const plantaAPIBaseURL = "https://public.planta-api.com/v1"
 
+// plantaDueTasksCacheTTL is how long due tasks are reused across page visits.
+// Completing an action clears the cache since it changes the schedule.
+const plantaDueTasksCacheTTL = 30 * time.Minute
+
 // ActionType represents the type of plant care action.
 type ActionType string
 
@@ -31,6 +38,34 @@ var CompletableActions = map[ActionType]bool{
 	ActionCleaning:    true,
 }
 
+// ActionFilter selects the action types GetDueTasks returns. An empty
+// Include allows every type; Exclude wins over Include.
+type ActionFilter struct {
+	Include []ActionType
+	Exclude []ActionType
+}
+
+// NewActionFilter returns an ActionFilter for action type names, e.g. from
+// config.Config.PlantaActions.
+func NewActionFilter(include, exclude []string) ActionFilter {
+	var f ActionFilter
+	for _, t := range include {
+		f.Include = append(f.Include, ActionType(t))
+	}
+	for _, t := range exclude {
+		f.Exclude = append(f.Exclude, ActionType(t))
+	}
+	return f
+}
+
+// Allows reports whether tasks of type t pass the filter.
+func (f ActionFilter) Allows(t ActionType) bool {
+	if slices.Contains(f.Exclude, t) {
+		return false
+	}
+	return len(f.Include) == 0 || slices.Contains(f.Include, t)
+}
+
 // ActionSchedule represents a scheduled action for a plant.
 type ActionSchedule struct {
 	Next      *ActionDate `json:"next"`
@@ -97,34 +132,50 @@ type PlantTask struct {
 
 // PlantaClient is a client for the Planta API.
 type PlantaClient struct {
-	auth       *PlantaAuth
-	httpClient *http.Client
+	auth          *PlantaAuth
+	httpClient    *http.Client
+	dueTasksCache *cache[[]PlantTask]
+	actions       ActionFilter
 }
 
-// NewPlantaClient creates a new PlantaClient.
-func NewPlantaClient(appCode string) *PlantaClient {
+// NewPlantaClient creates a new PlantaClient whose due tasks are limited to
+// the action types actions allows. See NewPlantaAuth for tokenPassphrase.
+func NewPlantaClient(appCode, tokenPassphrase string, actions ActionFilter) *PlantaClient {
 	return &PlantaClient{
-		auth: NewPlantaAuth(appCode),
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		auth: NewPlantaAuth(appCode, tokenPassphrase),
+		// Requests are bounded by their contexts; see config.ClientTimeouts
+		httpClient:    &http.Client{},
+		dueTasksCache: newCache[[]PlantTask](plantaDueTasksCacheTTL),
+		actions:       actions,
 	}
 }
 
+// ClearCache drops cached responses so the next fetch goes to the network.
+func (c *PlantaClient) ClearCache() {
+	c.dueTasksCache.clear()
+}
+
 // Auth returns the underlying PlantaAuth for authentication operations.
 func (c *PlantaClient) Auth() *PlantaAuth {
 	return c.auth
 }
 
-// IsAuthenticated returns true if valid tokens are available.
+// IsAuthenticated returns true if valid tokens are available, or if
+// refreshing them failed in a way that may pass (see ErrRefreshFailed), so
+// a brief outage doesn't ask for signing in again.
 func (c *PlantaClient) IsAuthenticated() bool {
-	tokens, err := c.auth.GetValidTokens()
+	ctx, cancel := context.WithTimeout(context.Background(), authCheckTimeout)
+	defer cancel()
+	tokens, err := c.auth.GetValidTokens(ctx)
+	if errors.Is(err, ErrRefreshFailed) {
+		return true
+	}
 	return err == nil && tokens != nil
 }
 
 // EnsureAuthenticated ensures we have valid tokens, exchanging code if needed.
-func (c *PlantaClient) EnsureAuthenticated() error {
-	tokens, err := c.auth.GetValidTokens()
+func (c *PlantaClient) EnsureAuthenticated(ctx context.Context) error {
+	tokens, err := c.auth.GetValidTokens(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get valid tokens: %w", err)
 	}
@@ -137,7 +188,7 @@ func (c *PlantaClient) EnsureAuthenticated() error {
 		return fmt.Errorf("missing PLANTA_APP_CODE")
 	}
 
-	_, err = c.auth.ExchangeCode()
+	_, err = c.auth.ExchangeCode(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to exchange code: %w", err)
 	}
@@ -146,8 +197,8 @@ func (c *PlantaClient) EnsureAuthenticated() error {
 }
 
 // GetAllPlants fetches all plants, handling pagination.
-func (c *PlantaClient) GetAllPlants() ([]Plant, error) {
-	tokens, err := c.auth.GetValidTokens()
+func (c *PlantaClient) GetAllPlants(ctx context.Context) ([]Plant, error) {
+	tokens, err := c.auth.GetValidTokens(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get valid tokens: %w", err)
 	}
@@ -164,7 +215,7 @@ func (c *PlantaClient) GetAllPlants() ([]Plant, error) {
 			url += "?cursor=" + cursor
 		}
 
-		req, err := http.NewRequest("GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
@@ -179,7 +230,7 @@ func (c *PlantaClient) GetAllPlants() ([]Plant, error) {
 
 		// Handle 401 - try to refresh and retry once
 		if resp.StatusCode == http.StatusUnauthorized {
-			newTokens, err := c.auth.RefreshTokens(tokens.RefreshToken)
+			newTokens, err := c.auth.RefreshTokens(ctx, tokens.RefreshToken)
 			if err != nil {
 				return nil, fmt.Errorf("token refresh failed: %w", err)
 			}
@@ -194,12 +245,12 @@ func (c *PlantaClient) GetAllPlants() ([]Plant, error) {
 		}
 
 		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+			return nil, statusError("API request", resp)
 		}
 
 		var plantsResp AddedPlantsResponse
-		if err := json.NewDecoder(resp.Body).Decode(&plantsResp); err != nil {
-			return nil, fmt.Errorf("failed to decode response: %w", err)
+		if err := decodeJSON("response", resp, &plantsResp); err != nil {
+			return nil, err
 		}
 
 		allPlants = append(allPlants, plantsResp.Data...)
@@ -213,9 +264,29 @@ func (c *PlantaClient) GetAllPlants() ([]Plant, error) {
 	return allPlants, nil
 }
 
-// GetDueTasks fetches plants and extracts tasks due within the specified days.
-func (c *PlantaClient) GetDueTasks(withinDays int) ([]PlantTask, error) {
-	plants, err := c.GetAllPlants()
+// GetDueTasks returns tasks due within the specified days, from cache if fresh.
+// The returned slice is a copy and may be modified by the caller.
+func (c *PlantaClient) GetDueTasks(ctx context.Context, withinDays int) ([]PlantTask, error) {
+	key := fmt.Sprintf("due:%s:%d", time.Now().Format("2006-01-02"), withinDays)
+	tasks, ok := c.dueTasksCache.get(key)
+	if !ok {
+		var err error
+		tasks, err = c.fetchDueTasks(ctx, withinDays)
+		if err != nil {
+			logger.Warnf("planta: fetch due tasks: %v", err)
+			return nil, err
+		}
+		logger.Debugf("planta: fetched %d due tasks", len(tasks))
+		c.dueTasksCache.set(key, tasks)
+	} else {
+		logger.Debugf("planta: due tasks from cache")
+	}
+	return slices.Clone(tasks), nil
+}
+
+// fetchDueTasks fetches plants and extracts tasks due within the specified days.
+func (c *PlantaClient) fetchDueTasks(ctx context.Context, withinDays int) ([]PlantTask, error) {
+	plants, err := c.GetAllPlants(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -239,7 +310,7 @@ func (c *PlantaClient) GetDueTasks(withinDays int) ([]PlantTask, error) {
 		}
 
 		for _, as := range actionSchedules {
-			if as.schedule == nil || as.schedule.Next == nil {
+			if as.schedule == nil || as.schedule.Next == nil || !c.actions.Allows(as.actionType) {
 				continue
 			}
 
@@ -287,12 +358,21 @@ func (c *PlantaClient) GetDueTasks(withinDays int) ([]PlantTask, error) {
 }
 
 // CompleteAction marks an action as complete for a plant.
-func (c *PlantaClient) CompleteAction(plantID string, actionType ActionType) error {
+func (c *PlantaClient) CompleteAction(ctx context.Context, plantID string, actionType ActionType) error {
+	if err := c.completeAction(ctx, plantID, actionType); err != nil {
+		logger.Warnf("planta: complete %s for plant %s: %v", actionType, plantID, err)
+		return err
+	}
+	logger.Debugf("planta: completed %s for plant %s", actionType, plantID)
+	return nil
+}
+
+func (c *PlantaClient) completeAction(ctx context.Context, plantID string, actionType ActionType) error {
 	if !CompletableActions[actionType] {
 		return fmt.Errorf("%s cannot be completed via API", actionType)
 	}
 
-	tokens, err := c.auth.GetValidTokens()
+	tokens, err := c.auth.GetValidTokens(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get valid tokens: %w", err)
 	}
@@ -308,7 +388,7 @@ func (c *PlantaClient) CompleteAction(plantID string, actionType ActionType) err
 		return fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -324,7 +404,7 @@ func (c *PlantaClient) CompleteAction(plantID string, actionType ActionType) err
 
 	// Handle 401 - try to refresh and retry once
 	if resp.StatusCode == http.StatusUnauthorized {
-		newTokens, err := c.auth.RefreshTokens(tokens.RefreshToken)
+		newTokens, err := c.auth.RefreshTokens(ctx, tokens.RefreshToken)
 		if err != nil {
 			return fmt.Errorf("token refresh failed: %w", err)
 		}
@@ -338,8 +418,11 @@ func (c *PlantaClient) CompleteAction(plantID string, actionType ActionType) err
 	}
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+		return statusError("API request", resp)
 	}
 
+	// The plant's schedule has changed, so cached due tasks are stale
+	c.ClearCache()
+
 	return nil
 }
@@ -2,13 +2,16 @@ package clients
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
+
+	"stet.codes/tui/config"
 )
 
 const (
@@ -35,59 +38,51 @@ func (t *PlantaTokens) IsExpired() bool {
 
 // PlantaAuth handles authentication for the Planta API.
 type PlantaAuth struct {
-	AppCode    string
-	tokensPath string
+	AppCode string
+	tokens  *tokenFile
+
+	// refreshMu serializes token refreshes so a background refresh and a
+	// fetch don't both spend the same refresh token.
+	refreshMu sync.Mutex
 }
 
-// NewPlantaAuth creates a new PlantaAuth instance.
-func NewPlantaAuth(appCode string) *PlantaAuth {
-	tokensPath := os.ExpandEnv("$HOME/.local/share/stet/planta_tokens.json")
+// NewPlantaAuth creates a new PlantaAuth instance. Tokens are stored
+// encrypted with tokenPassphrase, or in plaintext if it is empty.
+func NewPlantaAuth(appCode, tokenPassphrase string) *PlantaAuth {
+	tokensPath := filepath.Join(config.DataDir(), "planta_tokens.json")
 	return &PlantaAuth{
-		AppCode:    appCode,
-		tokensPath: tokensPath,
+		AppCode: appCode,
+		tokens:  newTokenFile(tokensPath, tokenPassphrase),
 	}
 }
 
-// LoadTokens loads tokens from disk.
+// LoadTokens loads tokens from disk, decrypting them if they were saved
+// with a passphrase.
 func (a *PlantaAuth) LoadTokens() (*PlantaTokens, error) {
-	data, err := os.ReadFile(a.tokensPath)
+	var tokens PlantaTokens
+	ok, err := a.tokens.load(&tokens)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil // No tokens yet
-		}
-		return nil, fmt.Errorf("failed to read tokens: %w", err)
+		return nil, err
 	}
-
-	var tokens PlantaTokens
-	if err := json.Unmarshal(data, &tokens); err != nil {
-		return nil, fmt.Errorf("failed to parse tokens: %w", err)
+	if !ok {
+		return nil, nil // No tokens yet
 	}
-
 	return &tokens, nil
 }
 
-// SaveTokens saves tokens to disk.
+// SaveTokens saves tokens to disk, encrypted if a passphrase is configured.
 func (a *PlantaAuth) SaveTokens(tokens *PlantaTokens) error {
-	// Ensure directory exists
-	dir := filepath.Dir(a.tokensPath)
-	if err := os.MkdirAll(dir, 0700); err != nil {
-		return fmt.Errorf("failed to create tokens directory: %w", err)
-	}
-
-	data, err := json.MarshalIndent(tokens, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal tokens: %w", err)
-	}
-
-	if err := os.WriteFile(a.tokensPath, data, 0600); err != nil {
-		return fmt.Errorf("failed to write tokens: %w", err)
-	}
-
-	return nil
+	return a.tokens.save(tokens)
 }
 
-// GetValidTokens returns valid tokens, refreshing if necessary.
-func (a *PlantaAuth) GetValidTokens() (*PlantaTokens, error) {
+// GetValidTokens returns valid tokens, refreshing if necessary. It returns
+// nil tokens when signing in is needed: there are none, or the refresh
+// token was refused. A refresh that fails otherwise returns an error
+// wrapping ErrRefreshFailed and leaves the tokens for the next attempt.
+func (a *PlantaAuth) GetValidTokens(ctx context.Context) (*PlantaTokens, error) {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
 	tokens, err := a.LoadTokens()
 	if err != nil {
 		return nil, err
@@ -97,10 +92,15 @@ func (a *PlantaAuth) GetValidTokens() (*PlantaTokens, error) {
 	}
 
 	if tokens.IsExpired() {
-		// Try to refresh
-		newTokens, err := a.RefreshTokens(tokens.RefreshToken)
+		if tokens.RefreshToken == "" {
+			return nil, nil // Nothing to refresh with, need to authenticate
+		}
+		newTokens, err := a.RefreshTokens(ctx, tokens.RefreshToken)
+		if refreshRejected(err) {
+			return nil, nil // Refresh token refused, need to re-authenticate
+		}
 		if err != nil {
-			return nil, nil // Refresh failed, need to re-authenticate
+			return nil, fmt.Errorf("%w: %w", ErrRefreshFailed, err)
 		}
 		return newTokens, nil
 	}
@@ -120,26 +120,26 @@ type plantaAuthResponse struct {
 }
 
 // ExchangeCode exchanges the app code for tokens.
-func (a *PlantaAuth) ExchangeCode() (*PlantaTokens, error) {
+func (a *PlantaAuth) ExchangeCode(ctx context.Context) (*PlantaTokens, error) {
 	body := map[string]string{"code": a.AppCode}
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	resp, err := http.Post(plantaAuthURL, "application/json", bytes.NewBuffer(jsonBody))
+	resp, err := post(ctx, plantaAuthURL, "application/json", bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to exchange code: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("code exchange failed with status: %d", resp.StatusCode)
+		return nil, statusError("code exchange", resp)
 	}
 
 	var authResp plantaAuthResponse
-	if err := json.NewDecoder(resp.Body).Decode(&authResp); err != nil {
-		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	if err := decodeJSON("token response", resp, &authResp); err != nil {
+		return nil, err
 	}
 
 	// Parse expiry time
@@ -160,31 +160,55 @@ func (a *PlantaAuth) ExchangeCode() (*PlantaTokens, error) {
 	if err := a.SaveTokens(tokens); err != nil {
 		return nil, err
 	}
+	logger.Infof("planta: signed in, tokens valid until %s", tokens.ExpiresAt.Format(time.RFC3339))
 
 	return tokens, nil
 }
 
+// RefreshIfNeeded refreshes the stored tokens if they expire within window.
+// It reports whether a refresh happened; having no tokens is not an error.
+func (a *PlantaAuth) RefreshIfNeeded(ctx context.Context, window time.Duration) (bool, error) {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
+	tokens, err := a.LoadTokens()
+	if err != nil {
+		return false, err
+	}
+	if tokens == nil || tokens.RefreshToken == "" {
+		return false, nil
+	}
+	if time.Now().Add(window).Before(tokens.ExpiresAt) {
+		return false, nil
+	}
+
+	if _, err := a.RefreshTokens(ctx, tokens.RefreshToken); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // RefreshTokens exchanges a refresh token for new tokens.
-func (a *PlantaAuth) RefreshTokens(refreshToken string) (*PlantaTokens, error) {
+func (a *PlantaAuth) RefreshTokens(ctx context.Context, refreshToken string) (*PlantaTokens, error) {
 	body := map[string]string{"refreshToken": refreshToken}
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	resp, err := http.Post(plantaRefreshURL, "application/json", bytes.NewBuffer(jsonBody))
+	resp, err := post(ctx, plantaRefreshURL, "application/json", bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to refresh tokens: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("token refresh failed with status: %d", resp.StatusCode)
+		return nil, statusError("token refresh", resp)
 	}
 
 	var authResp plantaAuthResponse
-	if err := json.NewDecoder(resp.Body).Decode(&authResp); err != nil {
-		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	if err := decodeJSON("token response", resp, &authResp); err != nil {
+		return nil, err
 	}
 
 	// Parse expiry time
@@ -204,6 +228,7 @@ func (a *PlantaAuth) RefreshTokens(refreshToken string) (*PlantaTokens, error) {
 	if err := a.SaveTokens(tokens); err != nil {
 		return nil, err
 	}
+	logger.Infof("planta: refreshed tokens, valid until %s", tokens.ExpiresAt.Format(time.RFC3339))
 
 	return tokens, nil
 }
@@ -0,0 +1,62 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNotAuthenticated is returned by Probe when no usable tokens are stored.
+var ErrNotAuthenticated = errors.New("not authenticated")
+
+// Probe checks that the stored Oura tokens are accepted by the API with a
+// single lightweight request. It does not retry or touch the response caches.
+func (c *OuraClient) Probe(ctx context.Context) error {
+	tokens, err := c.auth.GetValidTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get valid tokens: %w", err)
+	}
+	if tokens == nil {
+		return ErrNotAuthenticated
+	}
+	return probe(ctx, c.httpClient, ouraAPIBaseURL+"/usercollection/personal_info", tokens.AccessToken)
+}
+
+// Probe checks that the stored Planta tokens are accepted by the API with a
+// single lightweight request. Unlike EnsureAuthenticated it never exchanges
+// the app code, so it has no side effects.
+func (c *PlantaClient) Probe(ctx context.Context) error {
+	tokens, err := c.auth.GetValidTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get valid tokens: %w", err)
+	}
+	if tokens == nil {
+		return ErrNotAuthenticated
+	}
+	return probe(ctx, c.httpClient, plantaAPIBaseURL+"/addedPlants", tokens.AccessToken)
+}
+
+// probe issues an authorized GET and maps the status to an error.
+func probe(ctx context.Context, client *http.Client, url, accessToken string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized:
+		return ErrNotAuthenticated
+	default:
+		return statusError("API request", resp)
+	}
+}
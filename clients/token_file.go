@@ -0,0 +1,187 @@
+package clients
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Token files are plain JSON unless a passphrase is configured, in which
+// case they are sealed with AES-256-GCM under a key derived from it with
+// PBKDF2. A plaintext file is still read with a passphrase set and is
+// encrypted the next time tokens are saved.
+
+const (
+	tokenFileVersion    = 1
+	tokenFileIterations = 600_000
+	tokenFileSaltSize   = 16
+)
+
+// ErrTokensEncrypted is returned when loading an encrypted token file
+// without a passphrase.
+var ErrTokensEncrypted = errors.New("tokens are encrypted; set STET_TOKEN_PASSPHRASE")
+
+// ErrTokensWrongPassphrase is returned when an encrypted token file can't be
+// opened with the configured passphrase, or has been tampered with.
+var ErrTokensWrongPassphrase = errors.New("tokens could not be decrypted; wrong passphrase or corrupted file")
+
+// encryptedTokenFile is the on-disk form of an encrypted token file.
+type encryptedTokenFile struct {
+	Version    int    `json:"stet_encrypted_tokens"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// tokenFile reads and writes a token file, encrypting it when passphrase
+// is set.
+type tokenFile struct {
+	path       string
+	passphrase string
+
+	// Deriving a key is deliberately slow, so the last one is kept for
+	// loads and saves that reuse the file's salt.
+	mu      sync.Mutex
+	keySalt []byte
+	key     []byte
+}
+
+func newTokenFile(path, passphrase string) *tokenFile {
+	return &tokenFile{path: path, passphrase: passphrase}
+}
+
+// load decodes the file into v. It reports false if the file doesn't exist.
+func (f *tokenFile) load(v any) (bool, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read tokens: %w", err)
+	}
+
+	var sealed encryptedTokenFile
+	if err := json.Unmarshal(data, &sealed); err == nil && sealed.Version != 0 {
+		if data, err = f.open(sealed); err != nil {
+			return false, err
+		}
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("failed to parse tokens: %w", err)
+	}
+	return true, nil
+}
+
+// save writes v to the file, encrypted if a passphrase is set.
+func (f *tokenFile) save(v any) error {
+	// Ensure directory exists
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create tokens directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tokens: %w", err)
+	}
+
+	if f.passphrase != "" {
+		if data, err = f.seal(data); err != nil {
+			return fmt.Errorf("failed to encrypt tokens: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(f.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write tokens: %w", err)
+	}
+	return nil
+}
+
+func (f *tokenFile) open(sealed encryptedTokenFile) ([]byte, error) {
+	if f.passphrase == "" {
+		return nil, ErrTokensEncrypted
+	}
+	if sealed.Version != tokenFileVersion {
+		return nil, fmt.Errorf("unsupported encrypted token file version %d", sealed.Version)
+	}
+	gcm, err := f.cipher(sealed.Salt, sealed.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.Nonce) != gcm.NonceSize() {
+		return nil, ErrTokensWrongPassphrase
+	}
+	data, err := gcm.Open(nil, sealed.Nonce, sealed.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrTokensWrongPassphrase
+	}
+	return data, nil
+}
+
+func (f *tokenFile) seal(data []byte) ([]byte, error) {
+	f.mu.Lock()
+	salt := f.keySalt
+	f.mu.Unlock()
+	if salt == nil {
+		salt = make([]byte, tokenFileSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+	}
+
+	gcm, err := f.cipher(salt, tokenFileIterations)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(encryptedTokenFile{
+		Version:    tokenFileVersion,
+		Iterations: tokenFileIterations,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, data, nil),
+	}, "", "  ")
+}
+
+// cipher returns an AES-GCM cipher keyed from the passphrase and salt.
+func (f *tokenFile) cipher(salt []byte, iterations int) (cipher.AEAD, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.key == nil || string(salt) != string(f.keySalt) || iterations != tokenFileIterations {
+		if iterations <= 0 || len(salt) == 0 {
+			return nil, ErrTokensWrongPassphrase
+		}
+		key, err := pbkdf2.Key(sha256.New, f.passphrase, salt, iterations, 32)
+		if err != nil {
+			return nil, err
+		}
+		if iterations != tokenFileIterations {
+			// Not reusable for saving, which always uses the current count
+			return newGCM(key)
+		}
+		f.key, f.keySalt = key, salt
+	}
+	return newGCM(f.key)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,98 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// The completion webhook posts each task completion to a URL the user
+// configured, e.g. a personal API or a Home Assistant webhook, so simple
+// automations can follow along. It is fire-and-forget: failures are logged
+// and never reach the UI.
+
+const (
+	webhookTimeout  = 5 * time.Second // per attempt
+	webhookAttempts = 3
+	webhookBackoff  = time.Second // before the second attempt, doubling after
+)
+
+// CompletionEvent is the JSON body posted when a task is completed or
+// uncompleted.
+type CompletionEvent struct {
+	TaskID    string `json:"task_id"`
+	Title     string `json:"title"`
+	Date      string `json:"date"` // "YYYY-MM-DD", the day the completion is for
+	Completed bool   `json:"completed"`
+}
+
+// Webhook posts CompletionEvents to a URL. The zero value, or one with no
+// URL, is disabled.
+type Webhook struct {
+	url string
+}
+
+// NewWebhook returns a webhook posting to url; "" disables it.
+func NewWebhook(url string) *Webhook {
+	return &Webhook{url: url}
+}
+
+// Enabled reports whether a URL is configured.
+func (w *Webhook) Enabled() bool {
+	return w != nil && w.url != ""
+}
+
+// PostCompletion posts event, trying up to webhookAttempts times while the
+// request fails on the network or with a server error. A client error, e.g.
+// 404, is not retried.
+func (w *Webhook) PostCompletion(ctx context.Context, event CompletionEvent) error {
+	if !w.Enabled() {
+		return nil
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	backoff := webhookBackoff
+	for attempt := 1; ; attempt++ {
+		err = w.post(ctx, body)
+		var httpErr *HTTPError
+		if err == nil || attempt == webhookAttempts ||
+			(errors.As(err, &httpErr) && httpErr.Status < http.StatusInternalServerError) {
+			return err
+		}
+		logger.Debugf("webhook: attempt %d failed, retrying: %v", attempt, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (w *Webhook) post(ctx context.Context, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+	resp, err := post(ctx, w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// url.Error quotes the URL, which may hold a secret, e.g. a Home
+		// Assistant webhook id; keep it out of errors and the log
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("completion webhook: %s: %w", urlErr.Op, urlErr.Err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return statusError("completion webhook", resp)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+
+	"stet.codes/tui/config"
+	"stet.codes/tui/sound"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// completionSoundCmd plays the configured completion sound, or the milestone
+// one. Failures, e.g. no sound player installed, are only logged.
+func (m AppModel) completionSoundCmd(milestone bool) tea.Cmd {
+	mode, logger := m.completionSound, m.logger
+	if mode == config.CompletionSoundOff {
+		return nil
+	}
+	event := sound.Complete
+	if milestone {
+		event = sound.Milestone
+	}
+	return func() tea.Msg {
+		var err error
+		if mode == config.CompletionSoundBell {
+			err = sound.Bell(os.Stdout)
+		} else {
+			err = sound.Play(event)
+		}
+		if err != nil {
+			logger.Warnf("completion sound: %v", err)
+		}
+		return nil
+	}
+}
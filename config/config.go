@@ -0,0 +1,638 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"stet.codes/tui/logging"
+)
+
+// CompletedOrder controls how completed tasks are ordered on the Today page.
+type CompletedOrder string
+
+const (
+	// CompletedOrderCreated keeps completed tasks in creation order.
+	CompletedOrderCreated CompletedOrder = "created"
+	// CompletedOrderRecentLast puts the most recently completed task last.
+	CompletedOrderRecentLast CompletedOrder = "recent-last"
+	// CompletedOrderRecentFirst puts the most recently completed task first.
+	CompletedOrderRecentFirst CompletedOrder = "recent-first"
+)
+
+// HeatmapPalette selects the glyphs and colors of the History heatmap.
+type HeatmapPalette string
+
+const (
+	// HeatmapPaletteDefault draws green filled and grey hollow squares.
+	HeatmapPaletteDefault HeatmapPalette = "default"
+	// HeatmapPaletteBlueOrange uses blue and orange, which remain distinct
+	// for red-green color blindness.
+	HeatmapPaletteBlueOrange HeatmapPalette = "blue-orange"
+	// HeatmapPaletteShapes uses dots and brightness only, no hue.
+	HeatmapPaletteShapes HeatmapPalette = "shapes"
+)
+
+// Bars selects the characters bars and gauges are drawn with.
+type Bars string
+
+const (
+	// BarsBlocks draws with block characters, filling part of a cell where
+	// the value falls between cells.
+	BarsBlocks Bars = "blocks"
+	// BarsASCII draws with # and -, for terminals or fonts without the
+	// block characters.
+	BarsASCII Bars = "ascii"
+)
+
+// LastUpdated selects how integration pages show when data was fetched.
+type LastUpdated string
+
+const (
+	// LastUpdatedRelative shows a live age, e.g. "Updated 2m 05s ago".
+	LastUpdatedRelative LastUpdated = "relative"
+	// LastUpdatedAbsolute shows the clock time of the fetch.
+	LastUpdatedAbsolute LastUpdated = "absolute"
+)
+
+// FocusLayout selects how the History focus view shows a task's year.
+type FocusLayout string
+
+const (
+	// FocusLayoutCalendar draws a calendar heatmap of weeks by weekday.
+	FocusLayoutCalendar FocusLayout = "calendar"
+	// FocusLayoutList lists each day, newest first, with its status.
+	FocusLayoutList FocusLayout = "list"
+)
+
+// StartupCheck selects what the startup integration check does.
+type StartupCheck string
+
+const (
+	// StartupCheckOff skips the check.
+	StartupCheckOff StartupCheck = "off"
+	// StartupCheckLog probes each integration and logs the results.
+	StartupCheckLog StartupCheck = "log"
+	// StartupCheckBanner also shows the results in a banner at startup.
+	StartupCheckBanner StartupCheck = "banner"
+)
+
+// LogLevel is the least important kind of line written to the log file.
+type LogLevel string
+
+const (
+	// LogLevelDebug also logs detail such as each fetch and cache hit.
+	LogLevelDebug LogLevel = "debug"
+	// LogLevelInfo logs routine events such as migrations and token refreshes.
+	LogLevelInfo LogLevel = "info"
+	// LogLevelWarn logs only failures the app recovers from, and errors.
+	LogLevelWarn LogLevel = "warn"
+	// LogLevelError logs only failures that lose data or break a feature.
+	LogLevelError LogLevel = "error"
+)
+
+// Level returns l as a logging.Level.
+func (l LogLevel) Level() logging.Level {
+	switch l {
+	case LogLevelDebug:
+		return logging.LevelDebug
+	case LogLevelWarn:
+		return logging.LevelWarn
+	case LogLevelError:
+		return logging.LevelError
+	}
+	return logging.LevelInfo
+}
+
+// JournalEntries selects how the Journal page stores entries.
+type JournalEntries string
+
+const (
+	// JournalEntriesDaily keeps a single entry per day that is edited in place.
+	JournalEntriesDaily JournalEntries = "daily"
+	// JournalEntriesTimestamped allows several timestamped notes per day; the
+	// Journal page can start a new note instead of editing the last one.
+	JournalEntriesTimestamped JournalEntries = "timestamped"
+)
+
+// JournalStartMode selects the mode the Journal page opens in.
+type JournalStartMode string
+
+const (
+	// JournalStartView opens read-only; ctrl+v starts editing.
+	JournalStartView JournalStartMode = "view"
+	// JournalStartNormal opens in vim normal mode.
+	JournalStartNormal JournalStartMode = "normal"
+	// JournalStartInsert opens in vim insert mode, ready to type.
+	JournalStartInsert JournalStartMode = "insert"
+)
+
+// ChartStyle selects how the Oura heart rate chart is drawn.
+type ChartStyle string
+
+const (
+	// ChartStyleBraille draws a high-resolution line with braille dots.
+	ChartStyleBraille ChartStyle = "braille"
+	// ChartStyleLines draws with box-drawing line characters, for fonts
+	// that render braille poorly.
+	ChartStyleLines ChartStyle = "lines"
+	// ChartStylePoints marks each sample with a dot and no connecting line.
+	ChartStylePoints ChartStyle = "points"
+)
+
+// Contributors selects how the Oura readiness contributors are shown.
+type Contributors string
+
+const (
+	// ContributorsNumbers lists each contributor's 0-100 score.
+	ContributorsNumbers Contributors = "numbers"
+	// ContributorsBars draws each score as a bar colored by band.
+	ContributorsBars Contributors = "bars"
+)
+
+// TaskDelete selects what deleting a task on the Task Config page does.
+type TaskDelete string
+
+const (
+	// TaskDeleteSoft hides the task but keeps it and its history in the
+	// database.
+	TaskDeleteSoft TaskDelete = "soft"
+	// TaskDeleteHard permanently removes the task and all of its history.
+	TaskDeleteHard TaskDelete = "hard"
+)
+
+// CompletionSound selects what plays when a task is completed on the Today
+// page.
+type CompletionSound string
+
+const (
+	// CompletionSoundOff plays nothing.
+	CompletionSoundOff CompletionSound = "off"
+	// CompletionSoundBell rings the terminal bell.
+	CompletionSoundBell CompletionSound = "bell"
+	// CompletionSoundSystem plays a short system sound, with a different
+	// one for streak milestones.
+	CompletionSoundSystem CompletionSound = "sound"
+)
+
+// HelpBar is how much of the key help is shown below the page.
+type HelpBar string
+
+const (
+	// HelpBarShort shows the page's keys on one line.
+	HelpBarShort HelpBar = "short"
+	// HelpBarFull also lists the global keys, on a second line.
+	HelpBarFull HelpBar = "full"
+	// HelpBarHidden shows no help, giving its lines to the page.
+	HelpBarHidden HelpBar = "hidden"
+)
+
+// WeekStart is the first day of the week in calendars.
+type WeekStart string
+
+const (
+	// WeekStartMonday starts weeks on Monday.
+	WeekStartMonday WeekStart = "monday"
+	// WeekStartSunday starts weeks on Sunday.
+	WeekStartSunday WeekStart = "sunday"
+)
+
+// Weekday returns w as a time.Weekday.
+func (w WeekStart) Weekday() time.Weekday {
+	if w == WeekStartSunday {
+		return time.Sunday
+	}
+	return time.Monday
+}
+
+// DateFormats holds the Go time layouts (see time.Layout) used to show dates
+// and times.
+type DateFormats struct {
+	// DateDay is for a month and day alone, e.g. "Jan 2" for the end of a
+	// pause or an overdue plant.
+	DateDay string
+	// DateShort is for dates in lists and status lines, e.g. "Mon Jan 2".
+	DateShort string
+	// DateMedium is for a single day with its year, e.g. "Mon Jan 2, 2006"
+	// in History's focus view.
+	DateMedium string
+	// DateLong is for headings, e.g. "Monday, January 2, 2006".
+	DateLong string
+	// Time is for clock times, e.g. "15:04:05".
+	Time string
+	// TimeShort is for clock times to the minute, e.g. "15:04" beside
+	// journal notes and in the activity feed.
+	TimeShort string
+}
+
+// ClientTimeouts bound how long a fetch from an integration may take,
+// including every page of results and any token refresh it needs.
+type ClientTimeouts struct {
+	// Interactive applies while someone waits on the result, e.g. a manual
+	// refresh or completing a Planta task.
+	Interactive time.Duration
+	// Background applies to the initial load and polls.
+	Background time.Duration
+}
+
+// For returns the timeout for an interactive or background fetch.
+func (t ClientTimeouts) For(interactive bool) time.Duration {
+	if interactive {
+		return t.Interactive
+	}
+	return t.Background
+}
+
+// Config holds user-tunable settings. Values are read from STET_* environment
+// variables, which can be set in the .env file next to the binary.
+type Config struct {
+	// KeepCompletedInPlace leaves completed tasks where they are on the Today
+	// page instead of moving them to the bottom until the next reload.
+	KeepCompletedInPlace bool
+
+	// CompletedOrder orders the completed group on the Today page.
+	CompletedOrder CompletedOrder
+
+	// StreakAtRiskMin is the shortest streak the Today page flags as at
+	// risk while its task is incomplete. Zero turns the flag off.
+	StreakAtRiskMin int
+
+	// StreakAtRiskFirst sorts tasks with a streak at risk to the top of
+	// the Today page.
+	StreakAtRiskFirst bool
+
+	// IdleRefreshAfter reloads the active page on the first keypress after
+	// this long without input. Zero disables idle refresh.
+	IdleRefreshAfter time.Duration
+
+	// HeatmapPalette selects the History heatmap glyphs and colors.
+	HeatmapPalette HeatmapPalette
+
+	// LastUpdated selects relative or absolute fetch times on Oura and Planta.
+	LastUpdated LastUpdated
+
+	// HistoryIncludeToday starts the History heatmap at today instead of
+	// yesterday, so it agrees with the Today page.
+	HistoryIncludeToday bool
+
+	// HistoryMatchToday orders History's tasks the way the Today page
+	// orders them, instead of by creation.
+	HistoryMatchToday bool
+
+	// HistoryMaxDays caps how many days the History table shows, and so
+	// loads, however wide the terminal. Between 7 and 365.
+	HistoryMaxDays int
+
+	// HistoryFocusLayout is how the History focus view first shows a task;
+	// v switches layouts.
+	HistoryFocusLayout FocusLayout
+
+	// StartupCheck probes Oura and Planta credentials at startup instead of
+	// waiting for their pages to be visited.
+	StartupCheck StartupCheck
+
+	// LogLevel is how much goes to the log file.
+	LogLevel LogLevel
+
+	// JournalEntries selects one entry per day or timestamped notes.
+	JournalEntries JournalEntries
+
+	// JournalStartMode is the mode the Journal page opens in.
+	JournalStartMode JournalStartMode
+
+	// HeartRateChartHeight is the preferred height of the Oura heart rate
+	// chart in rows. It shrinks when the terminal is too short.
+	HeartRateChartHeight int
+
+	// HeartRateChartStyle selects how the heart rate chart is drawn.
+	HeartRateChartStyle ChartStyle
+
+	// Contributors selects numbers or bars for the readiness contributors.
+	Contributors Contributors
+
+	// DoNotDisturbFor is how long do not disturb stays on once toggled.
+	// Zero keeps it on until toggled off again.
+	DoNotDisturbFor time.Duration
+
+	// TaskDelete selects soft-deleting tasks or purging them with their
+	// history.
+	TaskDelete TaskDelete
+
+	// WeekStart is the first day of the week in the Journal and History
+	// calendars.
+	WeekStart WeekStart
+
+	// HomeZone is the time zone days are counted in for tasks and the
+	// journal, so they stay on home time while travelling. Defaults to the
+	// system zone.
+	HomeZone *time.Location
+
+	// JournalAutosaveDelay is how long the Journal waits after the last edit
+	// before saving. At least MinJournalAutosaveDelay.
+	JournalAutosaveDelay time.Duration
+
+	// Formats are the date and time layouts used across the pages.
+	Formats DateFormats
+
+	// OuraTimeouts and PlantaTimeouts bound requests to each integration.
+	OuraTimeouts   ClientTimeouts
+	PlantaTimeouts ClientTimeouts
+
+	// Celebrate shows a moment of confetti on the Today page when the last
+	// task is completed.
+	Celebrate bool
+
+	// CompletionSound plays when a task is completed on the Today page and,
+	// distinctly where possible, when its streak reaches 7, 30 or 100 days.
+	// Do not disturb silences it.
+	CompletionSound CompletionSound
+
+	// Bars selects block characters or ASCII for bars and gauges.
+	Bars Bars
+
+	// HelpBar is how the key help starts out on first run. After that, the
+	// last state chosen with ? or H is remembered instead.
+	HelpBar HelpBar
+
+	// PlantaActions limits Planta tasks to these action types; empty allows
+	// all of them. PlantaSkipActions hides types, e.g. progressUpdate, and
+	// wins over PlantaActions.
+	PlantaActions     []string
+	PlantaSkipActions []string
+
+	// WebhookURL receives a POST for each task completed or uncompleted on
+	// the Today page or the Journal checklist; empty disables it.
+	WebhookURL string
+}
+
+// plantaActionTypes are the action types PlantaActions and PlantaSkipActions
+// accept, as the Planta API names them.
+var plantaActionTypes = []string{"watering", "fertilizing", "misting", "cleaning", "repotting", "progressUpdate"}
+
+// MinJournalAutosaveDelay is the shortest accepted JournalAutosaveDelay.
+const MinJournalAutosaveDelay = 50 * time.Millisecond
+
+// Default returns the configuration used when no overrides are set.
+func Default() Config {
+	return Config{
+		KeepCompletedInPlace: false,
+		CompletedOrder:       CompletedOrderCreated,
+		StreakAtRiskMin:      3,
+		StreakAtRiskFirst:    false,
+		IdleRefreshAfter:     0,
+		HeatmapPalette:       HeatmapPaletteDefault,
+		LastUpdated:          LastUpdatedRelative,
+		HistoryIncludeToday:  false,
+		HistoryMatchToday:    false,
+		HistoryMaxDays:       90,
+		HistoryFocusLayout:   FocusLayoutCalendar,
+		StartupCheck:         StartupCheckOff,
+		LogLevel:             LogLevelInfo,
+		JournalEntries:       JournalEntriesDaily,
+		JournalStartMode:     JournalStartView,
+		HeartRateChartHeight: 8,
+		HeartRateChartStyle:  ChartStyleBraille,
+		Contributors:         ContributorsNumbers,
+		DoNotDisturbFor:      time.Hour,
+		TaskDelete:           TaskDeleteSoft,
+		WeekStart:            WeekStartMonday,
+		HomeZone:             time.Local,
+		JournalAutosaveDelay: 500 * time.Millisecond,
+		Formats: DateFormats{
+			DateDay:    "Jan 2",
+			DateShort:  "Mon Jan 2",
+			DateMedium: "Mon Jan 2, 2006",
+			DateLong:   "Monday, January 2, 2006",
+			Time:       "15:04:05",
+			TimeShort:  "15:04",
+		},
+		OuraTimeouts:    ClientTimeouts{Interactive: 10 * time.Second, Background: 30 * time.Second},
+		PlantaTimeouts:  ClientTimeouts{Interactive: 15 * time.Second, Background: time.Minute},
+		Celebrate:       true,
+		CompletionSound: CompletionSoundOff,
+		HelpBar:         HelpBarShort,
+		Bars:            BarsBlocks,
+	}
+}
+
+// Load returns the default configuration with the config file (see
+// ConfigFile) and then environment overrides applied. Invalid values and
+// unknown keys are reported in the returned error and left at their
+// defaults, so callers can log the error and carry on with a usable Config.
+func Load() (Config, error) {
+	cfg := Default()
+	var errs []error
+
+	values, err := readConfigFile(ConfigFile())
+	if err != nil {
+		errs = append(errs, err)
+	}
+	fileValues = values
+	defer func() { fileValues = nil }()
+
+	envBool(&cfg.KeepCompletedInPlace, "STET_KEEP_COMPLETED_IN_PLACE", &errs)
+	envEnum(&cfg.CompletedOrder, "STET_COMPLETED_ORDER", &errs,
+		CompletedOrderCreated, CompletedOrderRecentLast, CompletedOrderRecentFirst)
+	envInt(&cfg.StreakAtRiskMin, "STET_STREAK_AT_RISK_MIN", &errs, 0, 365)
+	envBool(&cfg.StreakAtRiskFirst, "STET_STREAK_AT_RISK_FIRST", &errs)
+	envDuration(&cfg.IdleRefreshAfter, "STET_IDLE_REFRESH_AFTER", &errs)
+	envEnum(&cfg.HeatmapPalette, "STET_HEATMAP_PALETTE", &errs,
+		HeatmapPaletteDefault, HeatmapPaletteBlueOrange, HeatmapPaletteShapes)
+	envEnum(&cfg.LastUpdated, "STET_LAST_UPDATED", &errs,
+		LastUpdatedRelative, LastUpdatedAbsolute)
+	envBool(&cfg.HistoryIncludeToday, "STET_HISTORY_INCLUDE_TODAY", &errs)
+	envBool(&cfg.HistoryMatchToday, "STET_HISTORY_MATCH_TODAY", &errs)
+	envInt(&cfg.HistoryMaxDays, "STET_HISTORY_MAX_DAYS", &errs, 7, 365)
+	envEnum(&cfg.HistoryFocusLayout, "STET_HISTORY_FOCUS_LAYOUT", &errs,
+		FocusLayoutCalendar, FocusLayoutList)
+	envEnum(&cfg.StartupCheck, "STET_STARTUP_CHECK", &errs,
+		StartupCheckOff, StartupCheckLog, StartupCheckBanner)
+	envEnum(&cfg.LogLevel, "STET_LOG_LEVEL", &errs,
+		LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError)
+	envEnum(&cfg.JournalEntries, "STET_JOURNAL_ENTRIES", &errs,
+		JournalEntriesDaily, JournalEntriesTimestamped)
+	envEnum(&cfg.JournalStartMode, "STET_JOURNAL_START_MODE", &errs,
+		JournalStartView, JournalStartNormal, JournalStartInsert)
+	envInt(&cfg.HeartRateChartHeight, "STET_HR_CHART_HEIGHT", &errs, 3, 40)
+	envEnum(&cfg.HeartRateChartStyle, "STET_HR_CHART_STYLE", &errs,
+		ChartStyleBraille, ChartStyleLines, ChartStylePoints)
+	envEnum(&cfg.Contributors, "STET_OURA_CONTRIBUTORS", &errs, ContributorsNumbers, ContributorsBars)
+	envDuration(&cfg.DoNotDisturbFor, "STET_DND_DURATION", &errs)
+	envEnum(&cfg.TaskDelete, "STET_TASK_DELETE", &errs, TaskDeleteSoft, TaskDeleteHard)
+	envEnum(&cfg.WeekStart, "STET_WEEK_START", &errs, WeekStartMonday, WeekStartSunday)
+	envLocation(&cfg.HomeZone, "STET_HOME_TIMEZONE", &errs)
+	envBool(&cfg.Celebrate, "STET_CELEBRATE", &errs)
+	envEnum(&cfg.CompletionSound, "STET_COMPLETION_SOUND", &errs,
+		CompletionSoundOff, CompletionSoundBell, CompletionSoundSystem)
+	envEnum(&cfg.HelpBar, "STET_HELP_BAR", &errs, HelpBarShort, HelpBarFull, HelpBarHidden)
+	envEnum(&cfg.Bars, "STET_BARS", &errs, BarsBlocks, BarsASCII)
+	envTimeout(&cfg.OuraTimeouts.Background, "STET_OURA_TIMEOUT", &errs)
+	envTimeout(&cfg.OuraTimeouts.Interactive, "STET_OURA_REFRESH_TIMEOUT", &errs)
+	envTimeout(&cfg.PlantaTimeouts.Background, "STET_PLANTA_TIMEOUT", &errs)
+	envTimeout(&cfg.PlantaTimeouts.Interactive, "STET_PLANTA_REFRESH_TIMEOUT", &errs)
+	envList(&cfg.PlantaActions, "STET_PLANTA_ACTIONS", &errs, plantaActionTypes...)
+	envList(&cfg.PlantaSkipActions, "STET_PLANTA_SKIP_ACTIONS", &errs, plantaActionTypes...)
+	envURL(&cfg.WebhookURL, "STET_WEBHOOK_URL", &errs)
+
+	autosave := cfg.JournalAutosaveDelay
+	envDuration(&autosave, "STET_JOURNAL_AUTOSAVE_DELAY", &errs)
+	if autosave < MinJournalAutosaveDelay {
+		errs = append(errs, fmt.Errorf("STET_JOURNAL_AUTOSAVE_DELAY: %v is below the minimum of %v",
+			autosave, MinJournalAutosaveDelay))
+	} else {
+		cfg.JournalAutosaveDelay = autosave
+	}
+
+	// A layout must change with the day (or time) it formats; anything else,
+	// e.g. a strftime-style "%d/%m", has no layout elements Go recognizes
+	day := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	envLayout(&cfg.Formats.DateDay, "STET_DATE_DAY", &errs, day, day.AddDate(0, 0, 1))
+	envLayout(&cfg.Formats.DateShort, "STET_DATE_SHORT", &errs, day, day.AddDate(0, 0, 1))
+	envLayout(&cfg.Formats.DateMedium, "STET_DATE_MEDIUM", &errs, day, day.AddDate(0, 0, 1))
+	envLayout(&cfg.Formats.DateLong, "STET_DATE_LONG", &errs, day, day.AddDate(0, 0, 1))
+	envLayout(&cfg.Formats.Time, "STET_TIME_FORMAT", &errs, day, day.Add(time.Hour+time.Minute))
+	envLayout(&cfg.Formats.TimeShort, "STET_TIME_SHORT", &errs, day, day.Add(time.Hour+time.Minute))
+
+	return cfg, errors.Join(errs...)
+}
+
+// envLayout overwrites dst with the named variable if, used as a time layout,
+// it formats a and b differently.
+func envLayout(dst *string, name string, errs *[]error, a, b time.Time) {
+	raw, ok := lookup(name)
+	if !ok || strings.TrimSpace(raw) == "" {
+		return
+	}
+	if a.Format(raw) == b.Format(raw) {
+		*errs = append(*errs, fmt.Errorf("%s: invalid layout %q (use Go's reference time, e.g. %q)", name, raw, *dst))
+		return
+	}
+	*dst = raw
+}
+
+// envURL overwrites dst with the named variable if it is an http or https
+// URL.
+func envURL(dst *string, name string, errs *[]error) {
+	raw, ok := lookup(name)
+	if !ok || strings.TrimSpace(raw) == "" {
+		return
+	}
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		*errs = append(*errs, fmt.Errorf("%s: invalid URL %q (want an http or https URL)", name, raw))
+		return
+	}
+	*dst = u.String()
+}
+
+// envLocation overwrites dst with the time zone the named variable names,
+// e.g. "Europe/Berlin", if set.
+func envLocation(dst **time.Location, name string, errs *[]error) {
+	raw, ok := lookup(name)
+	if !ok || strings.TrimSpace(raw) == "" {
+		return
+	}
+	loc, err := time.LoadLocation(strings.TrimSpace(raw))
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s: unknown time zone %q (want a name like \"Europe/Berlin\")", name, raw))
+		return
+	}
+	*dst = loc
+}
+
+// envEnum overwrites dst with the named variable if it is one of allowed.
+func envEnum[T ~string](dst *T, name string, errs *[]error, allowed ...T) {
+	raw, ok := lookup(name)
+	if !ok || strings.TrimSpace(raw) == "" {
+		return
+	}
+	v := T(strings.ToLower(strings.TrimSpace(raw)))
+	if !slices.Contains(allowed, v) {
+		*errs = append(*errs, fmt.Errorf("%s: invalid value %q (want one of %v)", name, raw, allowed))
+		return
+	}
+	*dst = v
+}
+
+// envList overwrites dst with the named variable split on commas, if set and
+// every item is one of allowed. Items are matched ignoring case and stored as
+// spelled in allowed.
+func envList(dst *[]string, name string, errs *[]error, allowed ...string) {
+	raw, ok := lookup(name)
+	if !ok || strings.TrimSpace(raw) == "" {
+		return
+	}
+	var list []string
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		i := slices.IndexFunc(allowed, func(a string) bool { return strings.EqualFold(a, item) })
+		if i < 0 {
+			*errs = append(*errs, fmt.Errorf("%s: invalid item %q (want a comma-separated list of %v)", name, item, allowed))
+			return
+		}
+		list = append(list, allowed[i])
+	}
+	*dst = list
+}
+
+// envBool overwrites dst with the boolean value of the named variable, if set.
+func envBool(dst *bool, name string, errs *[]error) {
+	raw, ok := lookup(name)
+	if !ok || strings.TrimSpace(raw) == "" {
+		return
+	}
+	v, err := strconv.ParseBool(strings.TrimSpace(raw))
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s: invalid boolean %q", name, raw))
+		return
+	}
+	*dst = v
+}
+
+// envInt overwrites dst with the named variable parsed as an integer in
+// [lo, hi], if set.
+func envInt(dst *int, name string, errs *[]error, lo, hi int) {
+	raw, ok := lookup(name)
+	if !ok || strings.TrimSpace(raw) == "" {
+		return
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || v < lo || v > hi {
+		*errs = append(*errs, fmt.Errorf("%s: invalid value %q (want %d-%d)", name, raw, lo, hi))
+		return
+	}
+	*dst = v
+}
+
+// envTimeout is envDuration for timeouts, which must be positive.
+func envTimeout(dst *time.Duration, name string, errs *[]error) {
+	v := *dst
+	envDuration(&v, name, errs)
+	if v == 0 {
+		*errs = append(*errs, fmt.Errorf("%s: timeout must be greater than zero", name))
+		return
+	}
+	*dst = v
+}
+
+// envDuration overwrites dst with the named variable parsed as a Go duration
+// (e.g. "30m"), if set. Negative durations are rejected.
+func envDuration(dst *time.Duration, name string, errs *[]error) {
+	raw, ok := lookup(name)
+	if !ok || strings.TrimSpace(raw) == "" {
+		return
+	}
+	v, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil || v < 0 {
+		*errs = append(*errs, fmt.Errorf("%s: invalid duration %q", name, raw))
+		return
+	}
+	*dst = v
+}
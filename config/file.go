@@ -0,0 +1,254 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Settings can also be kept in ConfigFile, a JSON object with a key for each
+// STET_ variable: its name without the prefix, in lower case, e.g.
+// "help_bar" for STET_HELP_BAR. Values are checked like the variables', and
+// a variable that is set wins over the file. Lines starting with // are
+// comments, so the file written by WriteDefaults can document every key.
+
+// Option is one setting that can be kept in the config file.
+type Option struct {
+	// Env is the environment variable that sets it, e.g. "STET_HELP_BAR".
+	Env string
+	// Doc says what it does and which values it takes.
+	Doc string
+
+	value func(Config) any // its current value, as written to the file
+}
+
+// Key is the option's name in the config file, e.g. "help_bar".
+func (o Option) Key() string {
+	return strings.ToLower(strings.TrimPrefix(o.Env, "STET_"))
+}
+
+// Options lists every setting the config file takes, in the order they are
+// written out. Credentials stay in the environment.
+var Options = []Option{
+	{"STET_KEEP_COMPLETED_IN_PLACE", "Keep completed tasks in place on the Today page instead of moving them to the bottom.",
+		func(c Config) any { return c.KeepCompletedInPlace }},
+	{"STET_COMPLETED_ORDER", "Order of completed tasks on the Today page: created, recent-last or recent-first.",
+		func(c Config) any { return c.CompletedOrder }},
+	{"STET_STREAK_AT_RISK_MIN", "Flag incomplete tasks whose streak of at least this many days ends today; 0 turns the flag off.",
+		func(c Config) any { return c.StreakAtRiskMin }},
+	{"STET_STREAK_AT_RISK_FIRST", "Sort tasks with a streak at risk to the top of the Today page.",
+		func(c Config) any { return c.StreakAtRiskFirst }},
+	{"STET_IDLE_REFRESH_AFTER", "Reload the active page on the first keypress after this long idle, e.g. 30m; 0s disables it.",
+		func(c Config) any { return durationValue(c.IdleRefreshAfter) }},
+	{"STET_HEATMAP_PALETTE", "History heatmap palette: default, blue-orange or shapes.",
+		func(c Config) any { return c.HeatmapPalette }},
+	{"STET_LAST_UPDATED", "How Oura and Planta show when data was fetched: relative or absolute.",
+		func(c Config) any { return c.LastUpdated }},
+	{"STET_HISTORY_INCLUDE_TODAY", "Show today as the leftmost History heatmap column.",
+		func(c Config) any { return c.HistoryIncludeToday }},
+	{"STET_HISTORY_MATCH_TODAY", "List History's tasks in the Today page's order instead of by creation.",
+		func(c Config) any { return c.HistoryMatchToday }},
+	{"STET_HISTORY_MAX_DAYS", "Most days the History table shows, 7-365.",
+		func(c Config) any { return c.HistoryMaxDays }},
+	{"STET_HISTORY_FOCUS_LAYOUT", "How the History focus view first shows a task's year: calendar or list.",
+		func(c Config) any { return c.HistoryFocusLayout }},
+	{"STET_STARTUP_CHECK", "Check Oura and Planta credentials at startup: off, log or banner.",
+		func(c Config) any { return c.StartupCheck }},
+	{"STET_LOG_LEVEL", "How much goes to the log file: debug, info, warn or error.",
+		func(c Config) any { return c.LogLevel }},
+	{"STET_JOURNAL_ENTRIES", "Journal entries: daily or timestamped.",
+		func(c Config) any { return c.JournalEntries }},
+	{"STET_JOURNAL_START_MODE", "Mode the Journal page opens in: view, normal or insert.",
+		func(c Config) any { return c.JournalStartMode }},
+	{"STET_HR_CHART_HEIGHT", "Preferred height of the Oura heart rate chart in rows, 3-40.",
+		func(c Config) any { return c.HeartRateChartHeight }},
+	{"STET_HR_CHART_STYLE", "How the heart rate chart is drawn: braille, lines or points.",
+		func(c Config) any { return c.HeartRateChartStyle }},
+	{"STET_OURA_CONTRIBUTORS", "How the Oura readiness contributors start out: numbers or bars.",
+		func(c Config) any { return c.Contributors }},
+	{"STET_DND_DURATION", "How long do not disturb stays on, e.g. 25m; 0s keeps it on until toggled off.",
+		func(c Config) any { return durationValue(c.DoNotDisturbFor) }},
+	{"STET_TASK_DELETE", "What deleting a task does: soft (keep its history) or hard (remove it all).",
+		func(c Config) any { return c.TaskDelete }},
+	{"STET_WEEK_START", "First day of the week in calendars: monday or sunday.",
+		func(c Config) any { return c.WeekStart }},
+	{"STET_HOME_TIMEZONE", "Time zone days are counted in, e.g. America/New_York; empty uses the system zone.",
+		func(c Config) any {
+			if c.HomeZone == nil || c.HomeZone == time.Local {
+				return ""
+			}
+			return c.HomeZone.String()
+		}},
+	{"STET_CELEBRATE", "Show confetti on the Today page when the last task is completed.",
+		func(c Config) any { return c.Celebrate }},
+	{"STET_COMPLETION_SOUND", "Sound when a task is completed: off, bell or sound.",
+		func(c Config) any { return c.CompletionSound }},
+	{"STET_HELP_BAR", "Key help below the page on first run: short, full or hidden.",
+		func(c Config) any { return c.HelpBar }},
+	{"STET_BARS", "Characters for bars and gauges: blocks or ascii.",
+		func(c Config) any { return c.Bars }},
+	{"STET_OURA_TIMEOUT", "How long loading and polling Oura may take.",
+		func(c Config) any { return durationValue(c.OuraTimeouts.Background) }},
+	{"STET_OURA_REFRESH_TIMEOUT", "How long an Oura fetch you are waiting on may take.",
+		func(c Config) any { return durationValue(c.OuraTimeouts.Interactive) }},
+	{"STET_PLANTA_TIMEOUT", "How long loading and polling Planta may take.",
+		func(c Config) any { return durationValue(c.PlantaTimeouts.Background) }},
+	{"STET_PLANTA_REFRESH_TIMEOUT", "How long a Planta fetch you are waiting on may take.",
+		func(c Config) any { return durationValue(c.PlantaTimeouts.Interactive) }},
+	{"STET_PLANTA_ACTIONS", "Planta action types to show, e.g. [\"watering\"]; empty shows them all.",
+		func(c Config) any { return listValue(c.PlantaActions) }},
+	{"STET_PLANTA_SKIP_ACTIONS", "Planta action types to hide, e.g. [\"progressUpdate\"].",
+		func(c Config) any { return listValue(c.PlantaSkipActions) }},
+	{"STET_WEBHOOK_URL", "URL each task completion is POSTed to as JSON, e.g. a Home Assistant webhook; empty disables it.",
+		func(c Config) any { return c.WebhookURL }},
+	{"STET_JOURNAL_AUTOSAVE_DELAY", "How long the Journal waits after the last keystroke before saving; at least 50ms.",
+		func(c Config) any { return durationValue(c.JournalAutosaveDelay) }},
+	{"STET_DATE_DAY", "Month and day layout, written as Go's reference time Mon Jan 2 15:04:05 2006.",
+		func(c Config) any { return c.Formats.DateDay }},
+	{"STET_DATE_SHORT", "Short date layout, for lists and status lines.",
+		func(c Config) any { return c.Formats.DateShort }},
+	{"STET_DATE_MEDIUM", "Date layout for a single day with its year, as in History's focus view.",
+		func(c Config) any { return c.Formats.DateMedium }},
+	{"STET_DATE_LONG", "Long date layout, for headings.",
+		func(c Config) any { return c.Formats.DateLong }},
+	{"STET_TIME_FORMAT", "Clock time layout, e.g. 3:04PM for a 12-hour clock.",
+		func(c Config) any { return c.Formats.Time }},
+	{"STET_TIME_SHORT", "Clock time layout to the minute, e.g. 3:04PM.",
+		func(c Config) any { return c.Formats.TimeShort }},
+}
+
+func durationValue(d time.Duration) string {
+	return d.String()
+}
+
+func listValue(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+// fileValues holds the config file's settings while Load runs, as the
+// strings the matching variables would hold.
+var fileValues map[string]string
+
+// lookup returns the named variable if set, or else its config file value.
+func lookup(name string) (string, bool) {
+	if v, ok := os.LookupEnv(name); ok {
+		return v, true
+	}
+	v, ok := fileValues[strings.ToLower(strings.TrimPrefix(name, "STET_"))]
+	return v, ok
+}
+
+// readConfigFile reads the config file at path, if there is one, keyed as
+// in the file. Unknown keys and values that aren't a string, number,
+// boolean or list of strings are reported; the rest are still returned.
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(stripComments(data), &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var errs []error
+	values := make(map[string]string, len(raw))
+	for key, msg := range raw {
+		if !slices.ContainsFunc(Options, func(o Option) bool { return o.Key() == key }) {
+			errs = append(errs, fmt.Errorf("%s: unknown key %q", path, key))
+			continue
+		}
+		v, err := fileValue(msg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s: %w", path, key, err))
+			continue
+		}
+		values[key] = v
+	}
+	return values, errors.Join(errs...)
+}
+
+// fileValue turns a config file value into the string the matching
+// variable would hold. Lists are joined with commas.
+func fileValue(msg json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(msg, &s); err == nil {
+		return s, nil
+	}
+	var list []string
+	if err := json.Unmarshal(msg, &list); err == nil {
+		return strings.Join(list, ","), nil
+	}
+	var v any
+	if err := json.Unmarshal(msg, &v); err != nil {
+		return "", err
+	}
+	switch v.(type) {
+	case bool, float64:
+		return string(bytes.TrimSpace(msg)), nil
+	}
+	return "", fmt.Errorf("want a string, number, boolean or list of strings")
+}
+
+// stripComments blanks lines starting with //, keeping the line count so
+// JSON errors point at the right place.
+func stripComments(data []byte) []byte {
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("//")) {
+			lines[i] = nil
+		}
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// WriteDefaults writes a config file with every option at its default,
+// each under a comment saying what it does.
+func WriteDefaults(w io.Writer) error {
+	return writeConfig(w, Default(), true)
+}
+
+// WriteEffective writes cfg as a config file, without comments, e.g. to
+// show the settings in effect once the file and environment are applied.
+func WriteEffective(w io.Writer, cfg Config) error {
+	return writeConfig(w, cfg, false)
+}
+
+func writeConfig(w io.Writer, cfg Config, comments bool) error {
+	var b bytes.Buffer
+	b.WriteString("{\n")
+	for i, o := range Options {
+		if comments {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "  // %s (%s)\n", o.Doc, o.Env)
+		}
+		key, _ := json.Marshal(o.Key())
+		value, err := json.Marshal(o.value(cfg))
+		if err != nil {
+			return fmt.Errorf("%s: %w", o.Key(), err)
+		}
+		fmt.Fprintf(&b, "  %s: %s", key, value)
+		if i < len(Options)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
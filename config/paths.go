@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DataDir returns the directory stet keeps its database, log and tokens in:
+// $XDG_DATA_HOME/stet, or ~/.local/share/stet when XDG_DATA_HOME is unset.
+// stet always used ~/.local/share/stet before honouring XDG_DATA_HOME, so
+// that is kept while it exists and $XDG_DATA_HOME/stet doesn't, rather than
+// starting over on an empty database; see LegacyDataDir.
+func DataDir() string {
+	dir, _ := dataDir()
+	return dir
+}
+
+// LegacyDataDir reports whether DataDir is ~/.local/share/stet although
+// XDG_DATA_HOME points elsewhere, and if so where the data would go once
+// moved.
+func LegacyDataDir() (xdgDir string, ok bool) {
+	_, xdgDir = dataDir()
+	return xdgDir, xdgDir != ""
+}
+
+// dataDir returns the data directory and, when the legacy directory is kept
+// in place of the one XDG_DATA_HOME names, the latter.
+func dataDir() (dir, xdgDir string) {
+	legacy := os.ExpandEnv("$HOME/.local/share/stet")
+	xdg := os.Getenv("XDG_DATA_HOME")
+	if !filepath.IsAbs(xdg) {
+		return legacy, ""
+	}
+	dir = filepath.Join(xdg, "stet")
+	if dir != legacy && !exists(dir) && exists(legacy) {
+		return legacy, dir
+	}
+	return dir, ""
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// ExportDir returns the directory pages export files to, inside DataDir.
+func ExportDir() string {
+	return filepath.Join(DataDir(), "exports")
+}
+
+// ConfigFile returns the path of the optional config file:
+// $XDG_CONFIG_HOME/stet/config.json, or ~/.config/stet/config.json when
+// XDG_CONFIG_HOME is unset.
+func ConfigFile() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "stet", "config.json")
+	}
+	return os.ExpandEnv("$HOME/.config/stet/config.json")
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"stet.codes/tui/config"
+)
+
+const configUsage = "usage: stet config --write-defaults [--force] | --print\n"
+
+// runConfig writes a commented config file with every setting at its
+// default, or prints the settings in effect. loadErr is what config.Load
+// reported, shown on stderr so a mistake in the file doesn't go unnoticed.
+func runConfig(cfg config.Config, loadErr error, args []string) int {
+	if loadErr != nil {
+		fmt.Fprintf(os.Stderr, "stet: config: %v\n", loadErr)
+	}
+
+	switch {
+	case slices.Equal(args, []string{"--print"}):
+		if err := config.WriteEffective(os.Stdout, cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "stet: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case len(args) > 0 && args[0] == "--write-defaults":
+		force := slices.Equal(args[1:], []string{"--force"})
+		if len(args) > 1 && !force {
+			fmt.Fprint(os.Stderr, configUsage)
+			return 2
+		}
+		path := config.ConfigFile()
+		if err := writeDefaultConfig(path, force); err != nil {
+			fmt.Fprintf(os.Stderr, "stet: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Wrote %s\n", path)
+		return 0
+	}
+
+	fmt.Fprint(os.Stderr, configUsage)
+	return 2
+}
+
+// writeDefaultConfig writes the default config file to path, creating its
+// directory. An existing file is only replaced when force is set.
+func writeDefaultConfig(path string, force bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if os.IsExist(err) {
+		return fmt.Errorf("%s already exists; use --force to replace it", path)
+	}
+	if err != nil {
+		return err
+	}
+	if err := config.WriteDefaults(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"stet.codes/tui/logging"
+	"stet.codes/tui/pages"
+)
+
+const exportJournalUsage = "usage: stet export-journal [--include-private]\n"
+
+// runExportJournal writes the journal to stdout as Markdown, a heading per
+// day, oldest first. Private entries are left out unless --include-private
+// is given. Returns the process exit code.
+func runExportJournal(fileLogger *logging.Logger, args []string) int {
+	includePrivate := slices.Equal(args, []string{"--include-private"})
+	if len(args) > 0 && !includePrivate {
+		fmt.Fprint(os.Stderr, exportJournalUsage)
+		return 2
+	}
+
+	db, err := openDB(fileLogger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stet: cannot open database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	days, err := pages.LoadJournalExport(db, includePrivate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stet: cannot load journal: %v\n", err)
+		return 1
+	}
+	writeJournalMarkdown(os.Stdout, days)
+	return 0
+}
+
+func writeJournalMarkdown(w io.Writer, days []pages.JournalExportEntry) {
+	for i, d := range days {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "## %s\n\n%s\n", d.Date, strings.TrimRight(d.Content, "\n"))
+	}
+}
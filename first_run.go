@@ -0,0 +1,35 @@
+package main
+
+import (
+	"stet.codes/tui/pages"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// firstRunMsg reports that this looks like a first run, so onboarding
+// should be shown.
+type firstRunMsg struct{}
+
+// firstRunCmd checks for a first run: no tasks in the database, onboarding
+// not done before, and no stored Oura or Planta tokens. Tokens that fail to
+// load still count as stored; only their absence suggests a new install.
+func (m AppModel) firstRunCmd() tea.Cmd {
+	db, oura, planta := m.db, m.ouraClient, m.plantaClient
+	return func() tea.Msg {
+		needed, err := pages.NeedsOnboarding(db)
+		if err != nil {
+			m.logger.Warnf("first run check: %v", err)
+			return nil
+		}
+		if !needed {
+			return nil
+		}
+		if tokens, err := oura.Auth().LoadTokens(); tokens != nil || err != nil {
+			return nil
+		}
+		if tokens, err := planta.Auth().LoadTokens(); tokens != nil || err != nil {
+			return nil
+		}
+		return firstRunMsg{}
+	}
+}
@@ -1,11 +1,16 @@
 package main
 
-import "log"
+import (
+	"log"
 
-// gooseLogger adapts a standard library log.Logger to Goose's logger interface.
-// Goose expects a logger with Printf and Fatalf methods.
+	"stet.codes/tui/logging"
+)
+
+// gooseLogger adapts the app's logger to Goose's logger interface, logging
+// migration steps at info level. Goose expects a logger with Printf and
+// Fatalf methods.
 type gooseLogger struct {
-	l *log.Logger
+	l *logging.Logger
 }
 
 func (c *gooseLogger) Printf(format string, v ...interface{}) {
@@ -13,7 +18,7 @@ func (c *gooseLogger) Printf(format string, v ...interface{}) {
 		log.Printf(format, v...)
 		return
 	}
-	c.l.Printf(format, v...)
+	c.l.Infof(format, v...)
 }
 
 func (c *gooseLogger) Fatalf(format string, v ...interface{}) {
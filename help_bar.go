@@ -0,0 +1,65 @@
+package main
+
+import (
+	"stet.codes/tui/config"
+	"stet.codes/tui/pages"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// helpBarKey is the app_state key holding the help bar state last chosen,
+// which wins over config.HelpBar from then on.
+const helpBarKey = "help_bar"
+
+// helpBarLoadedMsg carries the remembered help bar state.
+type helpBarLoadedMsg struct {
+	state config.HelpBar
+}
+
+// loadHelpBarCmd reads the remembered help bar state, if any.
+func (m AppModel) loadHelpBarCmd() tea.Cmd {
+	db, logger := m.db, m.logger
+	return func() tea.Msg {
+		value, err := pages.LoadAppState(db, helpBarKey)
+		if err != nil {
+			logger.Warnf("load help bar state: %v", err)
+			return nil
+		}
+		switch state := config.HelpBar(value); state {
+		case config.HelpBarShort, config.HelpBarFull, config.HelpBarHidden:
+			return helpBarLoadedMsg{state: state}
+		}
+		return nil
+	}
+}
+
+// helpBar returns the help bar's current state.
+func (m AppModel) helpBar() config.HelpBar {
+	switch {
+	case m.helpHidden:
+		return config.HelpBarHidden
+	case m.help.ShowAll:
+		return config.HelpBarFull
+	}
+	return config.HelpBarShort
+}
+
+// setHelpBar shows the help bar as state and resizes the pages to fit.
+func (m *AppModel) setHelpBar(state config.HelpBar) {
+	m.helpHidden = state == config.HelpBarHidden
+	m.help.ShowAll = state == config.HelpBarFull
+	m.updatePageSizes()
+}
+
+// changeHelpBar switches the help bar to state and returns the command that
+// remembers it.
+func (m *AppModel) changeHelpBar(state config.HelpBar) tea.Cmd {
+	m.setHelpBar(state)
+	db, logger := m.db, m.logger
+	return func() tea.Msg {
+		if err := pages.SaveAppState(db, helpBarKey, string(state)); err != nil {
+			logger.Warnf("save help bar state: %v", err)
+		}
+		return nil
+	}
+}
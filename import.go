@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"stet.codes/tui/logging"
+	"stet.codes/tui/pages"
+
+	"gopkg.in/yaml.v3"
+)
+
+// importEntry is one task in a YAML import file. Category and schedule are
+// accepted so habit files can carry them, but tasks have neither yet.
+type importEntry struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Category    string `yaml:"category"`
+	Schedule    string `yaml:"schedule"`
+}
+
+// runImportTasks reads task definitions from the file named in args and adds
+// them to the database, reporting how many were added and skipped. Returns
+// the process exit code.
+func runImportTasks(fileLogger *logging.Logger, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "usage: stet import-tasks <file.yaml|file.md>\n")
+		return 2
+	}
+	path := args[0]
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stet: %v\n", err)
+		return 1
+	}
+
+	var entries []importEntry
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		entries = parseMarkdownTasks(data)
+	default:
+		if entries, err = parseYAMLTasks(data); err != nil {
+			fmt.Fprintf(os.Stderr, "stet: %s: %v\n", path, err)
+			return 1
+		}
+	}
+
+	tasks := make([]pages.TaskImport, 0, len(entries))
+	ignored := false
+	for _, e := range entries {
+		tasks = append(tasks, pages.TaskImport{Title: e.Title, Description: e.Description})
+		ignored = ignored || e.Category != "" || e.Schedule != ""
+	}
+
+	db, err := openDB(fileLogger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stet: cannot open database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	added, skipped, err := pages.ImportTasks(db, tasks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stet: import failed, nothing was added: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Added %d, skipped %d (duplicate or untitled)\n", added, skipped)
+	if ignored {
+		fmt.Println("Note: category and schedule are not supported yet and were ignored")
+	}
+	return 0
+}
+
+// parseYAMLTasks accepts either a top-level list of tasks or a mapping with a
+// "tasks" list.
+func parseYAMLTasks(data []byte) ([]importEntry, error) {
+	var entries []importEntry
+	if err := yaml.Unmarshal(data, &entries); err == nil {
+		return entries, nil
+	}
+	var doc struct {
+		Tasks []importEntry `yaml:"tasks"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Tasks, nil
+}
+
+// parseMarkdownTasks reads a Markdown bullet list: each top-level "- " or "* "
+// item is a task title (a leading "[ ]" checkbox is dropped), and indented
+// lines beneath it form the description.
+func parseMarkdownTasks(data []byte) []importEntry {
+	var entries []importEntry
+	var desc []string
+	flush := func() {
+		if len(entries) > 0 {
+			entries[len(entries)-1].Description = strings.Join(desc, " ")
+		}
+		desc = nil
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := sc.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* "):
+			flush()
+			title := strings.TrimSpace(line[2:])
+			for _, box := range []string{"[ ]", "[x]", "[X]"} {
+				title = strings.TrimSpace(strings.TrimPrefix(title, box))
+			}
+			entries = append(entries, importEntry{Title: title})
+		case trimmed != "" && len(entries) > 0 && line != trimmed:
+			// Indented continuation, with any sub-bullet marker dropped
+			trimmed = strings.TrimPrefix(strings.TrimPrefix(trimmed, "- "), "* ")
+			desc = append(desc, trimmed)
+		}
+	}
+	flush()
+	return entries
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"stet.codes/tui/clients"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Startup integration check: each integration with credentials gets one quick
+// authorized request so bad tokens show up at launch rather than on first
+// visit to its page. Probes run concurrently and never block startup.
+const (
+	integrationProbeTimeout = 5 * time.Second
+	integrationBannerTime   = 10 * time.Second
+)
+
+// integrationState is the outcome of checking one integration.
+type integrationState int
+
+const (
+	integrationReady integrationState = iota
+	integrationNeedsSetup
+	integrationNeedsSignIn
+	integrationFailed
+)
+
+// integrationStatus reports the state of a single integration.
+type integrationStatus struct {
+	name  string
+	state integrationState
+	err   error
+}
+
+// String describes the status for the log.
+func (s integrationStatus) String() string {
+	switch s.state {
+	case integrationReady:
+		return s.name + ": ready"
+	case integrationNeedsSetup:
+		return s.name + ": needs setup (no credentials in .env)"
+	case integrationNeedsSignIn:
+		return s.name + ": needs sign-in"
+	default:
+		return s.name + ": check failed: " + s.err.Error()
+	}
+}
+
+// integrationCheckMsg carries the results of the startup integration check.
+type integrationCheckMsg struct {
+	statuses []integrationStatus
+}
+
+// clearIntegrationBannerMsg hides the startup integration banner.
+type clearIntegrationBannerMsg struct{}
+
+var (
+	integrationReadyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+	integrationSetupStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F5A623"))
+)
+
+// integrationCheckCmd probes Oura and Planta concurrently.
+func (m AppModel) integrationCheckCmd() tea.Cmd {
+	oura, planta := m.ouraClient, m.plantaClient
+	return func() tea.Msg {
+		ouraCh := make(chan integrationStatus, 1)
+		plantaCh := make(chan integrationStatus, 1)
+		go func() {
+			ouraCh <- checkIntegration("Oura", oura.Auth().HasCredentials(), oura.Probe)
+		}()
+		go func() {
+			plantaCh <- checkIntegration("Planta", planta.Auth().HasCredentials(), planta.Probe)
+		}()
+		return integrationCheckMsg{statuses: []integrationStatus{<-ouraCh, <-plantaCh}}
+	}
+}
+
+// checkIntegration runs probe with a timeout. The probe itself may block on a
+// token refresh that ignores the context, so the result is abandoned rather
+// than waited for once the timeout passes.
+func checkIntegration(name string, hasCredentials bool, probe func(context.Context) error) integrationStatus {
+	if !hasCredentials {
+		return integrationStatus{name: name, state: integrationNeedsSetup}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), integrationProbeTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- probe(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	switch {
+	case err == nil:
+		return integrationStatus{name: name, state: integrationReady}
+	case errors.Is(err, clients.ErrNotAuthenticated):
+		return integrationStatus{name: name, state: integrationNeedsSignIn}
+	case errors.Is(err, context.DeadlineExceeded):
+		return integrationStatus{name: name, state: integrationFailed, err: errors.New("timed out")}
+	default:
+		return integrationStatus{name: name, state: integrationFailed, err: err}
+	}
+}
+
+// renderIntegrationBanner lists which integrations are ready and which need
+// attention, e.g. "Oura ready · Planta needs setup".
+func renderIntegrationBanner(statuses []integrationStatus) string {
+	parts := make([]string, len(statuses))
+	for i, s := range statuses {
+		switch s.state {
+		case integrationReady:
+			parts[i] = s.name + " " + integrationReadyStyle.Render("ready")
+		case integrationNeedsSetup:
+			parts[i] = s.name + " " + integrationSetupStyle.Render("needs setup")
+		case integrationNeedsSignIn:
+			parts[i] = s.name + " " + integrationSetupStyle.Render("needs sign-in")
+		default:
+			parts[i] = s.name + " " + integrationSetupStyle.Render("unreachable")
+		}
+	}
+	return strings.Join(parts, dimStyle2.Render(" · "))
+}
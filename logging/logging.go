@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sync/atomic"
+)
+
+// Level is how important a log line is. Lines below a Logger's level are
+// dropped.
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = [...]string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+func (l Level) String() string {
+	if l < LevelDebug || l > LevelError {
+		return fmt.Sprintf("LEVEL(%d)", int32(l))
+	}
+	return levelNames[l]
+}
+
+// Logger writes leveled lines to a standard library logger, e.g.
+// "APP: 2006/01/02 15:04:05 WARN oura: request failed". It is safe for
+// concurrent use, including changing the level.
+type Logger struct {
+	l     *log.Logger
+	level atomic.Int32
+}
+
+// New returns a Logger writing to w with the given prefix, logging lines at
+// level and above.
+func New(w io.Writer, prefix string, level Level) *Logger {
+	lg := &Logger{l: log.New(w, prefix, log.LstdFlags)}
+	lg.SetLevel(level)
+	return lg
+}
+
+// Discard returns a Logger that writes nothing.
+func Discard() *Logger {
+	return New(io.Discard, "", LevelError+1)
+}
+
+// SetLevel changes the lowest level that is logged.
+func (lg *Logger) SetLevel(level Level) {
+	lg.level.Store(int32(level))
+}
+
+// enabled reports whether lines at level are logged.
+func (lg *Logger) enabled(level Level) bool {
+	return lg != nil && int32(level) >= lg.level.Load()
+}
+
+func (lg *Logger) logf(level Level, format string, v ...any) {
+	if !lg.enabled(level) {
+		return
+	}
+	lg.l.Output(3, level.String()+" "+fmt.Sprintf(format, v...))
+}
+
+// Debugf logs detail that is only useful when chasing a problem.
+func (lg *Logger) Debugf(format string, v ...any) { lg.logf(LevelDebug, format, v...) }
+
+// Infof logs routine events, e.g. a migration being applied.
+func (lg *Logger) Infof(format string, v ...any) { lg.logf(LevelInfo, format, v...) }
+
+// Warnf logs failures the app recovers from, e.g. a request that will be
+// retried on the next refresh.
+func (lg *Logger) Warnf(format string, v ...any) { lg.logf(LevelWarn, format, v...) }
+
+// Errorf logs failures that lose data or leave something not working, e.g.
+// a save that didn't reach the database.
+func (lg *Logger) Errorf(format string, v ...any) { lg.logf(LevelError, format, v...) }
+
+// Fatalf logs at error level regardless of the level set, then exits.
+func (lg *Logger) Fatalf(format string, v ...any) {
+	lg.l.Fatalf(LevelError.String()+" "+format, v...)
+}
@@ -1,14 +1,20 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
-	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 
 	"stet.codes/tui/clients"
+	"stet.codes/tui/config"
+	"stet.codes/tui/logging"
+	"stet.codes/tui/pages"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/joho/godotenv"
@@ -20,8 +26,29 @@ import (
 //go:embed migrations/*.sql
 var embedMigrations embed.FS
 
-const dbPath = "$HOME/.local/share/stet/data.db"
-const logPath = "$HOME/.local/share/stet/debug.log"
+// Files in config.DataDir().
+const dbName = "data.db"
+const logName = "debug.log"
+
+const usage = `usage: stet [command]
+
+With no command, starts the interactive app.
+
+commands:
+  summary                print today's summary to stdout and exit
+  import-tasks <file>    add tasks from a YAML or Markdown file, skipping
+                         titles that already exist
+  export-journal [--include-private]
+                         print the journal as Markdown, leaving out
+                         entries marked private unless asked for
+  config --write-defaults [--force]
+                         write a config file documenting every setting at
+                         its default
+  config --print         print the settings in effect, after the config
+                         file and environment
+  version                print the version, the database's schema version
+                         and where stet keeps its files
+`
 
 func main() {
 	// Load .env file from the binary's directory (ignore error if not found)
@@ -29,54 +56,175 @@ func main() {
 		_ = godotenv.Load(filepath.Join(filepath.Dir(exePath), ".env"))
 	}
 
-	fileLogger := log.New(&lumberjack.Logger{
-		Filename:   os.ExpandEnv(logPath),
+	command := ""
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+	if command == "help" || command == "-h" || command == "--help" {
+		fmt.Print(usage)
+		return
+	}
+
+	dataDir := config.DataDir()
+	if err := checkDataDir(dataDir); err != nil {
+		fmt.Fprintf(os.Stderr, "stet: %v\n", err)
+		os.Exit(exitDataDirUnwritable)
+	}
+
+	logFile := &lumberjack.Logger{
+		Filename:   filepath.Join(dataDir, logName),
 		MaxSize:    5,  // Megabytes before it rotates
 		MaxBackups: 3,  // Keep only the 3 most recent old log files
 		MaxAge:     28, // Days to keep logs
 		Compress:   true,
-	}, "APP: ", log.LstdFlags)
+	}
+	fileLogger := logging.New(logFile, "APP: ", logging.LevelInfo)
+
+	pages.SetLogger(fileLogger)
+	clients.SetLogger(fileLogger)
+	if xdgDir, ok := config.LegacyDataDir(); ok {
+		fileLogger.Infof("data: using existing %s rather than %s from XDG_DATA_HOME; move it there to switch",
+			dataDir, xdgDir)
+	}
+
+	cfg, cfgErr := config.Load()
+	fileLogger.SetLevel(cfg.LogLevel.Level())
+	if cfgErr != nil {
+		fileLogger.Warnf("config: %v", cfgErr)
+	}
+	pages.SetHomeLocation(cfg.HomeZone)
+	pages.SetClockLayout(cfg.Formats.TimeShort)
+	pages.SetBars(cfg.Bars)
+	pages.SetCompletionWebhook(clients.NewWebhook(cfg.WebhookURL))
+
+	// Stored OAuth tokens are encrypted when a passphrase is set
+	tokenPassphrase := os.Getenv("STET_TOKEN_PASSPHRASE")
+
+	// Initialize Oura client with credentials from environment
+	ouraClient := clients.NewOuraClient(
+		os.Getenv("OURA_CLIENT_ID"),
+		os.Getenv("OURA_CLIENT_SECRET"),
+		tokenPassphrase,
+	)
+
+	// Initialize Planta client with app code from environment
+	plantaClient := clients.NewPlantaClient(os.Getenv("PLANTA_APP_CODE"), tokenPassphrase,
+		clients.NewActionFilter(cfg.PlantaActions, cfg.PlantaSkipActions))
+
+	code := 0
+	switch command {
+	case "":
+		code = runTUI(fileLogger, cfg, ouraClient, plantaClient)
+	case "summary":
+		code = runSummary(fileLogger, cfg, ouraClient, plantaClient)
+	case "import-tasks":
+		code = runImportTasks(fileLogger, os.Args[2:])
+	case "export-journal":
+		code = runExportJournal(fileLogger, os.Args[2:])
+	case "config":
+		code = runConfig(cfg, cfgErr, os.Args[2:])
+	case "version":
+		code = runVersion()
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n%s", command, usage)
+		code = 2
+	}
+
+	// os.Exit skips deferred calls, so everything is closed by now
+	logFile.Close()
+	os.Exit(code)
+}
 
-	dbPath := os.ExpandEnv(dbPath)
+// openDB opens the SQLite database, creating its directory if needed, and
+// applies any pending migrations.
+func openDB(fileLogger *logging.Logger) (*sql.DB, error) {
+	dbPath := filepath.Join(config.DataDir(), dbName)
 
 	dir := filepath.Dir(dbPath)
 
-	err := os.MkdirAll(dir, 0755)
-	if err != nil {
-		log.Fatalf("Could not create directories: %v", err)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create directories: %w", err)
 	}
 
 	db, err := sql.Open("sqlite", dbPath)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	defer db.Close()
 
 	goose.SetLogger(&gooseLogger{fileLogger})
 	goose.SetBaseFS(embedMigrations)
 
 	if err := goose.SetDialect("sqlite3"); err != nil {
-		log.Fatal(err)
+		db.Close()
+		return nil, err
+	}
+
+	// A newer build's schema may hold things this one would lose track of
+	latest, err := latestMigration()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if applied, err := appliedMigration(db); err != nil {
+		db.Close()
+		return nil, err
+	} else if applied > latest {
+		db.Close()
+		return nil, newerSchemaError(applied, latest)
 	}
 
 	// "migrations" is the folder name inside your project
 	if err := goose.Up(db, "migrations"); err != nil {
-		log.Fatal(err)
+		db.Close()
+		return nil, fmt.Errorf("migrate: %w", err)
 	}
 
-	// Initialize Oura client with credentials from environment
-	ouraClient := clients.NewOuraClient(
-		os.Getenv("OURA_CLIENT_ID"),
-		os.Getenv("OURA_CLIENT_SECRET"),
-	)
+	// Older builds could store journal dates with a time part; a failure
+	// here only affects lookups of those rows, so it isn't fatal.
+	if n, err := pages.NormalizeJournalDates(db); err != nil {
+		fileLogger.Errorf("normalize journal dates: %v", err)
+	} else if n > 0 {
+		fileLogger.Infof("normalized %d journal entry dates", n)
+	}
 
-	// Initialize Planta client with app code from environment
-	plantaClient := clients.NewPlantaClient(os.Getenv("PLANTA_APP_CODE"))
+	return db, nil
+}
+
+// runTUI starts the interactive Bubble Tea program and returns the process
+// exit code. However the program ends (quit key, SIGINT, SIGTERM or SIGHUP),
+// pages get to save and stop their background work before the DB closes.
+func runTUI(fileLogger *logging.Logger, cfg config.Config, ouraClient *clients.OuraClient, plantaClient *clients.PlantaClient) int {
+	db, err := openDB(fileLogger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stet: cannot open database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	// Bubble Tea handles SIGINT and SIGTERM itself; this also covers SIGHUP
+	// (terminal closed) by killing the program through its context.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGHUP, syscall.SIGTERM)
+	defer stop()
 
 	// Alt-screen makes this a true full-window TUI (no scrollback spam).
-	p := tea.NewProgram(NewAppModel(db, ouraClient, plantaClient), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	p := tea.NewProgram(NewAppModel(db, ouraClient, plantaClient, cfg, fileLogger),
+		tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithContext(ctx))
+	final, err := p.Run()
+	if m, ok := final.(AppModel); ok {
+		m.Shutdown()
+	}
+
+	switch {
+	case errors.Is(err, tea.ErrProgramPanic):
+		fmt.Println("Error running program:", err)
+		return 1
+	case err == nil, errors.Is(err, tea.ErrInterrupted), errors.Is(err, tea.ErrProgramKilled):
+		if err != nil {
+			fileLogger.Infof("exiting: %v", err)
+		}
+		return 0
+	default:
 		fmt.Println("Error running program:", err)
-		os.Exit(1)
+		return 1
 	}
 }
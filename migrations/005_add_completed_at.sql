@@ -0,0 +1,11 @@
+-- +goose Up
+-- Existing rows only have a date; their time is taken as midnight and they
+-- are marked backfilled, as are completions logged after the fact, so the
+-- completion-time histogram can leave them out.
+ALTER TABLE task_history ADD COLUMN completed_at DATETIME;
+ALTER TABLE task_history ADD COLUMN backfilled BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE task_history SET completed_at = date(completed_date) || ' 00:00:00', backfilled = TRUE;
+
+-- +goose Down
+ALTER TABLE task_history DROP COLUMN backfilled;
+ALTER TABLE task_history DROP COLUMN completed_at;
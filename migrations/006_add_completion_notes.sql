@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE task_definitions ADD COLUMN prompt_note BOOLEAN DEFAULT FALSE;
+ALTER TABLE task_history ADD COLUMN note TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE task_history DROP COLUMN note;
+ALTER TABLE task_definitions DROP COLUMN prompt_note;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE task_definitions ADD COLUMN satisfied_week DATE;
+
+-- +goose Down
+ALTER TABLE task_definitions DROP COLUMN satisfied_week;
@@ -0,0 +1,34 @@
+-- +goose Up
+-- SQLite can't drop a UNIQUE constraint, so rebuild the table without it.
+CREATE TABLE journal_entries_new (
+    id TEXT PRIMARY KEY,
+    entry_date DATE NOT NULL,
+    content TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO journal_entries_new (id, entry_date, content, created_at, updated_at)
+    SELECT id, entry_date, content, created_at, updated_at FROM journal_entries;
+DROP TABLE journal_entries;
+ALTER TABLE journal_entries_new RENAME TO journal_entries;
+CREATE INDEX idx_journal_entries_entry_date ON journal_entries (entry_date, created_at);
+
+-- +goose Down
+-- Only the first entry of each day survives the downgrade.
+CREATE TABLE journal_entries_old (
+    id TEXT PRIMARY KEY,
+    entry_date DATE NOT NULL UNIQUE,
+    content TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO journal_entries_old (id, entry_date, content, created_at, updated_at)
+    SELECT id, entry_date, content, created_at, updated_at FROM journal_entries j
+    WHERE id = (
+        SELECT id FROM journal_entries
+        WHERE entry_date = j.entry_date
+        ORDER BY created_at, id
+        LIMIT 1
+    );
+DROP TABLE journal_entries;
+ALTER TABLE journal_entries_old RENAME TO journal_entries;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE task_pauses (
+    id TEXT PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES task_definitions(id),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_task_pauses_task_id ON task_pauses (task_id, start_date);
+
+-- +goose Down
+DROP TABLE task_pauses;
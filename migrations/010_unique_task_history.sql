@@ -0,0 +1,20 @@
+-- +goose Up
+-- task_history was created with unique(task_id, completed_date), but a table
+-- rebuilt or imported without it can collect duplicate completions. Keep one
+-- row per task and day (preferring one with a note, then the earliest) and
+-- make sure the pair is unique from here on.
+DELETE FROM task_history
+WHERE id NOT IN (
+    SELECT (
+        SELECT id FROM task_history
+        WHERE task_id = h.task_id AND completed_date = h.completed_date
+        ORDER BY note = '', completed_at, id
+        LIMIT 1
+    )
+    FROM task_history h
+    GROUP BY task_id, completed_date
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_history_task_date ON task_history (task_id, completed_date);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_history_task_date;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Tasks captured for later triage; they stay inactive until scheduled.
+ALTER TABLE task_definitions ADD COLUMN inbox BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE task_definitions DROP COLUMN inbox;
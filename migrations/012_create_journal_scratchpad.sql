@@ -0,0 +1,14 @@
+-- +goose Up
+-- A single undated note kept alongside the journal. It has the columns the
+-- Journal page reads and writes entries with, so it shares their editing
+-- and save path.
+CREATE TABLE journal_scratchpad (
+    id TEXT PRIMARY KEY,
+    content TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO journal_scratchpad (id) VALUES ('scratchpad');
+
+-- +goose Down
+DROP TABLE journal_scratchpad;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Plant care completed from the Planta page, kept locally so it can be shown
+-- alongside task completions and journal entries. completed_at is local
+-- wall-clock time, like task_history.completed_at.
+CREATE TABLE plant_care_log (
+    id TEXT PRIMARY KEY,
+    plant_id TEXT NOT NULL,
+    plant_name TEXT NOT NULL,
+    action_type TEXT NOT NULL,
+    completed_at DATETIME NOT NULL
+);
+CREATE INDEX idx_plant_care_log_completed_at ON plant_care_log (completed_at);
+
+-- +goose Down
+DROP TABLE plant_care_log;
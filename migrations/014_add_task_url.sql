@@ -0,0 +1,6 @@
+-- +goose Up
+-- An optional link for a task, e.g. a workout video, opened from Today.
+ALTER TABLE task_definitions ADD COLUMN url TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE task_definitions DROP COLUMN url;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Small pieces of app state that outlive a session, e.g. whether the
+-- first-run introduction has been shown.
+CREATE TABLE app_state (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE app_state;
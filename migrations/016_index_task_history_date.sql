@@ -0,0 +1,8 @@
+-- +goose Up
+-- The History table, streaks and the activity feed read completions by date
+-- range across all tasks, which the (task_id, completed_date) index can't
+-- serve, so every load scanned the whole table.
+CREATE INDEX IF NOT EXISTS idx_task_history_completed_date ON task_history (completed_date);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_history_completed_date;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- The History comparison panels and pager show one day of the year, e.g.
+-- every March 5th, across all years. Index the "MM-DD" part of entry_date
+-- so those lookups don't scan every entry. Queries must use the same
+-- substr(entry_date, 6, 5) expression to use it.
+CREATE INDEX IF NOT EXISTS idx_journal_entries_month_day ON journal_entries (substr(entry_date, 6, 5), entry_date);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_journal_entries_month_day;
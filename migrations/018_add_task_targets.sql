@@ -0,0 +1,17 @@
+-- +goose Up
+-- A task can be done several times a day, e.g. "drink water" 8 times. Each
+-- day's row counts how many times so far, and keeps the target it was
+-- counted against so changing a task's target doesn't rewrite past days. A
+-- day is only complete once its count reaches its target; task_completions
+-- is the rows that are, for everything that reads completions.
+ALTER TABLE task_definitions ADD COLUMN target INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE task_history ADD COLUMN count INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE task_history ADD COLUMN target INTEGER NOT NULL DEFAULT 1;
+CREATE VIEW task_completions AS
+SELECT * FROM task_history WHERE count >= target;
+
+-- +goose Down
+DROP VIEW task_completions;
+ALTER TABLE task_history DROP COLUMN target;
+ALTER TABLE task_history DROP COLUMN count;
+ALTER TABLE task_definitions DROP COLUMN target;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- A journal entry can be marked private, to leave it out of exports unless
+-- asked for. The scratchpad gets the column too so it keeps the columns
+-- entries are read with, though it is never exported.
+ALTER TABLE journal_entries ADD COLUMN private BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE journal_scratchpad ADD COLUMN private BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE journal_scratchpad DROP COLUMN private;
+ALTER TABLE journal_entries DROP COLUMN private;
@@ -0,0 +1,24 @@
+package pages
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// LoadAppState returns the value stored in app_state under key, or "" if
+// there is none.
+func LoadAppState(db *sql.DB, key string) (string, error) {
+	var value string
+	err := db.QueryRow(`SELECT value FROM app_state WHERE key = ?`, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return value, err
+}
+
+// SaveAppState stores value in app_state under key, replacing any value
+// there.
+func SaveAppState(db *sql.DB, key, value string) error {
+	_, err := db.Exec(`INSERT OR REPLACE INTO app_state (key, value) VALUES (?, ?)`, key, value)
+	return err
+}
@@ -0,0 +1,68 @@
+package pages
+
+import (
+	"strings"
+
+	"stet.codes/tui/config"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var barTrackStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#444444"))
+
+// barGlyphs are what bars are drawn with.
+type barGlyphs struct {
+	full  string
+	track string
+	// partials fill part of a cell, from the smallest fraction up; with
+	// none, bars round to whole cells.
+	partials []string
+}
+
+var (
+	blockBarGlyphs = barGlyphs{
+		full:     "█",
+		track:    "░",
+		partials: []string{"▏", "▎", "▍", "▌", "▋", "▊", "▉"},
+	}
+	asciiBarGlyphs = barGlyphs{full: "#", track: "-"}
+
+	bars = blockBarGlyphs
+)
+
+// SetBars selects block characters or plain ASCII for every bar, for
+// terminals or fonts that draw the blocks badly.
+func SetBars(style config.Bars) {
+	bars = blockBarGlyphs
+	if style == config.BarsASCII {
+		bars = asciiBarGlyphs
+	}
+}
+
+// renderBar draws a horizontal bar width cells wide, filled in proportion to
+// value out of total with fill, rounded to the nearest part of a cell; the
+// rest of the track is dim.
+func renderBar(value, total, width int, fill lipgloss.Style) string {
+	if width <= 0 || total <= 0 {
+		return ""
+	}
+	value = min(max(value, 0), total)
+	steps := len(bars.partials) + 1 // per cell
+	units := (2*value*width*steps + total) / (2 * total)
+	if value > 0 && units == 0 {
+		units = 1 // something rather than nothing
+	}
+
+	full, part := units/steps, units%steps
+	filled := strings.Repeat(bars.full, full)
+	if part > 0 {
+		filled += bars.partials[part-1]
+		full++
+	}
+	return fill.Render(filled) + barTrackStyle.Render(strings.Repeat(bars.track, width-full))
+}
+
+// renderBarLabel is renderBar followed by label, e.g. "3/5 done".
+func renderBarLabel(value, total, width int, fill lipgloss.Style, label string) string {
+	return renderBar(value, total, width, fill) + " " + label
+}
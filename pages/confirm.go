@@ -0,0 +1,70 @@
+package pages
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	confirmTitleStyle   = lipgloss.NewStyle().Bold(true)
+	confirmWarningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B"))
+	confirmHintStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+)
+
+// confirmDialog is a titled yes/no question. A page shows it in place of its
+// content while asking and passes it keys; y answers yes, n or esc no.
+// Either answer closes it and sends a confirmResultMsg with its id and
+// target, so the page can tell which question was answered and about what.
+type confirmDialog struct {
+	id     string // which question, e.g. "delete"
+	target string // what it is about, e.g. a task ID
+
+	title   string
+	body    string
+	warning string // shown below the body in red, if set
+	yes, no string // what each answer does, e.g. "confirm" and "cancel"
+}
+
+// confirmResultMsg reports the answer to a confirmDialog.
+type confirmResultMsg struct {
+	id        string
+	target    string
+	confirmed bool
+}
+
+// newConfirmDialog returns a dialog asking body under title, with the usual
+// "y to confirm, n or esc to cancel" answers.
+func newConfirmDialog(id, target, title, body string) *confirmDialog {
+	return &confirmDialog{id: id, target: target, title: title, body: body, yes: "confirm", no: "cancel"}
+}
+
+// update handles a key, reporting whether it answered the dialog. The
+// command delivers the result.
+func (d *confirmDialog) update(msg tea.KeyMsg) (tea.Cmd, bool) {
+	var confirmed bool
+	switch msg.String() {
+	case "y", "Y":
+		confirmed = true
+	case "n", "N", "esc":
+	default:
+		return nil, false
+	}
+	result := confirmResultMsg{id: d.id, target: d.target, confirmed: confirmed}
+	return func() tea.Msg { return result }, true
+}
+
+func (d *confirmDialog) View() string {
+	var b strings.Builder
+	b.WriteString(confirmTitleStyle.Render(d.title))
+	b.WriteString("\n\n")
+	b.WriteString(d.body)
+	if d.warning != "" {
+		b.WriteString("\n\n")
+		b.WriteString(confirmWarningStyle.Render(d.warning))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(confirmHintStyle.Render("(y to " + d.yes + ", n or esc to " + d.no + ")"))
+	return b.String()
+}
@@ -0,0 +1,359 @@
+package pages
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"stet.codes/tui/clients"
+	"stet.codes/tui/config"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// The dashboard is everything at a glance: one line each for today's tasks,
+// streaks, Oura readiness, plants due and the journal. The app shows it over
+// the active page until closed. It is read-only and loads afresh each time
+// it opens, from the same queries the pages use and the clients' caches, so
+// opening it doesn't normally cost a request.
+
+// dashboardStreaks is how many of the longest current streaks are listed.
+const dashboardStreaks = 3
+
+// dashboardBarWidth is the width of the task progress bar.
+const dashboardBarWidth = 20
+
+type dashboardPart int
+
+const (
+	dashboardLocal dashboardPart = iota // tasks, streaks and the journal
+	dashboardReadiness
+	dashboardPlants
+	dashboardParts
+)
+
+// DashboardMsg carries one part of the dashboard's data. The parts load
+// separately so a slow integration doesn't hold up the rest; version drops
+// results from before a refresh.
+type DashboardMsg struct {
+	version int
+	part    dashboardPart
+	err     error
+
+	tasks     []Task
+	journaled bool
+
+	connected bool // the integration is set up
+	readiness *clients.DailyReadiness
+	plants    []clients.PlantTask
+}
+
+type dashboardKeyMap struct {
+	Refresh key.Binding
+	Close   key.Binding
+}
+
+var dashboardKeys = dashboardKeyMap{
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
+	Close: key.NewBinding(
+		key.WithKeys("esc", "D"),
+		key.WithHelp("esc", "close"),
+	),
+}
+
+var (
+	dashboardTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#04B575"))
+	dashboardLabelStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	dashboardDimStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#555555"))
+	dashboardErrStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B"))
+	dashboardWarnStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	dashboardDoneStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+)
+
+// Dashboard is the at-a-glance overlay. While it is open the app shows it in
+// place of the active page and passes it keys and DashboardMsgs.
+type Dashboard struct {
+	db            *sql.DB
+	ouraClient    *clients.OuraClient
+	plantaClient  *clients.PlantaClient
+	ouraTimeout   time.Duration
+	plantaTimeout time.Duration
+	atRiskMin     int // shortest streak flagged as at risk
+	dateLayout    string
+
+	version int
+	parts   [dashboardParts]*DashboardMsg // nil while loading
+	open    bool
+	width   int
+}
+
+// NewDashboard returns the dashboard, closed.
+func NewDashboard(db *sql.DB, ouraClient *clients.OuraClient, plantaClient *clients.PlantaClient, cfg config.Config) *Dashboard {
+	return &Dashboard{
+		db:            db,
+		ouraClient:    ouraClient,
+		plantaClient:  plantaClient,
+		ouraTimeout:   cfg.OuraTimeouts.Background,
+		plantaTimeout: cfg.PlantaTimeouts.Background,
+		atRiskMin:     cfg.StreakAtRiskMin,
+		dateLayout:    cfg.Formats.DateLong,
+	}
+}
+
+// Open shows the dashboard and returns the command that loads it.
+func (d *Dashboard) Open() tea.Cmd {
+	d.open = true
+	return d.RefreshCmd()
+}
+
+// IsOpen reports whether the dashboard is showing.
+func (d *Dashboard) IsOpen() bool {
+	return d.open
+}
+
+// RefreshCmd loads every part of the dashboard, dropping anything loaded
+// before.
+func (d *Dashboard) RefreshCmd() tea.Cmd {
+	d.version++
+	d.parts = [dashboardParts]*DashboardMsg{}
+	return tea.Batch(
+		loadDashboardLocalCmd(d.db, d.version),
+		loadDashboardReadinessCmd(d.ouraClient, d.ouraTimeout, d.version),
+		loadDashboardPlantsCmd(d.plantaClient, d.plantaTimeout, d.version),
+	)
+}
+
+// loadDashboardLocalCmd loads today's tasks with their streaks, as on the
+// Today page, and whether anything has been written in today's journal.
+func loadDashboardLocalCmd(db *sql.DB, version int) tea.Cmd {
+	return func() tea.Msg {
+		msg := DashboardMsg{version: version, part: dashboardLocal, connected: true}
+		switch loaded := loadTodayDataCmd(db)().(type) {
+		case activeTasksLoadedMsg:
+			msg.tasks = loaded.tasks
+		case activeTasksLoadFailedMsg:
+			msg.err = loaded.err
+			return msg
+		}
+		msg.err = db.QueryRow(`
+			SELECT EXISTS (
+				SELECT 1 FROM journal_entries WHERE entry_date = ? AND trim(content) != ''
+			)
+		`, todayKey()).Scan(&msg.journaled)
+		return msg
+	}
+}
+
+// loadDashboardReadinessCmd gets today's readiness, if Oura is signed in.
+func loadDashboardReadinessCmd(client *clients.OuraClient, timeout time.Duration, version int) tea.Cmd {
+	return func() tea.Msg {
+		msg := DashboardMsg{version: version, part: dashboardReadiness}
+		if !client.Auth().HasCredentials() || !client.IsAuthenticated() {
+			return msg
+		}
+		msg.connected = true
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		msg.readiness, msg.err = client.GetTodayReadiness(ctx)
+		return msg
+	}
+}
+
+// loadDashboardPlantsCmd gets the plant care due today or overdue, if
+// Planta is set up. It asks for the same range as the Planta page so the
+// cached response is shared.
+func loadDashboardPlantsCmd(client *clients.PlantaClient, timeout time.Duration, version int) tea.Cmd {
+	return func() tea.Msg {
+		msg := DashboardMsg{version: version, part: dashboardPlants}
+		if !client.Auth().HasCredentials() {
+			return msg
+		}
+		msg.connected = true
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if msg.err = client.EnsureAuthenticated(ctx); msg.err != nil {
+			return msg
+		}
+		tasks, err := client.GetDueTasks(ctx, 3)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		for _, t := range tasks {
+			if t.IsOverdue || t.IsToday {
+				msg.plants = append(msg.plants, t)
+			}
+		}
+		return msg
+	}
+}
+
+func (d *Dashboard) SetSize(width, height int) {
+	d.width = width
+}
+
+// Update handles a key or a DashboardMsg. Esc or D closes the dashboard.
+func (d *Dashboard) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case DashboardMsg:
+		if msg.version != d.version {
+			return nil
+		}
+		if msg.err != nil {
+			logger.Warnf("dashboard: load part %d: %v", msg.part, msg.err)
+		}
+		d.parts[msg.part] = &msg
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, dashboardKeys.Close):
+			d.open = false
+		case key.Matches(msg, dashboardKeys.Refresh):
+			return d.RefreshCmd()
+		}
+	}
+	return nil
+}
+
+func (d *Dashboard) View() string {
+	width := max(d.width-DocStyle.GetHorizontalFrameSize(), 20)
+
+	var b strings.Builder
+	b.WriteString(dashboardTitleStyle.Render("At a Glance"))
+	b.WriteString(" ")
+	b.WriteString(dashboardDimStyle.Render(homeNow().Format(d.dateLayout)))
+	b.WriteString("\n\n")
+
+	local := d.parts[dashboardLocal]
+	lines := []struct {
+		label string
+		value string
+	}{
+		{"Tasks", d.localLine(local, d.tasksLine)},
+		{"Streaks", d.localLine(local, d.streaksLine)},
+		{"Readiness", d.readinessLine()},
+		{"Plants", d.plantsLine()},
+		{"Journal", d.localLine(local, d.journalLine)},
+	}
+	for _, l := range lines {
+		line := dashboardLabelStyle.Render(fmt.Sprintf("%-10s", l.label)) + " " + l.value
+		b.WriteString(ansi.Truncate(line, width, ellipsis))
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// localLine renders a line from the tasks and journal part, or its loading
+// or failed state.
+func (d *Dashboard) localLine(part *DashboardMsg, render func(*DashboardMsg) string) string {
+	switch {
+	case part == nil:
+		return dashboardDimStyle.Render("Loading...")
+	case part.err != nil:
+		return dashboardErrStyle.Render("unavailable")
+	}
+	return render(part)
+}
+
+func (d *Dashboard) tasksLine(part *DashboardMsg) string {
+	if len(part.tasks) == 0 {
+		return dashboardDimStyle.Render("no active tasks")
+	}
+	done := 0
+	for _, t := range part.tasks {
+		if t.completed || t.satisfiedWeek {
+			done++
+		}
+	}
+	summary := fmt.Sprintf("%d/%d done", done, len(part.tasks))
+	if done == len(part.tasks) {
+		summary = dashboardDoneStyle.Render(summary + " ✓")
+	}
+	return renderBarLabel(done, len(part.tasks), dashboardBarWidth, dashboardDoneStyle, summary)
+}
+
+// streaksLine lists the longest current streaks, marking those at risk
+// today as the Today page does.
+func (d *Dashboard) streaksLine(part *DashboardMsg) string {
+	var tasks []Task
+	for _, t := range part.tasks {
+		if t.streak > 0 {
+			tasks = append(tasks, t)
+		}
+	}
+	if len(tasks) == 0 {
+		return dashboardDimStyle.Render("none yet")
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].streak > tasks[j].streak })
+
+	parts := make([]string, 0, dashboardStreaks)
+	for _, t := range tasks[:min(len(tasks), dashboardStreaks)] {
+		s := fmt.Sprintf("%s %dd", t.title, t.streak)
+		if t.streakAtRisk(d.atRiskMin) {
+			s = dashboardWarnStyle.Render(s + " !")
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, dashboardDimStyle.Render(" · "))
+}
+
+func (d *Dashboard) journalLine(part *DashboardMsg) string {
+	if part.journaled {
+		return dashboardDoneStyle.Render("written today ✓")
+	}
+	return dashboardDimStyle.Render("nothing written today")
+}
+
+func (d *Dashboard) readinessLine() string {
+	part := d.parts[dashboardReadiness]
+	switch {
+	case part == nil:
+		return dashboardDimStyle.Render("Loading...")
+	case !part.connected:
+		return dashboardDimStyle.Render("Oura not connected")
+	case part.err != nil:
+		return dashboardErrStyle.Render("unavailable")
+	case part.readiness == nil:
+		return dashboardDimStyle.Render("no score yet today")
+	}
+	score := part.readiness.Score
+	return contributorBandStyle(score).Render(fmt.Sprintf("%d", score))
+}
+
+func (d *Dashboard) plantsLine() string {
+	part := d.parts[dashboardPlants]
+	switch {
+	case part == nil:
+		return dashboardDimStyle.Render("Loading...")
+	case !part.connected:
+		return dashboardDimStyle.Render("Planta not connected")
+	case part.err != nil:
+		return dashboardErrStyle.Render("unavailable")
+	case len(part.plants) == 0:
+		return dashboardDoneStyle.Render("nothing due today ✓")
+	}
+	overdue := 0
+	for _, t := range part.plants {
+		if t.IsOverdue {
+			overdue++
+		}
+	}
+	line := fmt.Sprintf("%d due today", len(part.plants))
+	if overdue > 0 {
+		line += dashboardErrStyle.Render(fmt.Sprintf(" (%d overdue)", overdue))
+	}
+	return line
+}
+
+func (d *Dashboard) KeyMap() []key.Binding {
+	return []key.Binding{dashboardKeys.Refresh, dashboardKeys.Close}
+}
@@ -0,0 +1,43 @@
+package pages
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	_ "modernc.org/sqlite"
+)
+
+// openTestDB returns an in-memory database with every migration applied.
+func openTestDB(tb testing.TB) *sql.DB {
+	tb.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	// Each connection to :memory: is its own database
+	db.SetMaxOpenConns(1)
+	tb.Cleanup(func() { db.Close() })
+
+	goose.SetBaseFS(os.DirFS(".."))
+	goose.SetLogger(goose.NopLogger())
+	if err := goose.SetDialect("sqlite3"); err != nil {
+		tb.Fatal(err)
+	}
+	if err := goose.Up(db, "migrations"); err != nil {
+		tb.Fatal(err)
+	}
+	return db
+}
+
+// addTestTask adds an active task.
+func addTestTask(tb testing.TB, db *sql.DB, id, title string) {
+	tb.Helper()
+	if _, err := db.Exec(`
+		INSERT INTO task_definitions (id, title, description, active, deleted)
+		VALUES (?, ?, '', true, false)
+	`, id, title); err != nil {
+		tb.Fatal(err)
+	}
+}
@@ -0,0 +1,23 @@
+package pages
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	emptyStateMessageStyle = lipgloss.NewStyle().Bold(true)
+	emptyStateHintStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+)
+
+// emptyState renders a page's "nothing to show" block: an icon in the
+// page's accent color over a message and a dimmer hint, centered in width
+// by height. A height too small for the block leaves it top-aligned.
+func emptyState(accent lipgloss.Color, icon, message, hint string, width, height int) string {
+	block := lipgloss.JoinVertical(lipgloss.Center,
+		lipgloss.NewStyle().Foreground(accent).Bold(true).Render(icon),
+		"",
+		emptyStateMessageStyle.Render(message),
+		emptyStateHintStyle.Render(hint),
+	)
+	return lipgloss.Place(width, max(height, 0), lipgloss.Center, lipgloss.Center, block)
+}
@@ -0,0 +1,56 @@
+package pages
+
+import (
+	"context"
+	"time"
+)
+
+// fetcher tracks the requests an integration page makes. Starting a fetch
+// cancels the one in flight, and results carry the id of the fetch that
+// produced them so a late result from a superseded fetch can be dropped.
+// Shutdown cancels everything, including requests not started as fetches.
+type fetcher struct {
+	ctx    context.Context
+	stop   context.CancelFunc
+	cancel context.CancelFunc // the fetch in flight, if any
+	id     int
+}
+
+func newFetcher() *fetcher {
+	ctx, stop := context.WithCancel(context.Background())
+	return &fetcher{ctx: ctx, stop: stop}
+}
+
+// start cancels the fetch in flight and returns the context and id for a new
+// one bounded by timeout. The caller cancels the context when done.
+func (f *fetcher) start(timeout time.Duration) (context.Context, context.CancelFunc, int) {
+	f.abort()
+	f.id++
+	ctx, cancel := context.WithTimeout(f.ctx, timeout)
+	f.cancel = cancel
+	return ctx, cancel, f.id
+}
+
+// request returns a context for a one-off request, e.g. a write, that isn't
+// cancelled by later fetches but is by shutdown.
+func (f *fetcher) request(timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(f.ctx, timeout)
+}
+
+// abort cancels the fetch in flight, if any.
+func (f *fetcher) abort() {
+	if f.cancel != nil {
+		f.cancel()
+		f.cancel = nil
+	}
+}
+
+// current reports whether id is the latest fetch.
+func (f *fetcher) current(id int) bool {
+	return id == f.id
+}
+
+// shutdown cancels every request the fetcher's contexts are used for.
+func (f *fetcher) shutdown() {
+	f.stop()
+}
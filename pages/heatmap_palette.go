@@ -0,0 +1,70 @@
+package pages
+
+import (
+	"stet.codes/tui/config"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// heatmapPalette is the full set of glyphs and styles used to draw history
+// heatmap cells, so switching palettes is a single config value.
+type heatmapPalette struct {
+	completedSquare string
+	missedSquare    string
+	pausedSquare    string // a day the task was paused, neither done nor missed
+
+	completedStyle lipgloss.Style
+	missedStyle    lipgloss.Style
+	pausedStyle    lipgloss.Style
+
+	// Yesterday is the most actionable day to backfill, so its column is
+	// drawn slightly brighter. Kept subtle so the selection underline still
+	// stands out.
+	yesterdayCompletedStyle lipgloss.Style
+	yesterdayMissedStyle    lipgloss.Style
+}
+
+// heatmapPalettes holds the built-in presets, keyed by config value.
+var heatmapPalettes = map[config.HeatmapPalette]heatmapPalette{
+	// Green filled squares on grey hollow squares.
+	config.HeatmapPaletteDefault: {
+		completedSquare:         "■",
+		missedSquare:            "□",
+		pausedSquare:            "–",
+		completedStyle:          lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575")),
+		missedStyle:             lipgloss.NewStyle().Foreground(lipgloss.Color("#3C3C3C")),
+		pausedStyle:             lipgloss.NewStyle().Foreground(lipgloss.Color("#4A4A4A")),
+		yesterdayCompletedStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#3DDC97")).Bold(true),
+		yesterdayMissedStyle:    lipgloss.NewStyle().Foreground(lipgloss.Color("#6A6A6A")).Bold(true),
+	},
+	// Blue and orange stay distinct under the common red-green deficiencies.
+	config.HeatmapPaletteBlueOrange: {
+		completedSquare:         "■",
+		missedSquare:            "□",
+		pausedSquare:            "–",
+		completedStyle:          lipgloss.NewStyle().Foreground(lipgloss.Color("#3B82F6")),
+		missedStyle:             lipgloss.NewStyle().Foreground(lipgloss.Color("#9A5B1E")),
+		pausedStyle:             lipgloss.NewStyle().Foreground(lipgloss.Color("#4A4A4A")),
+		yesterdayCompletedStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#7AB0FF")).Bold(true),
+		yesterdayMissedStyle:    lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Bold(true),
+	},
+	// Shape and brightness only, readable without any color vision.
+	config.HeatmapPaletteShapes: {
+		completedSquare:         "●",
+		missedSquare:            "·",
+		pausedSquare:            " ",
+		completedStyle:          lipgloss.NewStyle().Foreground(lipgloss.Color("#E0E0E0")),
+		missedStyle:             lipgloss.NewStyle().Foreground(lipgloss.Color("#5A5A5A")),
+		pausedStyle:             lipgloss.NewStyle().Foreground(lipgloss.Color("#5A5A5A")),
+		yesterdayCompletedStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true),
+		yesterdayMissedStyle:    lipgloss.NewStyle().Foreground(lipgloss.Color("#8A8A8A")).Bold(true),
+	},
+}
+
+// heatmapPaletteFor returns the named preset, falling back to the default.
+func heatmapPaletteFor(name config.HeatmapPalette) heatmapPalette {
+	if p, ok := heatmapPalettes[name]; ok {
+		return p
+	}
+	return heatmapPalettes[config.HeatmapPaletteDefault]
+}
@@ -5,15 +5,19 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"stet.codes/tui/config"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/pmezard/go-difflib/difflib"
 )
 
 // ---------------------------------------------------------------------------
@@ -24,22 +28,68 @@ import (
 type HistoryTask struct {
 	id          string
 	title       string
-	completions map[string]bool // key: "YYYY-MM-DD", value: true if completed
+	completions map[string]bool   // key: "YYYY-MM-DD", value: true if completed
+	notes       map[string]string // key: "YYYY-MM-DD", completion note if any
+	paused      map[string]bool   // key: "YYYY-MM-DD", true if the task was paused
+	created     string            // "YYYY-MM-DD", local date the task was added
+	active      bool              // inactive tasks are only listed on request
+
+	// doneToday is set when the task is completed today but today isn't in
+	// the loaded range, so the heatmap can't show it.
+	doneToday bool
 }
 
 func (t HistoryTask) FilterValue() string { return t.title }
 func (t HistoryTask) Title() string       { return t.title }
 func (t HistoryTask) Description() string { return "" }
 
+// missed reports whether date is a day the task was due but not completed:
+// not paused, not before the task existed and not today, which isn't over.
+func (t HistoryTask) missed(date, today string) bool {
+	return !t.completions[date] && !t.paused[date] && date >= t.created && date < today
+}
+
 // ---------------------------------------------------------------------------
 // JournalEntry domain
 // ---------------------------------------------------------------------------
 
-// JournalEntry represents a journal entry with its date and content.
+// JournalEntry represents a day's journal with its date and content. When
+// the day has several timestamped notes, content lists each under its time.
 type JournalEntry struct {
 	id        string
 	entryDate time.Time
 	content   string
+	notes     []journalNote
+	private   bool // any of the day's notes is private
+}
+
+// journalNote is one of a day's journal entries.
+type journalNote struct {
+	createdAt string // home-zone time of day, see homeClock
+	content   string
+}
+
+// combineNotes returns the day's content. A single note is shown as is;
+// several are listed in order under their start times. Empty notes are
+// skipped.
+func combineNotes(notes []journalNote) string {
+	var filled []journalNote
+	for _, n := range notes {
+		if strings.TrimSpace(n.content) != "" {
+			filled = append(filled, n)
+		}
+	}
+	switch len(filled) {
+	case 0:
+		return ""
+	case 1:
+		return filled[0].content
+	}
+	parts := make([]string, len(filled))
+	for i, n := range filled {
+		parts[i] = "[" + n.createdAt + "]\n" + n.content
+	}
+	return strings.Join(parts, "\n\n")
 }
 
 func (j JournalEntry) FilterValue() string { return j.entryDate.Format("2006-01-02") }
@@ -56,6 +106,11 @@ const (
 	historyModeTaskTable historyMode = iota
 	historyModeJournalTable
 	historyModeJournalPager
+	historyModeJournalCompare
+	historyModeStats
+	historyModeTaskFocus
+	historyModeActivity
+	historyModeBackfill
 )
 
 // ---------------------------------------------------------------------------
@@ -72,19 +127,37 @@ type historyDataLoadFailedMsg struct {
 	err error
 }
 
-// historyCompletionSavedMsg indicates the completion toggle was saved.
-type historyCompletionSavedMsg struct {
-	taskID    string
-	date      string
-	completed bool
+// historyCell identifies one heatmap cell: a task on a day ("YYYY-MM-DD").
+type historyCell struct {
+	taskID string
+	date   string
 }
 
-// historyCompletionSaveFailedMsg indicates the completion toggle failed.
-type historyCompletionSaveFailedMsg struct {
+// historyWrite is one pending completion change for a task and day.
+type historyWrite struct {
 	taskID    string
 	date      string
 	completed bool
-	err       error
+}
+
+// HistoryCompletionsSavedMsg indicates a batch of completion toggles was
+// saved. It is delivered to the History page even when another page is
+// shown, since the batch may have been written as the user left.
+type HistoryCompletionsSavedMsg struct {
+	writes []historyWrite
+}
+
+// HistoryCompletionsSaveFailedMsg indicates a batch of completion toggles
+// failed; none of it was applied.
+type HistoryCompletionsSaveFailedMsg struct {
+	writes []historyWrite
+	err    error
+}
+
+// historyFlushTickMsg fires after the write debounce interval; only the tick
+// matching the latest version flushes.
+type historyFlushTickMsg struct {
+	version int
 }
 
 // journalHistoryLoadedMsg contains all journal entries.
@@ -97,19 +170,46 @@ type journalHistoryLoadFailedMsg struct {
 	err error
 }
 
+// sameDayEntriesLoadedMsg contains the journal entries for one day of the
+// year ("MM-DD") across all years, newest first.
+type sameDayEntriesLoadedMsg struct {
+	monthDay string
+	entries  []journalYearEntry
+}
+
+// sameDayEntriesLoadFailedMsg indicates loading a day's entries failed.
+type sameDayEntriesLoadFailedMsg struct {
+	monthDay string
+	err      error
+}
+
+// completionTimesLoadedMsg contains completion counts bucketed by hour of day.
+type completionTimesLoadedMsg struct {
+	counts [24]int
+}
+
+// completionTimesLoadFailedMsg indicates loading completion times failed.
+type completionTimesLoadFailedMsg struct {
+	err error
+}
+
 // ---------------------------------------------------------------------------
 // Database commands
 // ---------------------------------------------------------------------------
 
-func loadHistoryDataCmd(db *sql.DB, daysToShow int) tea.Cmd {
+// loadHistoryDataCmd loads active tasks, and inactive ones too if
+// inactive is set, with their completions between the from and to dates
+// ("YYYY-MM-DD", inclusive). Tasks are in creation order, or in order's if
+// it is set.
+func loadHistoryDataCmd(db *sql.DB, from, to string, order *todayOrder, inactive bool) tea.Cmd {
 	return func() tea.Msg {
-		// Query 1: Get all active, non-deleted tasks
+		// Query 1: Get all active (or all), non-deleted tasks
 		taskRows, err := db.Query(`
-			SELECT id, title
+			SELECT id, title, COALESCE(strftime('%Y-%m-%d %H:%M:%S', created_at), ''), active
 			FROM task_definitions
-			WHERE active = true AND deleted = false
+			WHERE (active = true OR ?) AND deleted = false
 			ORDER BY created_at ASC
-		`)
+		`, inactive)
 		if err != nil {
 			return historyDataLoadFailedMsg{err: err}
 		}
@@ -118,10 +218,14 @@ func loadHistoryDataCmd(db *sql.DB, daysToShow int) tea.Cmd {
 		var tasks []HistoryTask
 		for taskRows.Next() {
 			var t HistoryTask
-			if err := taskRows.Scan(&t.id, &t.title); err != nil {
+			if err := taskRows.Scan(&t.id, &t.title, &t.created, &t.active); err != nil {
 				return historyDataLoadFailedMsg{err: err}
 			}
+			if created, ok := fromUTC(t.created); ok {
+				t.created = dateKey(created)
+			}
 			t.completions = make(map[string]bool)
+			t.notes = make(map[string]string)
 			tasks = append(tasks, t)
 		}
 		if err := taskRows.Err(); err != nil {
@@ -137,61 +241,191 @@ func loadHistoryDataCmd(db *sql.DB, daysToShow int) tea.Cmd {
 		// Query 2: Get completions in date range
 		// Use date() to ensure we get just the date portion (YYYY-MM-DD)
 		histRows, err := db.Query(`
-			SELECT task_id, date(completed_date)
-			FROM task_history
-			WHERE completed_date >= date('now', 'localtime', ?)
-			  AND completed_date <= date('now', 'localtime')
-		`, fmt.Sprintf("-%d days", daysToShow-1))
+			SELECT task_id, date(completed_date), note
+			FROM task_completions
+			WHERE completed_date >= ? AND completed_date <= ?
+		`, from, to)
 		if err != nil {
 			return historyDataLoadFailedMsg{err: err}
 		}
 		defer histRows.Close()
 
 		for histRows.Next() {
-			var taskID, date string
-			if err := histRows.Scan(&taskID, &date); err != nil {
+			var taskID, date, note string
+			if err := histRows.Scan(&taskID, &date, &note); err != nil {
 				return historyDataLoadFailedMsg{err: err}
 			}
 			if task, exists := taskMap[taskID]; exists {
 				task.completions[date] = true
+				if note != "" {
+					task.notes[date] = note
+				}
 			}
 		}
 		if err := histRows.Err(); err != nil {
 			return historyDataLoadFailedMsg{err: err}
 		}
 
+		// Today's completions, when the range stops short of today
+		if today := todayKey(); to < today {
+			todayRows, err := db.Query(`
+				SELECT task_id FROM task_completions WHERE completed_date = ?
+			`, today)
+			if err != nil {
+				return historyDataLoadFailedMsg{err: err}
+			}
+			defer todayRows.Close()
+			for todayRows.Next() {
+				var taskID string
+				if err := todayRows.Scan(&taskID); err != nil {
+					return historyDataLoadFailedMsg{err: err}
+				}
+				if task, exists := taskMap[taskID]; exists {
+					task.doneToday = true
+				}
+			}
+			if err := todayRows.Err(); err != nil {
+				return historyDataLoadFailedMsg{err: err}
+			}
+		}
+
+		// Query 3: Pauses overlapping the range, drawn as neutral cells
+		pauses, err := loadTaskPauses(db, from, to)
+		if err != nil {
+			return historyDataLoadFailedMsg{err: err}
+		}
+		for i := range tasks {
+			tasks[i].paused = pausedDays(pauses[tasks[i].id], from, to)
+		}
+
+		if order != nil {
+			if err := sortLikeToday(db, tasks, *order); err != nil {
+				return historyDataLoadFailedMsg{err: err}
+			}
+		}
+
 		return historyDataLoadedMsg{tasks: tasks}
 	}
 }
 
-func saveHistoryCompletionCmd(db *sql.DB, taskID, date string, completed bool) tea.Cmd {
-	return func() tea.Msg {
-		var err error
-		if completed {
-			_, err = db.Exec(`
-				INSERT INTO task_history (id, task_id, completed_date)
-				VALUES (lower(hex(randomblob(16))), ?, ?)
-				ON CONFLICT(task_id, completed_date) DO NOTHING
-			`, taskID, date)
+// sortLikeToday puts tasks in the order the Today page shows them. Tasks
+// not on the Today page, e.g. ones paused today, follow in creation order.
+func sortLikeToday(db *sql.DB, tasks []HistoryTask, order todayOrder) error {
+	today, err := loadTodayTasks(db)
+	if err != nil {
+		return err
+	}
+	order.sort(today)
+	rank := make(map[string]int, len(today))
+	for i, t := range today {
+		rank[t.id] = i
+	}
+	rankOf := func(id string) int {
+		if r, ok := rank[id]; ok {
+			return r
+		}
+		return len(today)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return rankOf(tasks[i].id) < rankOf(tasks[j].id)
+	})
+	return nil
+}
+
+// historyWriteDebounce is how long toggles are buffered before being written
+// together, so rapid toggling costs one transaction instead of one per cell.
+const historyWriteDebounce = 750 * time.Millisecond
+
+func historyFlushTickCmd(version int) tea.Cmd {
+	return tea.Tick(historyWriteDebounce, func(time.Time) tea.Msg {
+		return historyFlushTickMsg{version: version}
+	})
+}
+
+// saveHistoryCompletions applies a batch of completion changes in a single
+// transaction. Each write sets the final state, so replaying one is harmless.
+func saveHistoryCompletions(db *sql.DB, writes []historyWrite) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, w := range writes {
+		if w.completed {
+			// Backfilled completions have no real time of day; see
+			// completeTaskDay. Today's cell (when shown) is a live
+			// completion, so gets the time.
+			completedAt := ""
+			if now := homeNow(); w.date == dateKey(now) {
+				completedAt = completedAtKey(now)
+			}
+			err = completeTaskDayTx(tx, w.taskID, w.date, completedAt)
 		} else {
-			_, err = db.Exec(`
+			_, err = tx.Exec(`
 				DELETE FROM task_history
 				WHERE task_id = ? AND completed_date = ?
-			`, taskID, date)
+			`, w.taskID, w.date)
 		}
 		if err != nil {
-			return historyCompletionSaveFailedMsg{taskID: taskID, date: date, completed: completed, err: err}
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func saveHistoryCompletionsCmd(db *sql.DB, writes []historyWrite) tea.Cmd {
+	return func() tea.Msg {
+		if err := saveHistoryCompletions(db, writes); err != nil {
+			return HistoryCompletionsSaveFailedMsg{writes: writes, err: err}
+		}
+		return HistoryCompletionsSavedMsg{writes: writes}
+	}
+}
+
+// loadCompletionTimesCmd counts completions of non-deleted tasks per hour of day.
+// Backfilled completions are skipped: they are stamped at midnight, but
+// their real time of day is unknown.
+func loadCompletionTimesCmd(db *sql.DB) tea.Cmd {
+	return func() tea.Msg {
+		rows, err := db.Query(`
+			SELECT CAST(strftime('%H', h.completed_at) AS INTEGER), COUNT(*)
+			FROM task_completions h
+			JOIN task_definitions d ON d.id = h.task_id
+			WHERE d.deleted = false
+			  AND h.completed_at IS NOT NULL
+			  AND NOT h.backfilled
+			GROUP BY 1
+		`)
+		if err != nil {
+			return completionTimesLoadFailedMsg{err: err}
+		}
+		defer rows.Close()
+
+		var msg completionTimesLoadedMsg
+		for rows.Next() {
+			var hour, count int
+			if err := rows.Scan(&hour, &count); err != nil {
+				return completionTimesLoadFailedMsg{err: err}
+			}
+			if hour >= 0 && hour < len(msg.counts) {
+				msg.counts[hour] = count
+			}
 		}
-		return historyCompletionSavedMsg{taskID: taskID, date: date, completed: completed}
+		if err := rows.Err(); err != nil {
+			return completionTimesLoadFailedMsg{err: err}
+		}
+		return msg
 	}
 }
 
 func loadJournalHistoryCmd(db *sql.DB) tea.Cmd {
 	return func() tea.Msg {
 		rows, err := db.Query(`
-			SELECT id, entry_date, content
+			SELECT id, entry_date, content,
+			       COALESCE(strftime('%Y-%m-%d %H:%M:%S', created_at), ''), private
 			FROM journal_entries
-			ORDER BY entry_date DESC
+			ORDER BY entry_date DESC, created_at, id
 		`)
 		if err != nil {
 			return journalHistoryLoadFailedMsg{err: err}
@@ -202,9 +436,11 @@ func loadJournalHistoryCmd(db *sql.DB) tea.Cmd {
 		for rows.Next() {
 			var e JournalEntry
 			var dateStr string
-			if err := rows.Scan(&e.id, &dateStr, &e.content); err != nil {
+			var note journalNote
+			if err := rows.Scan(&e.id, &dateStr, &note.content, &note.createdAt, &e.private); err != nil {
 				return journalHistoryLoadFailedMsg{err: err}
 			}
+			note.createdAt = homeClock(note.createdAt)
 			var parseErr error
 			e.entryDate, parseErr = time.Parse(time.RFC3339, dateStr)
 			if parseErr != nil {
@@ -214,73 +450,119 @@ func loadJournalHistoryCmd(db *sql.DB) tea.Cmd {
 					return journalHistoryLoadFailedMsg{err: fmt.Errorf("parse date %q: %w", dateStr, parseErr)}
 				}
 			}
+			// Rows of the same day are adjacent; fold them into one entry
+			if n := len(entries); n > 0 && entries[n-1].entryDate.Equal(e.entryDate) {
+				entries[n-1].notes = append(entries[n-1].notes, note)
+				entries[n-1].private = entries[n-1].private || e.private
+				continue
+			}
+			e.notes = []journalNote{note}
 			entries = append(entries, e)
 		}
 		if err := rows.Err(); err != nil {
 			return journalHistoryLoadFailedMsg{err: err}
 		}
+		for i := range entries {
+			entries[i].content = combineNotes(entries[i].notes)
+		}
 
 		return journalHistoryLoadedMsg{entries: entries}
 	}
 }
 
+// loadSameDayEntriesCmd loads the journal entries written on month and day
+// in any year, newest first, with each day's notes combined. It uses the
+// month-day index rather than loading every entry.
+func loadSameDayEntriesCmd(db *sql.DB, month time.Month, day int) tea.Cmd {
+	monthDay := fmt.Sprintf("%02d-%02d", month, day)
+	return func() tea.Msg {
+		rows, err := db.Query(`
+			SELECT substr(entry_date, 1, 4), content,
+			       COALESCE(strftime('%Y-%m-%d %H:%M:%S', created_at), '')
+			FROM journal_entries
+			WHERE substr(entry_date, 6, 5) = ?
+			ORDER BY entry_date DESC, created_at, id
+		`, monthDay)
+		if err != nil {
+			return sameDayEntriesLoadFailedMsg{monthDay: monthDay, err: err}
+		}
+		defer rows.Close()
+
+		var entries []journalYearEntry
+		var notes [][]journalNote
+		for rows.Next() {
+			var yearStr string
+			var note journalNote
+			if err := rows.Scan(&yearStr, &note.content, &note.createdAt); err != nil {
+				return sameDayEntriesLoadFailedMsg{monthDay: monthDay, err: err}
+			}
+			year, err := strconv.Atoi(yearStr)
+			if err != nil {
+				return sameDayEntriesLoadFailedMsg{monthDay: monthDay, err: fmt.Errorf("parse year %q: %w", yearStr, err)}
+			}
+			note.createdAt = homeClock(note.createdAt)
+			// Rows of the same year are adjacent; fold them into one entry
+			if n := len(entries); n > 0 && entries[n-1].year == year {
+				notes[n-1] = append(notes[n-1], note)
+				continue
+			}
+			entries = append(entries, journalYearEntry{year: year})
+			notes = append(notes, []journalNote{note})
+		}
+		if err := rows.Err(); err != nil {
+			return sameDayEntriesLoadFailedMsg{monthDay: monthDay, err: err}
+		}
+		for i := range entries {
+			entries[i].content = combineNotes(notes[i])
+		}
+		return sameDayEntriesLoadedMsg{monthDay: monthDay, entries: entries}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Width calculation
 // ---------------------------------------------------------------------------
 
 const (
-	minTitleWidth   = 20 // Minimum characters reserved for task title
+	minTitleWidth   = 20 // Characters the title keeps before the heatmap gives way
 	titleHeatmapGap = 2  // Space between title and heatmap
 	histListPadding = 6  // Account for list.Model's internal padding/borders
-	minDaysToShow   = 7
-	maxDaysToShow   = 90
+	minDaysToShow   = 1
 )
 
-func calculateDaysToShow(terminalWidth int) int {
+// calculateDaysToShow returns how many days fit across the terminal beside a
+// title minTitleWidth wide, up to maxDays. On a narrow terminal the heatmap
+// loses days first, down to minDaysToShow; only then does the title shrink.
+func calculateDaysToShow(terminalWidth, maxDays int) int {
 	// Available width after accounting for DocStyle margins
 	contentWidth := terminalWidth - DocStyle.GetHorizontalFrameSize()
 
 	// Width available for heatmap (each square = 1 character)
 	heatmapWidth := contentWidth - minTitleWidth - titleHeatmapGap - histListPadding
 
-	daysToShow := heatmapWidth
-	if daysToShow < minDaysToShow {
-		daysToShow = minDaysToShow
-	}
-	if daysToShow > maxDaysToShow {
-		daysToShow = maxDaysToShow
-	}
-
-	return daysToShow
+	return min(max(heatmapWidth, minDaysToShow), maxDays)
 }
 
 // ---------------------------------------------------------------------------
 // History delegate
 // ---------------------------------------------------------------------------
 
-// Heatmap characters and styles
-const (
-	completedSquare = "■"
-	missedSquare    = "□"
-)
-
-var (
-	heatmapCompletedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
-	heatmapMissedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#3C3C3C"))
-)
-
 type historyDelegate struct {
 	list.DefaultDelegate
+	palette      heatmapPalette
 	daysToShow   int
+	includeToday bool     // start the range at today instead of yesterday
 	dateRange    []string // Pre-computed list of date strings (newest to oldest)
 	selectedCell int      // which cell to highlight
 	selectedRow  int      // which row to highlight (matches list.Index())
 }
 
-func newHistoryDelegate(daysToShow int) *historyDelegate {
+func newHistoryDelegate(daysToShow int, palette heatmapPalette, includeToday bool) *historyDelegate {
 	d := &historyDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
+		palette:         palette,
 		daysToShow:      daysToShow,
+		includeToday:    includeToday,
 	}
 	d.ShowDescription = false
 	d.SetHeight(1)
@@ -290,40 +572,84 @@ func newHistoryDelegate(daysToShow int) *historyDelegate {
 }
 
 func (d *historyDelegate) generateDateRange() {
-	d.dateRange = make([]string, d.daysToShow)
-	yesterday := time.Now().AddDate(0, 0, -1)
-	for i := 0; i < d.daysToShow; i++ {
-		// Most recent (yesterday) first (left), oldest last (right)
-		date := yesterday.AddDate(0, 0, -i)
-		d.dateRange[i] = date.Format("2006-01-02")
+	newest := startOfDay(homeNow())
+	if !d.includeToday {
+		newest = addDays(newest, -1)
 	}
+	// Most recent (today or yesterday) first (left), oldest last (right)
+	d.dateRange = dateKeysBack(newest, d.daysToShow)
+}
+
+// yesterdayIndex returns the column holding yesterday.
+func (d *historyDelegate) yesterdayIndex() int {
+	if d.includeToday {
+		return 1
+	}
+	return 0
 }
 
 func (d *historyDelegate) renderHeatmap(task HistoryTask, isSelectedRow bool) string {
 	var b strings.Builder
 
+	yesterday := d.yesterdayIndex()
 	for i, date := range d.dateRange {
 		completed := task.completions[date]
+		paused := task.paused[date] && !completed
 		var style lipgloss.Style
-		if completed {
-			style = heatmapCompletedStyle
-		} else {
-			style = heatmapMissedStyle
+		switch {
+		case paused:
+			style = d.palette.pausedStyle
+		case i == yesterday && completed:
+			style = d.palette.yesterdayCompletedStyle
+		case i == yesterday:
+			style = d.palette.yesterdayMissedStyle
+		case completed:
+			style = d.palette.completedStyle
+		default:
+			style = d.palette.missedStyle
 		}
 		// Highlight selected cell on selected row
 		if isSelectedRow && i == d.selectedCell {
 			style = style.Underline(true)
 		}
-		if completed {
-			b.WriteString(style.Render(completedSquare))
-		} else {
-			b.WriteString(style.Render(missedSquare))
+		switch {
+		case completed:
+			b.WriteString(style.Render(d.palette.completedSquare))
+		case paused:
+			b.WriteString(style.Render(d.palette.pausedSquare))
+		default:
+			b.WriteString(style.Render(d.palette.missedSquare))
 		}
 	}
 
 	return b.String()
 }
 
+// rowWidth returns the width a row's content may take in a list listWidth
+// wide.
+func (d *historyDelegate) rowWidth(listWidth int) int {
+	s := &d.Styles
+	return max(listWidth-s.NormalTitle.GetPaddingLeft()-s.NormalTitle.GetPaddingRight(), 0)
+}
+
+// titleWidth returns the width of the title column in a list listWidth
+// wide: whatever the heatmap and titleHeatmapGap leave, which is at least
+// minTitleWidth unless the terminal is too narrow for even a day's heatmap.
+// The heatmap follows it after titleHeatmapGap.
+func (d *historyDelegate) titleWidth(listWidth int) int {
+	return max(d.rowWidth(listWidth)-d.daysToShow-titleHeatmapGap, 0)
+}
+
+// cellAt returns the heatmap column under column x of a rendered row in a
+// list listWidth wide, or -1. Heatmap glyphs are one cell wide.
+func (d *historyDelegate) cellAt(x, listWidth int) int {
+	start := d.Styles.NormalTitle.GetPaddingLeft() + d.titleWidth(listWidth) + titleHeatmapGap
+	if x < start || x >= start+len(d.dateRange) {
+		return -1
+	}
+	return x - start
+}
+
 func (d *historyDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
 	task, ok := item.(HistoryTask)
 	if !ok {
@@ -336,32 +662,26 @@ func (d *historyDelegate) Render(w io.Writer, m list.Model, index int, item list
 
 	s := &d.Styles
 	isSelected := index == m.Index()
+	titleWidth := d.titleWidth(m.Width())
 
-	// Calculate available width for title
-	heatmapWidth := d.daysToShow
-	availableWidth := m.Width() - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight()
-	titleWidth := availableWidth - heatmapWidth - titleHeatmapGap
-	if titleWidth < minTitleWidth {
-		titleWidth = minTitleWidth
-	}
-
-	// Truncate title if needed
+	// Truncate title if needed, then pad it to align the heatmap
 	title := task.Title()
-	titleLen := lipgloss.Width(title)
-	if titleLen > titleWidth {
-		title = ansi.Truncate(title, titleWidth-1, "…")
-		titleLen = lipgloss.Width(title)
+	if lipgloss.Width(title) > titleWidth {
+		title = ansi.Truncate(title, max(titleWidth-1, 0), "…")
 	}
-	// Pad title to ensure heatmap alignment
-	if titleLen < titleWidth {
-		title = title + strings.Repeat(" ", titleWidth-titleLen)
+	title += strings.Repeat(" ", max(titleWidth-lipgloss.Width(title), 0))
+	// Dim inactive tasks, as on the Tasks page
+	if !task.active && !isSelected {
+		title = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Render(title)
 	}
 
 	// Render heatmap
 	heatmap := d.renderHeatmap(task, isSelected)
 
-	// Combine title and heatmap
+	// Combine title and heatmap, cutting the row at the list's edge when
+	// not even a day fits beside the gap
 	content := title + strings.Repeat(" ", titleHeatmapGap) + heatmap
+	content = ansi.Truncate(content, d.rowWidth(m.Width()), "")
 
 	// Apply selection styling
 	if isSelected {
@@ -369,6 +689,8 @@ func (d *historyDelegate) Render(w io.Writer, m list.Model, index int, item list
 	} else {
 		content = s.NormalTitle.Render(content)
 	}
+	// A list narrower than the padding would still be overrun by it
+	content = ansi.Truncate(content, m.Width(), "")
 
 	fmt.Fprint(w, content)
 }
@@ -380,11 +702,13 @@ func (d *historyDelegate) Render(w io.Writer, m list.Model, index int, item list
 // journalDelegate renders journal entries showing only the date.
 type journalDelegate struct {
 	list.DefaultDelegate
+	dateLayout string
 }
 
-func newJournalDelegate() *journalDelegate {
+func newJournalDelegate(dateLayout string) *journalDelegate {
 	d := &journalDelegate{
 		DefaultDelegate: list.NewDefaultDelegate(),
+		dateLayout:      dateLayout,
 	}
 	d.ShowDescription = false
 	d.SetHeight(1)
@@ -405,14 +729,16 @@ func (d *journalDelegate) Render(w io.Writer, m list.Model, index int, item list
 	s := &d.Styles
 	isSelected := index == m.Index()
 
-	// Format: "2006-01-02"
-	dateStr := entry.entryDate.Format("2006-01-02")
+	dateStr := entry.entryDate.Format(d.dateLayout)
 
 	if isSelected {
 		dateStr = s.SelectedTitle.Render(dateStr)
 	} else {
 		dateStr = s.NormalTitle.Render(dateStr)
 	}
+	if entry.private {
+		dateStr += " 🔒"
+	}
 
 	fmt.Fprint(w, dateStr)
 }
@@ -429,6 +755,18 @@ type historyKeyMap struct {
 	SwitchTable key.Binding
 	Enter       key.Binding
 	Back        key.Binding
+	Stats       key.Binding
+	Compare     key.Binding
+	FromYear    key.Binding
+	ToYear      key.Binding
+	Retry       key.Binding
+	Focus       key.Binding
+	NextMiss    key.Binding
+	PrevMiss    key.Binding
+	Activity    key.Binding
+	Layout      key.Binding
+	Backfill    key.Binding
+	Inactive    key.Binding
 }
 
 var historyKeys = historyKeyMap{
@@ -456,56 +794,174 @@ var historyKeys = historyKeyMap{
 		key.WithKeys("esc", "q"),
 		key.WithHelp("esc/q", "back"),
 	),
+	Stats: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "time of day"),
+	),
+	Compare: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "compare years"),
+	),
+	FromYear: key.NewBinding(
+		key.WithKeys("[", "]"),
+		key.WithHelp("[/]", "from year"),
+	),
+	ToYear: key.NewBinding(
+		key.WithKeys("{", "}"),
+		key.WithHelp("{/}", "to year"),
+	),
+	Retry: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "retry"),
+	),
+	Focus: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "focus task"),
+	),
+	NextMiss: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n/N", "next/prev miss"),
+	),
+	PrevMiss: key.NewBinding(
+		key.WithKeys("N"),
+	),
+	Activity: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "activity"),
+	),
+	Layout: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "list/calendar"),
+	),
+	Backfill: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "log past day"),
+	),
+	Inactive: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "inactive tasks"),
+	),
 }
 
 // HistoryPage displays historical task completion data.
 type HistoryPage struct {
 	list         list.Model
 	delegate     *historyDelegate // direct reference for updating selection
+	palette      heatmapPalette
+	includeToday bool
+	showInactive bool         // list inactive tasks too, toggled with i
+	order        *todayOrder  // match the Today page's order; nil for creation order
+	weekStart    time.Weekday // first row of the focus calendar
+	dateLayout   string       // the focus view's selected day
+	dayLayout    string       // a journal day, without its year
+	timeLayout   string       // activity feed times
 	db           *sql.DB
 	width        int
 	height       int
 	daysToShow   int
+	maxDays      int // most days shown, whatever the width
 	selectedCell int // 0 = leftmost (newest), daysToShow-1 = rightmost (oldest)
 
 	// Journal history fields
 	mode            historyMode
 	journalList     list.Model
+	journalDelegate *journalDelegate
 	journalEntries  []JournalEntry
 	thisYearEntry   string
 	lastYearEntry   string
-	twoYearsEntry string
+	twoYearsEntry   string
 	viewport        viewport.Model
+
+	// The selected journal day's entries across years, for the comparison
+	// panels and the pager; sameDayKey is the "MM-DD" they are for.
+	sameDay       []journalYearEntry
+	sameDayKey    string
+	sameDayLoaded bool
+	earlierYears  bool // the day has entries in earlier years, see hasComparison
+
+	// Year comparison: indices into pagerEntries() (newest first)
+	compareFrom int
+	compareTo   int
+
+	// Single-task focus view
+	focus     historyFocus
+	focusList bool // list of days instead of the calendar; kept across tasks
+
+	// Logging a completion by date, see history_backfill.go
+	backfill historyBackfill
+
+	// Completion toggles waiting to be written
+	pendingWrites map[historyCell]bool
+	writeVersion  int // debounce generation; bumped on every queued write
+
+	// Set when a load fails; each is cleared by its next successful load
+	loadErr        error
+	journalLoadErr error
+
+	// Completion time-of-day histogram
+	completionTimes [24]int
+	statsErr        error
+
+	// Recent activity feed, shown in the viewport
+	activityErr error
 }
 
 // NewHistoryPage creates and initializes the History page.
-func NewHistoryPage(db *sql.DB) *HistoryPage {
+func NewHistoryPage(db *sql.DB, cfg config.Config) *HistoryPage {
 	// Default days until we get terminal width
-	defaultDays := 30
+	defaultDays := min(30, cfg.HistoryMaxDays)
 
-	delegate := newHistoryDelegate(defaultDays)
+	palette := heatmapPaletteFor(cfg.HeatmapPalette)
+	delegate := newHistoryDelegate(defaultDays, palette, cfg.HistoryIncludeToday)
 	l := list.New([]list.Item{}, delegate, 0, 0)
-	l.Title = "Completion History"
 	l.SetShowHelp(false)
 	l.SetFilteringEnabled(false)
 	l.SetShowStatusBar(false)
 
 	// Initialize journal list
-	journalDelegate := newJournalDelegate()
+	journalDelegate := newJournalDelegate(cfg.Formats.DateDay)
 	jl := list.New([]list.Item{}, journalDelegate, 0, 0)
 	jl.Title = "Journal History"
 	jl.SetShowHelp(false)
 	jl.SetFilteringEnabled(false)
 	jl.SetShowStatusBar(false)
 
-	return &HistoryPage{
-		list:         l,
-		delegate:     delegate,
-		db:           db,
-		daysToShow:   defaultDays,
-		selectedCell: 0,
-		mode:         historyModeTaskTable,
-		journalList:  jl,
+	p := &HistoryPage{
+		list:            l,
+		delegate:        delegate,
+		journalDelegate: journalDelegate,
+		palette:         palette,
+		includeToday:    cfg.HistoryIncludeToday,
+		weekStart:       cfg.WeekStart.Weekday(),
+		dateLayout:      cfg.Formats.DateMedium,
+		dayLayout:       cfg.Formats.DateDay,
+		timeLayout:      cfg.Formats.TimeShort,
+		db:              db,
+		daysToShow:      defaultDays,
+		maxDays:         cfg.HistoryMaxDays,
+		selectedCell:    0,
+		mode:            historyModeTaskTable,
+		journalList:     jl,
+		focusList:       cfg.HistoryFocusLayout == config.FocusLayoutList,
+		backfill:        historyBackfill{input: newBackfillInput()},
+	}
+	if cfg.HistoryMatchToday {
+		order := newTodayOrder(cfg)
+		p.order = &order
+	}
+	p.setListTitle()
+	return p
+}
+
+// setListTitle titles the task table, noting anything that changes which
+// days or tasks it shows.
+func (p *HistoryPage) setListTitle() {
+	p.list.Title = "Completion History"
+	if p.includeToday {
+		p.list.Title += " · today first"
+	}
+	if p.showInactive {
+		p.list.Title += " · with inactive"
 	}
 }
 
@@ -527,50 +983,108 @@ func (p *HistoryPage) SetSize(width, height int) {
 	contentWidth := max(width-DocStyle.GetHorizontalFrameSize(), 0)
 
 	// Calculate heights for each section
-	taskHeight, journalHeight := p.calculateHeights()
+	layout := p.calculateHeights()
 
 	p.list.SetWidth(contentWidth)
-	p.list.SetHeight(taskHeight)
+	p.list.SetHeight(layout.taskHeight)
 
 	p.journalList.SetWidth(contentWidth)
-	p.journalList.SetHeight(journalHeight)
+	p.journalList.SetHeight(layout.journalHeight)
 
 	// Update viewport for pager mode
 	p.viewport.Width = contentWidth
 	p.viewport.Height = height - 4 // -4 for header and scroll indicator
+
+	p.backfill.input.Width = max(contentWidth-4, 0)
+
+	// A narrower calendar may no longer show the selected day
+	if p.mode == historyModeTaskFocus {
+		p.clampFocus()
+	}
 }
 
-func (p *HistoryPage) calculateHeights() (taskHeight, journalHeight int) {
-	// Journal table gets fixed 5 rows + 2 for title/padding
-	journalHeight = 7
+// historyLayout is how the task table view splits its height.
+type historyLayout struct {
+	taskHeight    int
+	journalHeight int
+	comparison    bool // whether the journal comparison panels fit
+}
 
-	// Comparison boxes: 3 boxes × 4 lines each = 12
-	boxesHeight := 12
+const (
+	// historyListChrome is the rows a history list uses besides its items:
+	// the title and its padding, and the page dots.
+	historyListChrome = 3
+
+	// historyTaskMinRows is how many task rows the table keeps before the
+	// sections below it give up space.
+	historyTaskMinRows = 8
+
+	// Journal table heights: 5 rows normally, 1 when squeezed.
+	historyJournalHeight    = 7
+	historyJournalMinHeight = historyListChrome + 1
+)
 
+// calculateHeights splits the page between the task table, the journal
+// table and the comparison panels. The task table gets all the room left
+// over and pages through the tasks if they don't fit. To keep at least
+// historyTaskMinRows task rows on a short terminal, the comparison panels
+// are dropped first, then the journal table shrinks. They are also dropped
+// when there is nothing to compare, see hasComparison.
+func (p *HistoryPage) calculateHeights() historyLayout {
 	// Overhead: divider (2 lines with newlines) + newlines between sections
 	overhead := 4
-
-	// Task table gets all remaining space
-	taskHeight = p.height - journalHeight - boxesHeight - overhead
-	if taskHeight < 5 {
-		taskHeight = 5
+	avail := p.height - overhead
+	minTask := historyListChrome + historyTaskMinRows
+	boxesHeight := 3 * comparisonPanelHeight
+
+	l := historyLayout{journalHeight: historyJournalHeight}
+	if p.hasComparison() && avail-l.journalHeight-boxesHeight >= minTask {
+		l.comparison = true
+		l.taskHeight = avail - l.journalHeight - boxesHeight
+		return l
 	}
 
-	return
+	l.journalHeight = min(max(avail-minTask, historyJournalMinHeight), historyJournalHeight)
+	l.taskHeight = max(avail-l.journalHeight, historyListChrome+1)
+	return l
 }
 
 func (p *HistoryPage) InitCmd() tea.Cmd {
 	return tea.Batch(
-		loadHistoryDataCmd(p.db, p.daysToShow),
+		p.loadHistoryCmd(),
 		loadJournalHistoryCmd(p.db),
 	)
 }
 
+// loadHistoryCmd loads completions for exactly the days the heatmap shows.
+func (p *HistoryPage) loadHistoryCmd() tea.Cmd {
+	dates := p.delegate.dateRange
+	return loadHistoryDataCmd(p.db, dates[len(dates)-1], dates[0], p.order, p.showInactive)
+}
+
+// retryLoadCmd re-issues whichever loads failed.
+func (p *HistoryPage) retryLoadCmd() tea.Cmd {
+	var cmds []tea.Cmd
+	if p.loadErr != nil {
+		cmds = append(cmds, p.loadHistoryCmd())
+	}
+	if p.journalLoadErr != nil {
+		cmds = append(cmds, loadJournalHistoryCmd(p.db))
+	}
+	return tea.Batch(cmds...)
+}
+
+// RefreshCmd implements Refresher by reloading completions and journal history.
+func (p *HistoryPage) RefreshCmd() tea.Cmd {
+	return p.InitCmd()
+}
+
 func (p *HistoryPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case historyDataLoadedMsg:
+		p.loadErr = nil
 		items := make([]list.Item, len(msg.tasks))
 		for i, t := range msg.tasks {
 			items[i] = t
@@ -578,47 +1092,114 @@ func (p *HistoryPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		p.list.SetItems(items)
 
 	case historyDataLoadFailedMsg:
+		p.loadErr = msg.err
 		cmds = append(cmds, p.list.NewStatusMessage(
 			fmt.Sprintf("load failed: %v", msg.err)))
 
-	case historyCompletionSavedMsg:
-		status := fmt.Sprintf("%s: marked incomplete", msg.date)
-		if msg.completed {
-			status = fmt.Sprintf("%s: marked completed", msg.date)
+	case historyFlushTickMsg:
+		if msg.version == p.writeVersion {
+			cmds = append(cmds, p.flushWritesCmd())
 		}
-		cmds = append(cmds, p.list.NewStatusMessage(status))
 
-	case historyCompletionSaveFailedMsg:
-		// Revert optimistic update
-		for i, listItem := range p.list.Items() {
-			task, ok := listItem.(HistoryTask)
-			if !ok || task.id != msg.taskID {
-				continue
+	case HistoryCompletionsSavedMsg:
+		status := fmt.Sprintf("%d changes saved", len(msg.writes))
+		if len(msg.writes) == 1 {
+			w := msg.writes[0]
+			status = fmt.Sprintf("%s: marked incomplete", w.date)
+			if w.completed {
+				status = fmt.Sprintf("%s: marked completed", w.date)
+			}
+		}
+		cmds = append(cmds, p.list.NewStatusMessage(status))
+		today := todayKey()
+		for _, w := range msg.writes {
+			if w.date == today {
+				cmds = append(cmds, func() tea.Msg { return InvalidateTodayPageMsg{} })
+				break
 			}
-			task.completions[msg.date] = !msg.completed
-			p.list.SetItem(i, task)
-			break
 		}
+		// Keep the focus view's streak in step with the write
+		if p.mode == historyModeTaskFocus {
+			for _, w := range msg.writes {
+				if w.taskID == p.focus.task.id {
+					cmds = append(cmds, loadYearHeatmapCmd(p.db, w.taskID))
+					break
+				}
+			}
+		}
+
+	case HistoryCompletionsSaveFailedMsg:
+		logger.Errorf("history: save %d completions: %v", len(msg.writes), msg.err)
+		p.revertWrites(msg.writes, msg.err)
 		cmds = append(cmds, p.list.NewStatusMessage(fmt.Sprintf("save failed: %v", msg.err)))
 
 	case journalHistoryLoadedMsg:
+		p.journalLoadErr = nil
 		p.journalEntries = msg.entries
 		items := make([]list.Item, len(msg.entries))
 		for i, e := range msg.entries {
 			items[i] = e
 		}
 		p.journalList.SetItems(items)
+		p.SetSize(p.width, p.height) // the comparison panels may come or go
 		if len(items) > 0 {
+			cmds = append(cmds, p.journalSelectionChanged(true)) // entries may have changed
+		}
+
+	case sameDayEntriesLoadedMsg:
+		if msg.monthDay == p.sameDayKey {
+			p.sameDay = msg.entries
+			p.sameDayLoaded = true
 			p.updateComparisonBoxes()
+			if p.mode == historyModeJournalPager {
+				p.viewport.SetContent(p.buildPagerContent())
+			}
+		}
+
+	case sameDayEntriesLoadFailedMsg:
+		if msg.monthDay == p.sameDayKey {
+			p.journalLoadErr = msg.err
 		}
 
 	case journalHistoryLoadFailedMsg:
+		p.journalLoadErr = msg.err
 		cmds = append(cmds, p.journalList.NewStatusMessage(
 			fmt.Sprintf("journal load failed: %v", msg.err)))
 
+	case yearHeatmapLoadedMsg:
+		if msg.taskID == p.focus.task.id {
+			p.focus.completions = msg.completions
+			p.focus.paused = msg.paused
+			p.focus.createdDate = msg.createdDate
+			p.focus.currentStreak = msg.currentStreak
+			p.focus.loaded = true
+			p.focus.err = nil
+		}
+
+	case yearHeatmapLoadFailedMsg:
+		if msg.taskID == p.focus.task.id {
+			p.focus.err = msg.err
+		}
+
+	case completionTimesLoadedMsg:
+		p.completionTimes = msg.counts
+		p.statsErr = nil
+
+	case completionTimesLoadFailedMsg:
+		p.statsErr = msg.err
+
+	case activityLoadedMsg:
+		if p.mode == historyModeActivity {
+			p.viewport.SetContent(p.buildActivityContent(msg.events))
+			p.viewport.GotoTop()
+		}
+
+	case activityLoadFailedMsg:
+		p.activityErr = msg.err
+
 	case tea.WindowSizeMsg:
 		// Recalculate days and reload if changed
-		newDays := calculateDaysToShow(msg.Width)
+		newDays := calculateDaysToShow(msg.Width, p.maxDays)
 		if newDays != p.daysToShow {
 			p.daysToShow = newDays
 			// Clamp selectedCell to new range
@@ -626,19 +1207,49 @@ func (p *HistoryPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 				p.selectedCell = newDays - 1
 			}
 			// Update delegate with new days
-			delegate := newHistoryDelegate(newDays)
+			delegate := newHistoryDelegate(newDays, p.palette, p.includeToday)
 			delegate.selectedCell = p.selectedCell
 			p.delegate = delegate
 			p.list.SetDelegate(delegate)
 			// Reload data for new date range
-			cmds = append(cmds, loadHistoryDataCmd(p.db, p.daysToShow))
+			cmds = append(cmds, p.loadHistoryCmd())
+		}
+
+	case tea.MouseMsg:
+		if p.loadErr != nil || p.journalLoadErr != nil {
+			return p, nil
+		}
+		// The pager and compare views pass the wheel on to the viewport below
+		switch p.mode {
+		case historyModeTaskTable, historyModeJournalTable:
+			return p, p.handleTableMouse(msg)
+		case historyModeStats, historyModeTaskFocus:
+			return p, nil
 		}
 
 	case tea.KeyMsg:
+		// Only retry is available until both tables have loaded
+		if p.loadErr != nil || p.journalLoadErr != nil {
+			if key.Matches(msg, historyKeys.Retry) {
+				return p, p.retryLoadCmd()
+			}
+			return p, nil
+		}
+
 		// Mode-specific key handling
 		switch p.mode {
+		case historyModeBackfill:
+			return p.updateBackfill(msg)
+		case historyModeStats:
+			return p.handleStatsKeys(msg)
+		case historyModeActivity:
+			return p.handleActivityKeys(msg)
+		case historyModeTaskFocus:
+			return p.handleFocusKeys(msg)
 		case historyModeJournalPager:
 			return p.handlePagerKeys(msg)
+		case historyModeJournalCompare:
+			return p.handleCompareKeys(msg)
 		case historyModeJournalTable:
 			return p.handleJournalTableKeys(msg)
 		default:
@@ -653,10 +1264,12 @@ func (p *HistoryPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		prevIndex := p.journalList.Index()
 		p.journalList, listCmd = p.journalList.Update(msg)
 		if p.journalList.Index() != prevIndex {
-			p.updateComparisonBoxes()
+			cmds = append(cmds, p.journalSelectionChanged(false))
 		}
-	case historyModeJournalPager:
+	case historyModeJournalPager, historyModeJournalCompare, historyModeActivity:
 		p.viewport, listCmd = p.viewport.Update(msg)
+	case historyModeBackfill:
+		p.backfill.input, listCmd = p.backfill.input.Update(msg)
 	default:
 		p.list, listCmd = p.list.Update(msg)
 	}
@@ -686,9 +1299,33 @@ func (p *HistoryPage) handleTaskTableKeys(msg tea.KeyMsg) (Page, tea.Cmd) {
 	case key.Matches(msg, historyKeys.Toggle):
 		return p.handleSpaceToggle()
 
+	case key.Matches(msg, historyKeys.NextMiss):
+		return p, p.jumpToMiss(1)
+
+	case key.Matches(msg, historyKeys.PrevMiss):
+		return p, p.jumpToMiss(-1)
+
 	case key.Matches(msg, historyKeys.SwitchTable):
 		p.mode = historyModeJournalTable
 		return p, nil
+
+	case key.Matches(msg, historyKeys.Stats):
+		p.mode = historyModeStats
+		return p, loadCompletionTimesCmd(p.db)
+
+	case key.Matches(msg, historyKeys.Activity):
+		return p, p.openActivityView()
+
+	case key.Matches(msg, historyKeys.Focus):
+		return p, p.openFocusView()
+
+	case key.Matches(msg, historyKeys.Backfill):
+		return p, p.openBackfill()
+
+	case key.Matches(msg, historyKeys.Inactive):
+		p.showInactive = !p.showInactive
+		p.setListTitle()
+		return p, p.loadHistoryCmd()
 	}
 
 	// Check for j/down at last item to switch to journal list
@@ -705,6 +1342,56 @@ func (p *HistoryPage) handleTaskTableKeys(msg tea.KeyMsg) (Page, tea.Cmd) {
 	return p, listCmd
 }
 
+// handleTableMouse handles the mouse over the task and journal tables: the
+// wheel scrolls the table under the pointer and a click selects a row and
+// heatmap cell. Clicking the cell that is already selected toggles it, like
+// space.
+func (p *HistoryPage) handleTableMouse(msg tea.MouseMsg) tea.Cmd {
+	// The journal table follows the task table and the divider line
+	if journalTop := p.list.Height() + 1; msg.Y >= journalTop {
+		msg.Y -= journalTop
+		if !scrollList(&p.journalList, msg) {
+			if !isClick(msg) {
+				return nil
+			}
+			idx := listItemAt(p.journalList, p.journalDelegate, msg.Y)
+			if idx < 0 {
+				return nil
+			}
+			p.journalList.Select(idx)
+		}
+		p.mode = historyModeJournalTable
+		return p.journalSelectionChanged(false)
+	}
+
+	if scrollList(&p.list, msg) {
+		p.mode = historyModeTaskTable
+		return nil
+	}
+	if !isClick(msg) {
+		return nil
+	}
+	idx := listItemAt(p.list, p.delegate, msg.Y)
+	if idx < 0 {
+		return nil
+	}
+	p.mode = historyModeTaskTable
+	wasSelected := idx == p.list.Index()
+	p.list.Select(idx)
+
+	cell := p.delegate.cellAt(msg.X, p.list.Width())
+	if cell < 0 {
+		return nil
+	}
+	if wasSelected && cell == p.selectedCell {
+		_, cmd := p.handleSpaceToggle()
+		return cmd
+	}
+	p.selectedCell = cell
+	p.delegate.selectedCell = cell
+	return nil
+}
+
 func (p *HistoryPage) handleJournalTableKeys(msg tea.KeyMsg) (Page, tea.Cmd) {
 	switch {
 	case key.Matches(msg, historyKeys.SwitchTable):
@@ -731,7 +1418,7 @@ func (p *HistoryPage) handleJournalTableKeys(msg tea.KeyMsg) (Page, tea.Cmd) {
 	prevIndex := p.journalList.Index()
 	p.journalList, listCmd = p.journalList.Update(msg)
 	if p.journalList.Index() != prevIndex {
-		p.updateComparisonBoxes()
+		return p, tea.Batch(listCmd, p.journalSelectionChanged(false))
 	}
 	return p, listCmd
 }
@@ -741,6 +1428,10 @@ func (p *HistoryPage) handlePagerKeys(msg tea.KeyMsg) (Page, tea.Cmd) {
 		p.mode = historyModeJournalTable
 		return p, nil
 	}
+	if key.Matches(msg, historyKeys.Compare) {
+		p.openCompareView()
+		return p, nil
+	}
 
 	// Let viewport handle navigation
 	var cmd tea.Cmd
@@ -748,6 +1439,54 @@ func (p *HistoryPage) handlePagerKeys(msg tea.KeyMsg) (Page, tea.Cmd) {
 	return p, cmd
 }
 
+func (p *HistoryPage) handleCompareKeys(msg tea.KeyMsg) (Page, tea.Cmd) {
+	n := len(p.pagerEntries())
+	switch {
+	case key.Matches(msg, historyKeys.Back):
+		p.openPagerView()
+		return p, nil
+
+	// "[" and "{" step towards older years, "]" and "}" towards newer
+	case key.Matches(msg, historyKeys.FromYear):
+		p.compareFrom = stepCompareIndex(p.compareFrom, p.compareTo, n, msg.String() == "[")
+		p.refreshCompareView()
+		return p, nil
+
+	case key.Matches(msg, historyKeys.ToYear):
+		p.compareTo = stepCompareIndex(p.compareTo, p.compareFrom, n, msg.String() == "{")
+		p.refreshCompareView()
+		return p, nil
+	}
+
+	var cmd tea.Cmd
+	p.viewport, cmd = p.viewport.Update(msg)
+	return p, cmd
+}
+
+// stepCompareIndex moves idx one entry older or newer, skipping other so the
+// two sides of a comparison never show the same year.
+func stepCompareIndex(idx, other, n int, older bool) int {
+	step := -1
+	if older {
+		step = 1
+	}
+	next := idx + step
+	if next == other {
+		next += step
+	}
+	if next < 0 || next >= n {
+		return idx
+	}
+	return next
+}
+
+func (p *HistoryPage) handleStatsKeys(msg tea.KeyMsg) (Page, tea.Cmd) {
+	if key.Matches(msg, historyKeys.Back) || key.Matches(msg, historyKeys.Stats) {
+		p.mode = historyModeTaskTable
+	}
+	return p, nil
+}
+
 func (p *HistoryPage) handleSpaceToggle() (Page, tea.Cmd) {
 	idx := p.list.Index()
 	if idx < 0 || idx >= len(p.list.Items()) {
@@ -771,12 +1510,111 @@ func (p *HistoryPage) handleSpaceToggle() (Page, tea.Cmd) {
 	// Update list item
 	setCmd := p.list.SetItem(idx, item)
 
-	// Persist to DB
-	saveCmd := saveHistoryCompletionCmd(p.db, item.id, selectedDate, newCompleted)
+	// Persist to DB with the next batch
+	saveCmd := p.queueWrite(item.id, selectedDate, newCompleted)
 
 	return p, tea.Batch(setCmd, saveCmd)
 }
 
+// jumpToMiss moves the selected cell to the selected task's nearest missed
+// day in direction step: 1 for older (right), -1 for newer (left). Paused
+// days, days before the task was added and today are skipped, so filling
+// gaps is space, n, space, n...
+func (p *HistoryPage) jumpToMiss(step int) tea.Cmd {
+	task, ok := p.list.SelectedItem().(HistoryTask)
+	if !ok {
+		return nil
+	}
+	today := todayKey()
+	for i := p.selectedCell + step; i >= 0 && i < len(p.delegate.dateRange); i += step {
+		if task.missed(p.delegate.dateRange[i], today) {
+			p.selectedCell = i
+			p.delegate.selectedCell = i
+			return nil
+		}
+	}
+	if step > 0 {
+		return p.list.NewStatusMessage("no earlier misses")
+	}
+	return p.list.NewStatusMessage("no later misses")
+}
+
+// queueWrite buffers a completion change (replacing any earlier one for the
+// same cell) and restarts the flush debounce.
+func (p *HistoryPage) queueWrite(taskID, date string, completed bool) tea.Cmd {
+	if p.pendingWrites == nil {
+		p.pendingWrites = make(map[historyCell]bool)
+	}
+	p.pendingWrites[historyCell{taskID, date}] = completed
+	p.writeVersion++
+	return historyFlushTickCmd(p.writeVersion)
+}
+
+// takeWrites empties the pending buffer and returns its contents.
+func (p *HistoryPage) takeWrites() []historyWrite {
+	writes := make([]historyWrite, 0, len(p.pendingWrites))
+	for k, completed := range p.pendingWrites {
+		writes = append(writes, historyWrite{taskID: k.taskID, date: k.date, completed: completed})
+	}
+	p.pendingWrites = nil
+	return writes
+}
+
+func (p *HistoryPage) flushWritesCmd() tea.Cmd {
+	if len(p.pendingWrites) == 0 {
+		return nil
+	}
+	return saveHistoryCompletionsCmd(p.db, p.takeWrites())
+}
+
+// revertWrites undoes the optimistic updates for a failed batch, skipping
+// cells that have been toggled again since and are queued for the next one.
+func (p *HistoryPage) revertWrites(writes []historyWrite, err error) {
+	for _, w := range writes {
+		if _, requeued := p.pendingWrites[historyCell{w.taskID, w.date}]; requeued {
+			continue
+		}
+		for i, listItem := range p.list.Items() {
+			task, ok := listItem.(HistoryTask)
+			if !ok || task.id != w.taskID {
+				continue
+			}
+			task.completions[w.date] = !w.completed
+			p.list.SetItem(i, task)
+			break
+		}
+		if p.focus.loaded && p.focus.task.id == w.taskID {
+			p.focus.completions[w.date] = !w.completed
+			p.focus.status = fmt.Sprintf("save failed: %v", err)
+		}
+	}
+}
+
+// Leave implements Leaver by writing pending toggles immediately, since the
+// debounce tick is only delivered while this page is active. The result is
+// handled like a flush's, which tells the Today page if today changed.
+func (p *HistoryPage) Leave() tea.Cmd {
+	if len(p.pendingWrites) == 0 {
+		return nil
+	}
+	writes := p.takeWrites()
+	err := saveHistoryCompletions(p.db, writes)
+	return func() tea.Msg {
+		if err != nil {
+			return HistoryCompletionsSaveFailedMsg{writes: writes, err: err}
+		}
+		return HistoryCompletionsSavedMsg{writes: writes}
+	}
+}
+
+// Shutdown implements Shutdowner by writing pending toggles before exit.
+func (p *HistoryPage) Shutdown() error {
+	if len(p.pendingWrites) == 0 {
+		return nil
+	}
+	return saveHistoryCompletions(p.db, p.takeWrites())
+}
+
 // ---------------------------------------------------------------------------
 // Journal comparison boxes
 // ---------------------------------------------------------------------------
@@ -784,110 +1622,91 @@ func (p *HistoryPage) handleSpaceToggle() (Page, tea.Cmd) {
 func (p *HistoryPage) getSelectedJournalDate() time.Time {
 	idx := p.journalList.Index()
 	if idx < 0 || idx >= len(p.journalEntries) {
-		return time.Now()
+		return homeNow()
 	}
 	return p.journalEntries[idx].entryDate
 }
 
+// journalSelectionChanged updates the comparison panels for the selected
+// journal entry, loading its day's entries across years if the selection
+// moved to another day of the year, or if reload is set. A reload keeps
+// showing the entries already loaded until it completes.
+func (p *HistoryPage) journalSelectionChanged(reload bool) tea.Cmd {
+	selected := p.getSelectedJournalDate()
+	monthDay := selected.Format("01-02")
+	if monthDay != p.sameDayKey {
+		p.sameDayKey = monthDay
+		p.sameDay = nil
+		p.sameDayLoaded = false
+		reload = true
+	}
+	p.updateComparisonBoxes()
+	if !reload {
+		return nil
+	}
+	return loadSameDayEntriesCmd(p.db, selected.Month(), selected.Day())
+}
+
 func (p *HistoryPage) updateComparisonBoxes() {
-	selectedDate := p.getSelectedJournalDate()
+	thisYear := p.getSelectedJournalDate().Year()
 
 	// Clear existing
 	p.thisYearEntry = ""
 	p.lastYearEntry = ""
 	p.twoYearsEntry = ""
 
-	thisYear := selectedDate.Year()
-	lastYear := thisYear - 1
-	twoYearsAgo := thisYear - 2
-
-	month := selectedDate.Month()
-	day := selectedDate.Day()
-
-	for _, entry := range p.journalEntries {
-		if entry.entryDate.Month() == month && entry.entryDate.Day() == day {
-			switch entry.entryDate.Year() {
-			case thisYear:
-				p.thisYearEntry = entry.content
-			case lastYear:
-				p.lastYearEntry = entry.content
-			case twoYearsAgo:
-				p.twoYearsEntry = entry.content
-			}
+	for _, entry := range p.sameDay {
+		switch entry.year {
+		case thisYear:
+			p.thisYearEntry = entry.content
+		case thisYear - 1:
+			p.lastYearEntry = entry.content
+		case thisYear - 2:
+			p.twoYearsEntry = entry.content
 		}
 	}
+	// Keep the last day's answer while the next loads, so the layout
+	// doesn't jump while moving through the journal table
+	if p.sameDayLoaded {
+		p.earlierYears = p.lastYearEntry != "" || p.twoYearsEntry != ""
+	}
+	p.SetSize(p.width, p.height) // the comparison panels may come or go
 }
 
+// hasComparison reports whether the comparison panels have anything to
+// compare: an entry on the selected day in one of the two years before.
+// This year's panel alone only repeats the selected entry.
+func (p *HistoryPage) hasComparison() bool {
+	return len(p.journalEntries) > 0 && p.earlierYears
+}
+
+// comparisonPanelHeight is the height of each journal comparison panel:
+// two lines of the entry below the title.
+const comparisonPanelHeight = panelChrome + 2
+
 func (p *HistoryPage) renderComparisonBoxes() string {
 	selectedDate := p.getSelectedJournalDate()
 	thisYear := selectedDate.Year()
 
-	boxWidth := p.width - DocStyle.GetHorizontalFrameSize() - 4
-	if boxWidth < 20 {
-		boxWidth = 20
-	}
+	boxWidth := max(p.width-DocStyle.GetHorizontalFrameSize()-2, 22)
 
-	// Fixed small height - just 2 lines of content
-	boxHeight := 4 // 2 lines content + 2 for border
-
-	boxStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#555555")).
-		Width(boxWidth).
-		Height(boxHeight)
-
-	titleStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#888888"))
-
-	noEntryStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#555555")).
-		Italic(true)
-
-	boxes := []struct {
-		title   string
-		content string
-	}{
-		{fmt.Sprintf("This Year (%d)", thisYear), p.thisYearEntry},
-		{fmt.Sprintf("Last Year (%d)", thisYear-1), p.lastYearEntry},
-		{fmt.Sprintf("2 Years Ago (%d)", thisYear-2), p.twoYearsEntry},
-	}
-
-	var renderedBoxes []string
-	for _, box := range boxes {
-		content := box.content
-		if content == "" {
-			content = noEntryStyle.Render("No entry")
-		} else {
-			content = truncateContent(content, boxWidth-2, 2)
-		}
+	panels := []panel{
+		{title: fmt.Sprintf("This Year (%d)", thisYear), content: p.thisYearEntry},
+		{title: fmt.Sprintf("Last Year (%d)", thisYear-1), content: p.lastYearEntry},
+		{title: fmt.Sprintf("2 Years Ago (%d)", thisYear-2), content: p.twoYearsEntry},
+	}
 
-		boxContent := titleStyle.Render(box.title) + "\n" + content
-		renderedBoxes = append(renderedBoxes, boxStyle.Render(boxContent))
+	renderedBoxes := make([]string, len(panels))
+	for i, pn := range panels {
+		pn.empty = "No entry"
+		pn.width = boxWidth
+		pn.height = comparisonPanelHeight
+		renderedBoxes[i] = pn.View()
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, renderedBoxes...)
 }
 
-func truncateContent(content string, width, maxLines int) string {
-	lines := strings.Split(content, "\n")
-	var result []string
-	for i, line := range lines {
-		if i >= maxLines {
-			break
-		}
-		if len(line) > width {
-			result = append(result, line[:width-3]+"...")
-		} else {
-			result = append(result, line)
-		}
-	}
-	if len(lines) > maxLines && len(result) > 0 {
-		result[len(result)-1] = "..."
-	}
-	return strings.Join(result, "\n")
-}
-
 // ---------------------------------------------------------------------------
 // Pager view
 // ---------------------------------------------------------------------------
@@ -903,9 +1722,20 @@ func (p *HistoryPage) openPagerView() {
 	p.viewport.GotoTop()
 }
 
+// journalYearEntry is one year's journal entry for the pager's day.
+type journalYearEntry struct {
+	year    int
+	content string
+}
+
+// pagerEntries returns the entries for the selected journal day/month across
+// all years, newest first (see journalSelectionChanged).
+func (p *HistoryPage) pagerEntries() []journalYearEntry {
+	return p.sameDay
+}
+
 func (p *HistoryPage) buildPagerContent() string {
-	selectedDate := p.getSelectedJournalDate()
-	dayMonth := selectedDate.Format("January 2")
+	dayMonth := p.getSelectedJournalDate().Format(p.dayLayout)
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -914,28 +1744,10 @@ func (p *HistoryPage) buildPagerContent() string {
 	dividerStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#555555"))
 
-	// Collect all entries for this day/month across all years
-	type yearEntry struct {
-		year    int
-		content string
-	}
-	var entries []yearEntry
-
-	for _, entry := range p.journalEntries {
-		if entry.entryDate.Month() == selectedDate.Month() &&
-			entry.entryDate.Day() == selectedDate.Day() {
-			entries = append(entries, yearEntry{
-				year:    entry.entryDate.Year(),
-				content: entry.content,
-			})
-		}
+	entries := p.pagerEntries()
+	if !p.sameDayLoaded {
+		return "Loading..."
 	}
-
-	// Sort by year descending (newest first)
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].year > entries[j].year
-	})
-
 	if len(entries) == 0 {
 		return "No journal entries for " + dayMonth
 	}
@@ -970,7 +1782,13 @@ func (p *HistoryPage) viewPager() string {
 	hintStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#555555"))
 
-	b.WriteString(headerStyle.Render("Journal Entry Viewer"))
+	header := "Journal Entry Viewer"
+	if p.mode == historyModeJournalCompare {
+		entries := p.pagerEntries()
+		header = fmt.Sprintf("Compare %d → %d",
+			entries[p.compareFrom].year, entries[p.compareTo].year)
+	}
+	b.WriteString(headerStyle.Render(header))
 	b.WriteString(" ")
 	b.WriteString(hintStyle.Render("(press esc or q to return)"))
 	b.WriteString("\n\n")
@@ -986,13 +1804,186 @@ func (p *HistoryPage) viewPager() string {
 	return b.String()
 }
 
+// ---------------------------------------------------------------------------
+// Year comparison
+// ---------------------------------------------------------------------------
+
+// openCompareView switches the pager to a line diff between two years' entries
+// for the same day, starting with the two most recent. Needs at least two.
+func (p *HistoryPage) openCompareView() {
+	if len(p.pagerEntries()) < 2 {
+		return
+	}
+	p.mode = historyModeJournalCompare
+	p.compareFrom, p.compareTo = 1, 0
+	p.refreshCompareView()
+}
+
+func (p *HistoryPage) refreshCompareView() {
+	p.viewport.SetContent(p.buildCompareContent())
+	p.viewport.GotoTop()
+}
+
+// buildCompareContent renders a unified line diff from the older selected
+// year to the newer one. Long lines are wrapped to the viewport width with the
+// diff marker repeated, so entries of very different lengths stay readable.
+func (p *HistoryPage) buildCompareContent() string {
+	entries := p.pagerEntries()
+	from, to := entries[p.compareFrom], entries[p.compareTo]
+
+	removedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B"))
+	addedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+	sameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+
+	a := strings.Split(from.content, "\n")
+	b := strings.Split(to.content, "\n")
+	width := max(p.viewport.Width-2, 10)
+
+	var out strings.Builder
+	writeLines := func(lines []string, marker string, style lipgloss.Style) {
+		for _, line := range lines {
+			for _, part := range strings.Split(ansi.Wrap(line, width, " "), "\n") {
+				out.WriteString(style.Render(marker + part))
+				out.WriteString("\n")
+			}
+		}
+	}
+
+	for _, op := range difflib.NewMatcher(a, b).GetOpCodes() {
+		switch op.Tag {
+		case 'e':
+			writeLines(a[op.I1:op.I2], "  ", sameStyle)
+		case 'd':
+			writeLines(a[op.I1:op.I2], "- ", removedStyle)
+		case 'i':
+			writeLines(b[op.J1:op.J2], "+ ", addedStyle)
+		case 'r':
+			writeLines(a[op.I1:op.I2], "- ", removedStyle)
+			writeLines(b[op.J1:op.J2], "+ ", addedStyle)
+		}
+	}
+	return out.String()
+}
+
+// ---------------------------------------------------------------------------
+// Completion time-of-day histogram
+// ---------------------------------------------------------------------------
+
+// histogramBlocks are the partial-height glyphs used for the top of each bar.
+var histogramBlocks = []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+func (p *HistoryPage) viewStats() string {
+	var b strings.Builder
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#04B575"))
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#555555"))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B"))
+
+	b.WriteString(headerStyle.Render("Completions by Time of Day"))
+	b.WriteString(" ")
+	b.WriteString(hintStyle.Render("(press esc or q to return)"))
+	b.WriteString("\n\n")
+
+	if p.statsErr != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("load failed: %v", p.statsErr)))
+		return b.String()
+	}
+
+	total, peak := 0, 0
+	for _, c := range p.completionTimes {
+		total += c
+		peak = max(peak, c)
+	}
+	if total == 0 {
+		b.WriteString(hintStyle.Render("No timed completions yet. Complete tasks on the Today page to build this up."))
+		return b.String()
+	}
+
+	// Each hour gets a fixed-width column; shrink to fit narrow terminals.
+	contentWidth := p.width - DocStyle.GetHorizontalFrameSize()
+	colWidth := min(max((contentWidth-6)/24, 1), 3)
+	chartHeight := min(max(p.height-8, 3), 12)
+
+	barStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+	axisStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+
+	// Render rows top-down; each row covers 8 eighths of bar height.
+	for row := chartHeight - 1; row >= 0; row-- {
+		label := "    "
+		if row == chartHeight-1 {
+			label = fmt.Sprintf("%3d ", peak)
+		}
+		b.WriteString(axisStyle.Render(label))
+		for _, c := range p.completionTimes {
+			eighths := c * chartHeight * 8 / peak
+			fill := min(max(eighths-row*8, 0), 8)
+			b.WriteString(barStyle.Render(strings.Repeat(histogramBlocks[fill], colWidth)))
+		}
+		b.WriteString("\n")
+	}
+
+	// Hour axis: label every 3 hours when columns are narrow, every 2 otherwise.
+	step := 3
+	if colWidth >= 3 {
+		step = 2
+	}
+	axis := make([]rune, 24*colWidth)
+	for i := range axis {
+		axis[i] = ' '
+	}
+	for h := 0; h < 24; h += step {
+		lbl := fmt.Sprintf("%d", h)
+		for i, r := range lbl {
+			if pos := h*colWidth + i; pos < len(axis) {
+				axis[pos] = r
+			}
+		}
+	}
+	b.WriteString(axisStyle.Render("    " + string(axis)))
+	b.WriteString("\n\n")
+
+	peakHour := 0
+	for h, c := range p.completionTimes {
+		if c > p.completionTimes[peakHour] {
+			peakHour = h
+		}
+	}
+	b.WriteString(hintStyle.Render(fmt.Sprintf(
+		"%d timed completions · busiest hour %02d:00–%02d:59 · backfilled days excluded",
+		total, peakHour, peakHour)))
+
+	return b.String()
+}
+
 // ---------------------------------------------------------------------------
 // View and KeyMap
 // ---------------------------------------------------------------------------
 
 func (p *HistoryPage) View() string {
-	if p.mode == historyModeJournalPager {
+	switch p.mode {
+	case historyModeJournalPager, historyModeJournalCompare:
 		return p.viewPager()
+	case historyModeStats:
+		return p.viewStats()
+	case historyModeActivity:
+		return p.viewActivity()
+	case historyModeTaskFocus:
+		return p.viewFocus()
+	case historyModeBackfill:
+		return p.viewBackfill()
+	}
+
+	if p.loadErr != nil {
+		return renderLoadError("completion history", p.loadErr)
+	}
+	if p.journalLoadErr != nil {
+		return renderLoadError("journal history", p.journalLoadErr)
 	}
 
 	var b strings.Builder
@@ -1001,29 +1992,93 @@ func (p *HistoryPage) View() string {
 	b.WriteString(p.list.View())
 	b.WriteString("\n")
 
-	// Section divider
+	// Section divider, carrying the selected cell's completion note if any,
+	// or else explaining where a completion from today went
 	dividerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#444444"))
-	contentWidth := p.width - DocStyle.GetHorizontalFrameSize()
-	b.WriteString(dividerStyle.Render(strings.Repeat("─", contentWidth)))
+	contentWidth := max(p.width-DocStyle.GetHorizontalFrameSize(), 0)
+	note := p.selectedNote()
+	if t, ok := p.list.SelectedItem().(HistoryTask); ok && note == "" && t.doneToday {
+		note = "Done today · today's completions appear here tomorrow; use the Today tab for today"
+	}
+	if note != "" && contentWidth > 8 {
+		noteStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#AAAAAA"))
+		label := ansi.Truncate(strings.ReplaceAll(note, "\n", " "), contentWidth-6, ellipsis)
+		b.WriteString(dividerStyle.Render("── "))
+		b.WriteString(noteStyle.Render(label))
+		b.WriteString(dividerStyle.Render(" " + strings.Repeat("─", max(contentWidth-4-ansi.StringWidth(label), 0))))
+	} else {
+		b.WriteString(dividerStyle.Render(strings.Repeat("─", contentWidth)))
+	}
 	b.WriteString("\n")
 
 	// Journal list (title rendered by list component)
 	b.WriteString(p.journalList.View())
 	b.WriteString("\n")
 
-	// Comparison boxes
-	if len(p.journalEntries) > 0 {
+	// Comparison boxes, if there is room and anything to compare
+	if p.calculateHeights().comparison {
 		b.WriteString(p.renderComparisonBoxes())
 	}
 
 	return b.String()
 }
 
+// selectedNote returns the completion note for the selected task and day.
+func (p *HistoryPage) selectedNote() string {
+	t, ok := p.list.SelectedItem().(HistoryTask)
+	if !ok || p.selectedCell < 0 || p.selectedCell >= len(p.delegate.dateRange) {
+		return ""
+	}
+	date := p.delegate.dateRange[p.selectedCell]
+	if !t.completions[date] {
+		return "" // note row goes away when a completion is undone
+	}
+	return t.notes[date]
+}
+
+// DebugLayout implements LayoutDebugger.
+func (p *HistoryPage) DebugLayout() []LayoutValue {
+	layout := p.calculateHeights()
+	comparison := 0
+	if layout.comparison {
+		comparison = 1
+	}
+	return []LayoutValue{
+		{"days", p.daysToShow},
+		{"taskH", layout.taskHeight},
+		{"journalH", layout.journalHeight},
+		{"compare", comparison},
+		{"pagerH", p.viewport.Height},
+	}
+}
+
 func (p *HistoryPage) KeyMap() []key.Binding {
+	if p.loadErr != nil || p.journalLoadErr != nil {
+		return []key.Binding{historyKeys.Retry}
+	}
 	switch p.mode {
 	case historyModeJournalPager:
 		return []key.Binding{
 			historyKeys.Back,
+			historyKeys.Compare,
+		}
+	case historyModeJournalCompare:
+		return []key.Binding{
+			historyKeys.Back,
+			historyKeys.FromYear,
+			historyKeys.ToYear,
+		}
+	case historyModeStats, historyModeActivity:
+		return []key.Binding{
+			historyKeys.Back,
+		}
+	case historyModeBackfill:
+		return nil // the prompt says how to save or cancel
+	case historyModeTaskFocus:
+		return []key.Binding{
+			historyKeys.Back,
+			historyKeys.Toggle,
+			historyKeys.Layout,
 		}
 	case historyModeJournalTable:
 		return []key.Binding{
@@ -1035,16 +2090,25 @@ func (p *HistoryPage) KeyMap() []key.Binding {
 			historyKeys.Earlier,
 			historyKeys.Later,
 			historyKeys.Toggle,
+			historyKeys.NextMiss,
 			historyKeys.SwitchTable,
+			historyKeys.Stats,
+			historyKeys.Activity,
+			historyKeys.Focus,
+			historyKeys.Backfill,
+			historyKeys.Inactive,
 		}
 	}
 }
 
 // CapturesNavigation implements NavigationCapturer to prevent page switching in pager mode.
 func (p *HistoryPage) CapturesNavigation() bool {
-	return p.mode == historyModeJournalPager
+	return p.mode == historyModeJournalPager || p.mode == historyModeJournalCompare ||
+		p.mode == historyModeTaskFocus || p.mode == historyModeActivity ||
+		p.mode == historyModeBackfill
 }
 
+// CapturesGlobalKeys lets the past completion prompt take any key, e.g. q.
 func (p *HistoryPage) CapturesGlobalKeys() bool {
-	return false // Allow global keys in all modes
+	return p.mode == historyModeBackfill
 }
@@ -0,0 +1,252 @@
+package pages
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ---------------------------------------------------------------------------
+// Activity feed
+// ---------------------------------------------------------------------------
+
+// activityDays is how many days back, counting today, the feed goes.
+const activityDays = 14
+
+type activityKind int
+
+const (
+	activityTask activityKind = iota
+	activityJournal
+	activityPlant
+)
+
+// activityEvent is one thing done: a task completed, a journal entry
+// written or a plant cared for.
+type activityEvent struct {
+	at      time.Time
+	untimed bool // a backfilled completion, recorded at midnight
+	kind    activityKind
+	text    string
+}
+
+type activityLoadedMsg struct {
+	events []activityEvent
+}
+
+type activityLoadFailedMsg struct {
+	err error
+}
+
+var activityStyles = map[activityKind]struct {
+	glyph string
+	style lipgloss.Style
+}{
+	activityTask:    {"✓", lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))},
+	activityJournal: {"✎", lipgloss.NewStyle().Foreground(lipgloss.Color("#00CED1"))},
+	activityPlant:   {"✿", lipgloss.NewStyle().Foreground(lipgloss.Color("#22C55E"))},
+}
+
+// plantCareVerbs describes each Planta action type once done.
+var plantCareVerbs = map[string]string{
+	"watering":    "Watered",
+	"fertilizing": "Fertilized",
+	"misting":     "Misted",
+	"cleaning":    "Cleaned",
+	"repotting":   "Repotted",
+}
+
+// loadActivityCmd loads the last activityDays of task completions, journal
+// entries and plant care, most recent first.
+func loadActivityCmd(db *sql.DB) tea.Cmd {
+	since := dateKey(addDays(homeNow(), -(activityDays - 1)))
+	return func() tea.Msg {
+		events, err := loadActivity(db, since)
+		if err != nil {
+			return activityLoadFailedMsg{err: err}
+		}
+		return activityLoadedMsg{events: events}
+	}
+}
+
+func loadActivity(db *sql.DB, since string) ([]activityEvent, error) {
+	var events []activityEvent
+
+	// Each query yields a home-zone "YYYY-MM-DD HH:MM:SS" time and the
+	// event's text. Journal versions are stored in UTC, so are marked with a
+	// trailing Z to convert.
+	queries := []struct {
+		kind  activityKind
+		query string
+	}{
+		{activityTask, `
+			SELECT COALESCE(strftime('%Y-%m-%d %H:%M:%S', h.completed_at), h.completed_date || ' 00:00:00'),
+			       d.title
+			FROM task_completions h JOIN task_definitions d ON d.id = h.task_id
+			WHERE h.completed_date >= ? AND d.deleted = false
+		`},
+		{activityJournal, `
+			SELECT COALESCE(strftime('%Y-%m-%d %H:%M:%S', updated_at) || 'Z', entry_date || ' 00:00:00'),
+			       content
+			FROM journal_entries
+			WHERE entry_date >= ? AND trim(content) != ''
+		`},
+		{activityPlant, `
+			SELECT strftime('%Y-%m-%d %H:%M:%S', completed_at), action_type || ' ' || plant_name
+			FROM plant_care_log
+			WHERE completed_at >= ?
+		`},
+	}
+	for _, q := range queries {
+		rows, err := db.Query(q.query, since)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var at, text string
+			if err := rows.Scan(&at, &text); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			t, ok := parseActivityTime(at)
+			if !ok {
+				continue
+			}
+			events = append(events, activityEvent{
+				at:      t,
+				untimed: q.kind == activityTask && t.Equal(startOfDay(t)),
+				kind:    q.kind,
+				text:    activityText(q.kind, text),
+			})
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].at.After(events[j].at)
+	})
+	return events, nil
+}
+
+// parseActivityTime reads a query's time column; see loadActivity.
+func parseActivityTime(at string) (time.Time, bool) {
+	if utc, ok := strings.CutSuffix(at, "Z"); ok {
+		return fromUTC(utc)
+	}
+	t, err := time.ParseInLocation(sqliteTimestamp, at, homeLoc)
+	return t, err == nil
+}
+
+// activityText turns a query's text column into the line shown for it.
+func activityText(kind activityKind, text string) string {
+	switch kind {
+	case activityJournal:
+		for _, line := range strings.Split(text, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				return "Journal · " + line
+			}
+		}
+		return "Journal"
+	case activityPlant:
+		action, plant, _ := strings.Cut(text, " ")
+		if verb, ok := plantCareVerbs[action]; ok {
+			return verb + " " + plant
+		}
+		return plant + ": " + action
+	}
+	return text
+}
+
+// openActivityView shows the activity feed, loading it afresh.
+func (p *HistoryPage) openActivityView() tea.Cmd {
+	p.mode = historyModeActivity
+	p.activityErr = nil
+	p.viewport = viewport.New(p.width-DocStyle.GetHorizontalFrameSize(), p.height-4)
+	p.viewport.SetContent(lipgloss.NewStyle().Foreground(lipgloss.Color("#555555")).Render("Loading..."))
+	return loadActivityCmd(p.db)
+}
+
+// buildActivityContent lists events under a heading for each day.
+func (p *HistoryPage) buildActivityContent(events []activityEvent) string {
+	dayStyle := lipgloss.NewStyle().Bold(true)
+	timeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+
+	if len(events) == 0 {
+		return timeStyle.Render(fmt.Sprintf("Nothing done in the last %d days yet.", activityDays))
+	}
+
+	// Clocks are padded to the widest the layout gets, e.g. "10:00PM"
+	clockWidth := max(ansi.StringWidth(time.Date(2006, 1, 2, 10, 0, 0, 0, time.UTC).Format(p.timeLayout)),
+		ansi.StringWidth(time.Date(2006, 1, 2, 22, 0, 0, 0, time.UTC).Format(p.timeLayout)))
+	width := p.viewport.Width
+	var b strings.Builder
+	day := ""
+	for _, e := range events {
+		if d := dateKey(e.at); d != day {
+			if day != "" {
+				b.WriteString("\n")
+			}
+			day = d
+			b.WriteString(dayStyle.Render(e.at.Format(p.dateLayout)))
+			b.WriteString("\n")
+		}
+		clock := e.at.Format(p.timeLayout)
+		if e.untimed {
+			clock = strings.Repeat(" ", (clockWidth-1)/2) + "·"
+		}
+		clock += strings.Repeat(" ", max(clockWidth-ansi.StringWidth(clock), 0))
+		s := activityStyles[e.kind]
+		line := ansi.Truncate(e.text, max(width-clockWidth-6, 1), ellipsis)
+		b.WriteString("  " + timeStyle.Render(clock) + "  " + s.style.Render(s.glyph) + " " + line + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (p *HistoryPage) handleActivityKeys(msg tea.KeyMsg) (Page, tea.Cmd) {
+	if key.Matches(msg, historyKeys.Back) || key.Matches(msg, historyKeys.Activity) {
+		p.mode = historyModeTaskTable
+		return p, nil
+	}
+	var cmd tea.Cmd
+	p.viewport, cmd = p.viewport.Update(msg)
+	return p, cmd
+}
+
+func (p *HistoryPage) viewActivity() string {
+	var b strings.Builder
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#04B575"))
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#555555"))
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("Recent Activity · last %d days", activityDays)))
+	b.WriteString(" ")
+	b.WriteString(hintStyle.Render("(press esc or q to return)"))
+	b.WriteString("\n\n")
+
+	if p.activityErr != nil {
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render(
+			fmt.Sprintf("load failed: %v", p.activityErr)))
+		return b.String()
+	}
+
+	b.WriteString(p.viewport.View())
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render(fmt.Sprintf("%d%%", int(p.viewport.ScrollPercent()*100))))
+	return b.String()
+}
@@ -0,0 +1,149 @@
+package pages
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ---------------------------------------------------------------------------
+// Logging a past completion by date
+// ---------------------------------------------------------------------------
+
+// The heatmap only reaches back as far as the terminal is wide, and the
+// focus view a year. L on the task table asks for a date instead and marks
+// the selected task completed on it, however long ago.
+
+// errTaskGone is returned when logging a completion for a task that has been
+// deleted since the table loaded.
+var errTaskGone = errors.New("task no longer exists")
+
+// historyBackfill holds the state of the past completion prompt.
+type historyBackfill struct {
+	input textinput.Model
+	task  HistoryTask
+	err   error
+}
+
+func newBackfillInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "2006-01-02"
+	ti.CharLimit = 10
+	return ti
+}
+
+// parseBackfillDate reads the past completion prompt: a date like
+// "2006-01-02", no later than today.
+func parseBackfillDate(input string, now time.Time) (string, error) {
+	date, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(input), now.Location())
+	if err != nil {
+		return "", fmt.Errorf("enter a date like 2006-01-02")
+	}
+	if dateKey(date) > dateKey(now) {
+		return "", fmt.Errorf("that date hasn't happened yet")
+	}
+	return dateKey(date), nil
+}
+
+// backfillCompletionCmd marks a task completed on date, as a single
+// History write, after checking the task still exists.
+func backfillCompletionCmd(db *sql.DB, taskID, date string) tea.Cmd {
+	writes := []historyWrite{{taskID: taskID, date: date, completed: true}}
+	return func() tea.Msg {
+		var exists bool
+		err := db.QueryRow(`
+			SELECT EXISTS (SELECT 1 FROM task_definitions WHERE id = ? AND deleted = false)
+		`, taskID).Scan(&exists)
+		if err == nil && !exists {
+			err = errTaskGone
+		}
+		if err == nil {
+			err = saveHistoryCompletions(db, writes)
+		}
+		if err != nil {
+			return HistoryCompletionsSaveFailedMsg{writes: writes, err: err}
+		}
+		return HistoryCompletionsSavedMsg{writes: writes}
+	}
+}
+
+// openBackfill starts the prompt for the selected task.
+func (p *HistoryPage) openBackfill() tea.Cmd {
+	task, ok := p.list.SelectedItem().(HistoryTask)
+	if !ok {
+		return nil
+	}
+	p.backfill.task = task
+	p.backfill.err = nil
+	p.backfill.input.Reset()
+	p.mode = historyModeBackfill
+	return p.backfill.input.Focus()
+}
+
+func (p *HistoryPage) closeBackfill() {
+	p.backfill.input.Blur()
+	p.mode = historyModeTaskTable
+}
+
+func (p *HistoryPage) updateBackfill(msg tea.Msg) (Page, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			p.closeBackfill()
+			return p, nil
+		case "enter":
+			date, err := parseBackfillDate(p.backfill.input.Value(), homeNow())
+			if err != nil {
+				p.backfill.err = err
+				return p, nil
+			}
+			p.closeBackfill()
+
+			// Show it straight away if the day is on the heatmap
+			task := p.backfill.task
+			for i, item := range p.list.Items() {
+				if t, ok := item.(HistoryTask); ok && t.id == task.id {
+					t.completions[date] = true
+					p.list.SetItem(i, t)
+					break
+				}
+			}
+			return p, backfillCompletionCmd(p.db, task.id, date)
+		}
+	}
+
+	var cmd tea.Cmd
+	p.backfill.input, cmd = p.backfill.input.Update(msg)
+	return p, cmd
+}
+
+func (p *HistoryPage) viewBackfill() string {
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#04B575"))
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#555555"))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B"))
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("Log a Past Completion"))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("Which day was \"%s\" done?\n", p.backfill.task.title))
+	b.WriteString(p.backfill.input.View())
+	b.WriteString("\n\n")
+	if p.backfill.err != nil {
+		b.WriteString(errorStyle.Render(p.backfill.err.Error()))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(hintStyle.Render("Any day up to today, however far back the table reaches.\n\n(enter to save, esc to cancel)"))
+	return b.String()
+}
@@ -0,0 +1,459 @@
+package pages
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ---------------------------------------------------------------------------
+// Single-task focus view: a year-long calendar heatmap with stats
+// ---------------------------------------------------------------------------
+
+// The year can instead be shown as a list of days, newest first, which is
+// easier to read with a screen reader and to backfill a specific date in.
+// v switches between the two; STET_HISTORY_FOCUS_LAYOUT picks the first.
+
+// focusDays is how far back the focus view reaches, ending on the same day as
+// the multi-task table.
+const focusDays = 365
+
+// yearHeatmapLoadedMsg contains a task's completions for the focus view.
+type yearHeatmapLoadedMsg struct {
+	taskID        string
+	completions   map[string]bool // key: "YYYY-MM-DD"
+	paused        map[string]bool // key: "YYYY-MM-DD"
+	createdDate   string          // "YYYY-MM-DD"
+	currentStreak int
+}
+
+// yearHeatmapLoadFailedMsg indicates loading the focus view data failed.
+type yearHeatmapLoadFailedMsg struct {
+	taskID string
+	err    error
+}
+
+// loadYearHeatmapCmd loads a year of completions and paused days for one
+// task, along with its creation date and current streak (as shown on the
+// Today page).
+func loadYearHeatmapCmd(db *sql.DB, taskID string) tea.Cmd {
+	return func() tea.Msg {
+		var created string
+		err := db.QueryRow(`
+			SELECT strftime('%Y-%m-%d %H:%M:%S', created_at) FROM task_definitions WHERE id = ?
+		`, taskID).Scan(&created)
+		if err != nil {
+			return yearHeatmapLoadFailedMsg{taskID: taskID, err: err}
+		}
+		if t, ok := fromUTC(created); ok {
+			created = dateKey(t)
+		}
+
+		today := startOfDay(homeNow())
+		from := dateKey(addDays(today, -focusDays))
+		to := dateKey(today)
+
+		rows, err := db.Query(`
+			SELECT date(completed_date)
+			FROM task_completions
+			WHERE task_id = ?
+			  AND completed_date >= ?
+			  AND completed_date <= ?
+		`, taskID, from, to)
+		if err != nil {
+			return yearHeatmapLoadFailedMsg{taskID: taskID, err: err}
+		}
+		defer rows.Close()
+
+		completions := make(map[string]bool)
+		for rows.Next() {
+			var date string
+			if err := rows.Scan(&date); err != nil {
+				return yearHeatmapLoadFailedMsg{taskID: taskID, err: err}
+			}
+			completions[date] = true
+		}
+		if err := rows.Err(); err != nil {
+			return yearHeatmapLoadFailedMsg{taskID: taskID, err: err}
+		}
+
+		streaks, err := loadTaskStreaks(db)
+		if err != nil {
+			return yearHeatmapLoadFailedMsg{taskID: taskID, err: err}
+		}
+
+		pauses, err := loadTaskPauses(db, from, to)
+		if err != nil {
+			return yearHeatmapLoadFailedMsg{taskID: taskID, err: err}
+		}
+
+		return yearHeatmapLoadedMsg{
+			taskID:        taskID,
+			completions:   completions,
+			paused:        pausedDays(pauses[taskID], from, to),
+			createdDate:   created,
+			currentStreak: streaks[taskID],
+		}
+	}
+}
+
+// historyFocus holds the state of the single-task focus view.
+type historyFocus struct {
+	task          HistoryTask
+	completions   map[string]bool
+	paused        map[string]bool
+	createdDate   string
+	currentStreak int
+	loaded        bool
+	err           error
+	status        string
+	selected      time.Time // selected day, at local midnight
+}
+
+// focusDateRange returns the first and last day shown, at local midnight.
+func (p *HistoryPage) focusDateRange() (first, last time.Time) {
+	last = startOfDay(homeNow())
+	if !p.includeToday {
+		last = addDays(last, -1)
+	}
+	return addDays(last, -(focusDays - 1)), last
+}
+
+func (p *HistoryPage) openFocusView() tea.Cmd {
+	task, ok := p.list.SelectedItem().(HistoryTask)
+	if !ok {
+		return nil
+	}
+	_, last := p.focusDateRange()
+	p.focus = historyFocus{task: task, selected: last}
+	p.mode = historyModeTaskFocus
+	return loadYearHeatmapCmd(p.db, task.id)
+}
+
+func (p *HistoryPage) handleFocusKeys(msg tea.KeyMsg) (Page, tea.Cmd) {
+	move := func(days int) {
+		p.focus.selected = addDays(p.focus.selected, days)
+		p.clampFocus()
+	}
+
+	// The list runs newest first, so up is a day later
+	if p.focusList {
+		switch msg.String() {
+		case "up", "k":
+			move(1)
+			return p, nil
+		case "down", "j":
+			move(-1)
+			return p, nil
+		case "pgup":
+			move(p.focusListRows())
+			return p, nil
+		case "pgdown":
+			move(-p.focusListRows())
+			return p, nil
+		}
+	}
+
+	switch {
+	case key.Matches(msg, historyKeys.Back):
+		p.mode = historyModeTaskTable
+	case key.Matches(msg, historyKeys.Layout):
+		p.focusList = !p.focusList
+		p.clampFocus()
+	case msg.String() == "up" || msg.String() == "k":
+		move(-1)
+	case msg.String() == "down" || msg.String() == "j":
+		move(1)
+	case msg.String() == "left" || msg.String() == "h":
+		move(-7)
+	case msg.String() == "right" || msg.String() == "l":
+		move(7)
+	case key.Matches(msg, historyKeys.Toggle):
+		return p, p.toggleFocusDay()
+	}
+	return p, nil
+}
+
+// toggleFocusDay flips the selected day, updating the task table's row too
+// so both views agree before the write lands.
+func (p *HistoryPage) toggleFocusDay() tea.Cmd {
+	if !p.focus.loaded {
+		return nil
+	}
+	date := p.focus.selected.Format("2006-01-02")
+	completed := !p.focus.completions[date]
+	p.focus.completions[date] = completed
+	p.focus.status = ""
+
+	for _, item := range p.list.Items() {
+		if t, ok := item.(HistoryTask); ok && t.id == p.focus.task.id {
+			t.completions[date] = completed // shared map; no SetItem needed
+			break
+		}
+	}
+	return p.queueWrite(p.focus.task.id, date, completed)
+}
+
+// focusStats returns the longest streak and completion rate between first
+// and last, counting only days since the task was created. Paused days are
+// skipped, as in the current streak.
+func (f historyFocus) focusStats(first, last time.Time) (longest, done, days int) {
+	if created, err := time.ParseInLocation("2006-01-02", f.createdDate, homeLoc); err == nil && created.After(first) {
+		first = created
+	}
+	run := 0
+	for d := first; !d.After(last); d = addDays(d, 1) {
+		key := d.Format("2006-01-02")
+		if f.paused[key] {
+			continue
+		}
+		days++
+		if f.completions[key] {
+			done++
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest, done, days
+}
+
+func (p *HistoryPage) viewFocus() string {
+	var b strings.Builder
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#04B575"))
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#555555"))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B"))
+
+	b.WriteString(headerStyle.Render(p.focus.task.title))
+	b.WriteString(" ")
+	b.WriteString(hintStyle.Render("(press esc or q to return)"))
+	b.WriteString("\n\n")
+
+	if p.focus.err != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("load failed: %v", p.focus.err)))
+		return b.String()
+	}
+	if !p.focus.loaded {
+		b.WriteString(hintStyle.Render("Loading..."))
+		return b.String()
+	}
+
+	if p.focusList {
+		b.WriteString(p.renderFocusList())
+	} else {
+		b.WriteString(p.renderFocusCalendar())
+	}
+	b.WriteString("\n\n")
+
+	longest, done, days := p.focus.focusStats(p.focusDateRange())
+	rate := 0
+	if days > 0 {
+		rate = done * 100 / days
+	}
+	stats := panel{
+		title: "Stats",
+		content: fmt.Sprintf("Current streak: %d   Longest: %d\nRate: %d%% (%d/%d days)",
+			p.focus.currentStreak, longest, rate, done, days),
+		width:  min(p.width-DocStyle.GetHorizontalFrameSize(), 48),
+		height: focusStatsHeight,
+	}
+	b.WriteString(stats.View())
+	b.WriteString("\n")
+
+	selected := p.focus.selected.Format(p.dateLayout) + ": " + p.focus.dayStatus(p.focus.selected)
+	b.WriteString(hintStyle.Render(selected))
+	if p.focus.status != "" {
+		b.WriteString("  ")
+		b.WriteString(errorStyle.Render(p.focus.status))
+	}
+
+	return b.String()
+}
+
+// focusStatsHeight is the height of the focus view's stats panel.
+const focusStatsHeight = panelChrome + 2
+
+// dayStatus describes day: completed, paused or missed.
+func (f historyFocus) dayStatus(day time.Time) string {
+	date := day.Format("2006-01-02")
+	switch {
+	case f.completions[date]:
+		return "completed"
+	case f.paused[date]:
+		return "paused"
+	}
+	return "missed"
+}
+
+// focusListRows is how many days the list layout shows at once: the height
+// left after the header, stats panel and status line.
+func (p *HistoryPage) focusListRows() int {
+	return max(p.height-focusStatsHeight-4, 3)
+}
+
+// renderFocusList draws one row per day, newest first, scrolled to keep the
+// selected day in view.
+func (p *HistoryPage) renderFocusList() string {
+	_, last := p.focusDateRange()
+	rows := min(p.focusListRows(), focusDays)
+	selected := int(last.Sub(p.focus.selected).Hours()+12) / 24 // rows from the top
+	top := max(min(selected-rows/2, focusDays-rows), 0)
+
+	pal := p.palette
+	cursorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+	statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	width := p.width - DocStyle.GetHorizontalFrameSize()
+
+	// Pad dates to the widest shown so the statuses line up
+	dateWidth := 0
+	for i := top; i < top+rows; i++ {
+		dateWidth = max(dateWidth, ansi.StringWidth(addDays(last, -i).Format(p.dateLayout)))
+	}
+	dateWidth = min(dateWidth, max(width-16, 1))
+
+	lines := make([]string, 0, rows)
+	for i := top; i < top+rows; i++ {
+		day := addDays(last, -i)
+		status := p.focus.dayStatus(day)
+		glyph, style := pal.missedSquare, pal.missedStyle
+		switch status {
+		case "completed":
+			glyph, style = pal.completedSquare, pal.completedStyle
+		case "paused":
+			glyph, style = pal.pausedSquare, pal.pausedStyle
+		}
+		date := ansi.Truncate(day.Format(p.dateLayout), dateWidth, ellipsis)
+		date += strings.Repeat(" ", dateWidth-ansi.StringWidth(date))
+		if i == selected {
+			lines = append(lines, cursorStyle.Render("> "+date)+"  "+style.Render(glyph)+" "+status)
+		} else {
+			lines = append(lines, "  "+date+"  "+style.Render(glyph)+" "+statusStyle.Render(status))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// focusCalendarLabelWidth is the width of the calendar's weekday labels.
+const focusCalendarLabelWidth = 4
+
+// focusCalendarLayout returns the first week the calendar shows, how many
+// weeks and how wide each cell is. Cells are two columns wide when the
+// terminal allows, and the oldest weeks are dropped when it is too narrow.
+func (p *HistoryPage) focusCalendarLayout() (start time.Time, weeks, cellWidth int) {
+	first, last := p.focusDateRange()
+	start = weekStartOn(first, p.weekStart)
+	weeks = int(last.Sub(start).Hours()/24)/7 + 1
+
+	contentWidth := p.width - DocStyle.GetHorizontalFrameSize()
+	cellWidth = 2
+	if focusCalendarLabelWidth+weeks*cellWidth > contentWidth {
+		cellWidth = 1
+	}
+	if visible := (contentWidth - focusCalendarLabelWidth) / cellWidth; visible > 0 && visible < weeks {
+		start = addDays(start, 7*(weeks-visible))
+		weeks = visible
+	}
+	return start, weeks, cellWidth
+}
+
+// focusVisibleRange returns the first and last day the selection may be on:
+// the whole range in the list, which scrolls, but only the weeks the
+// calendar has room for.
+func (p *HistoryPage) focusVisibleRange() (first, last time.Time) {
+	first, last = p.focusDateRange()
+	if !p.focusList {
+		if start, _, _ := p.focusCalendarLayout(); start.After(first) {
+			first = start
+		}
+	}
+	return first, last
+}
+
+// clampFocus moves the selected day into focusVisibleRange, e.g. after a
+// move past either end or a resize that drops weeks from the calendar.
+func (p *HistoryPage) clampFocus() {
+	first, last := p.focusVisibleRange()
+	if p.focus.selected.Before(first) {
+		p.focus.selected = first
+	} else if p.focus.selected.After(last) {
+		p.focus.selected = last
+	}
+}
+
+// renderFocusCalendar draws the year as weeks (columns) by weekday (rows),
+// with month labels above; see focusCalendarLayout.
+func (p *HistoryPage) renderFocusCalendar() string {
+	first, last := p.focusDateRange()
+	start, weeks, cellWidth := p.focusCalendarLayout()
+
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	pal := p.palette
+
+	// Month labels, placed at the first week containing the 1st
+	months := []rune(strings.Repeat(" ", weeks*cellWidth))
+	free := 0
+	for w := 0; w < weeks; w++ {
+		for d := 0; d < 7; d++ {
+			day := start.AddDate(0, 0, w*7+d)
+			if day.Day() != 1 || day.Before(first) || day.After(last) {
+				continue
+			}
+			pos := w * cellWidth
+			if pos >= free && pos+3 <= len(months) {
+				copy(months[pos:], []rune(day.Format("Jan")))
+				free = pos + 4
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", focusCalendarLabelWidth))
+	b.WriteString(labelStyle.Render(string(months)))
+	b.WriteString("\n")
+
+	for d := 0; d < 7; d++ {
+		// Label every other row, starting with the first day of the week
+		label := ""
+		if d%2 == 0 {
+			label = start.AddDate(0, 0, d).Format("Mon")
+		}
+		b.WriteString(labelStyle.Render(fmt.Sprintf("%-*s", focusCalendarLabelWidth, label)))
+		for w := 0; w < weeks; w++ {
+			day := start.AddDate(0, 0, w*7+d)
+			if day.Before(first) || day.After(last) {
+				b.WriteString(strings.Repeat(" ", cellWidth))
+				continue
+			}
+			glyph, style := pal.missedSquare, pal.missedStyle
+			switch date := day.Format("2006-01-02"); {
+			case p.focus.completions[date]:
+				glyph, style = pal.completedSquare, pal.completedStyle
+			case p.focus.paused[date]:
+				glyph, style = pal.pausedSquare, pal.pausedStyle
+			}
+			if day.Equal(p.focus.selected) {
+				style = style.Underline(true).Bold(true)
+			}
+			b.WriteString(style.Render(glyph))
+			b.WriteString(strings.Repeat(" ", cellWidth-1))
+		}
+		if d < 6 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,235 @@
+package pages
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"stet.codes/tui/config"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestHistoryNarrowWidths(t *testing.T) {
+	yesterday := dateKey(addDays(startOfDay(homeNow()), -1))
+	tasks := []HistoryTask{
+		{id: "1", title: "Read", completions: map[string]bool{yesterday: true}, active: true},
+		{id: "2", title: "A task with a title far too long for any narrow terminal", active: true},
+		{id: "3", title: "Stretch", paused: map[string]bool{yesterday: true}},
+	}
+
+	for _, width := range []int{0, 1, 2, 5, 8, 12, 20, 30, 40} {
+		t.Run(fmt.Sprint(width), func(t *testing.T) {
+			p := NewHistoryPage(nil, config.Default())
+			p.Update(historyDataLoadedMsg{tasks: tasks})
+			p.SetSize(width, 30)
+
+			listWidth := p.list.Width()
+			for i, item := range p.list.Items() {
+				var b bytes.Buffer
+				p.delegate.Render(&b, p.list, i, item)
+				if w := ansi.StringWidth(b.String()); w > listWidth {
+					t.Errorf("row %d is %d wide in a list %d wide: %q", i, w, listWidth, b.String())
+				}
+			}
+
+			// The page must render at any width, however cramped
+			_ = p.View()
+		})
+	}
+}
+
+func TestHistoryDelegateRowWidth(t *testing.T) {
+	d := newHistoryDelegate(10, heatmapPaletteFor(config.Default().HeatmapPalette), false)
+	for listWidth := range 20 {
+		want := max(listWidth-d.Styles.NormalTitle.GetPaddingLeft()-d.Styles.NormalTitle.GetPaddingRight(), 0)
+		if got := d.rowWidth(listWidth); got != want {
+			t.Errorf("rowWidth(%d) = %d, want %d", listWidth, got, want)
+		}
+		if got := d.titleWidth(listWidth); got < 0 || got > d.rowWidth(listWidth) {
+			t.Errorf("titleWidth(%d) = %d, outside [0, %d]", listWidth, got, d.rowWidth(listWidth))
+		}
+	}
+}
+
+// BenchmarkLoadHistoryData loads a full History range from a table with
+// tens of thousands of completions, the case the completed_date index is
+// for.
+func BenchmarkLoadHistoryData(b *testing.B) {
+	db := openTestDB(b)
+	const tasks, days = 60, 700 // 42,000 completions
+	today := startOfDay(homeNow())
+
+	tx, err := db.Begin()
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := range tasks {
+		id := fmt.Sprintf("task-%d", i)
+		if _, err := tx.Exec(`
+			INSERT INTO task_definitions (id, title, description, active, deleted)
+			VALUES (?, ?, '', true, false)
+		`, id, strings.Repeat("x", i%20+1)); err != nil {
+			b.Fatal(err)
+		}
+		for d := range days {
+			date := dateKey(addDays(today, -d))
+			if _, err := tx.Exec(`
+				INSERT INTO task_history (id, task_id, completed_date, completed_at)
+				VALUES (?, ?, ?, ?)
+			`, fmt.Sprintf("%s-%d", id, d), id, date, date+" 08:00:00"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		b.Fatal(err)
+	}
+
+	maxDays := config.Default().HistoryMaxDays
+	from := dateKey(addDays(today, -maxDays))
+	to := dateKey(addDays(today, -1))
+	for b.Loop() {
+		msg := loadHistoryDataCmd(db, from, to, nil, false)()
+		if failed, ok := msg.(historyDataLoadFailedMsg); ok {
+			b.Fatal(failed.err)
+		}
+	}
+}
+
+// The completion-time chart counts a completion at midnight but not a
+// backfilled one, which is stamped at midnight too but flagged.
+func TestCompletionTimesSkipBackfills(t *testing.T) {
+	db := openTestDB(t)
+	addTestTask(t, db, "t1", "Read")
+	today := startOfDay(homeNow())
+	if err := completeTaskDay(db, "t1", dateKey(today), completedAtKey(today)); err != nil {
+		t.Fatal(err)
+	}
+	backfill := []historyWrite{{taskID: "t1", date: dateKey(addDays(today, -1)), completed: true}}
+	if err := saveHistoryCompletions(db, backfill); err != nil {
+		t.Fatal(err)
+	}
+	var completedAt string
+	var backfilled bool
+	if err := db.QueryRow(`
+		SELECT strftime('%Y-%m-%d %H:%M:%S', completed_at), backfilled
+		FROM task_history WHERE completed_date = ?
+	`, backfill[0].date).Scan(&completedAt, &backfilled); err != nil {
+		t.Fatal(err)
+	}
+	if completedAt != backfill[0].date+" 00:00:00" || !backfilled {
+		t.Errorf("backfill stored at %q, backfilled %v; want midnight, backfilled", completedAt, backfilled)
+	}
+
+	msg, ok := loadCompletionTimesCmd(db)().(completionTimesLoadedMsg)
+	if !ok {
+		t.Fatal("load failed")
+	}
+	var want [24]int
+	want[today.Hour()] = 1 // midnight, unless a DST change skipped it
+	if msg.counts != want {
+		t.Errorf("counts = %v, want %v", msg.counts, want)
+	}
+}
+
+// Moving the focus view's selection stops at the ends of what is shown,
+// including when the calendar is too narrow for the whole year.
+func TestFocusSelectionStaysVisible(t *testing.T) {
+	p := NewHistoryPage(nil, config.Default())
+	p.focusList = false
+	p.list.SetItems([]list.Item{HistoryTask{id: "1", title: "Read", active: true}})
+	p.SetSize(100, 40)
+	p.openFocusView()
+	p.focus.loaded = true
+	first, last := p.focusDateRange()
+
+	press := func(k string, times int) {
+		for range times {
+			p.handleFocusKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+
+	press("l", 1)
+	if !p.focus.selected.Equal(last) {
+		t.Errorf("right from the last day selected %v, want %v", p.focus.selected, last)
+	}
+
+	// 40 columns fit 36 one-column weeks, well short of a year
+	p.SetSize(40, 40)
+	press("h", 60)
+	visible, _ := p.focusVisibleRange()
+	if !visible.After(first) {
+		t.Fatalf("calendar 40 wide shows every day from %v", first)
+	}
+	if !p.focus.selected.Equal(visible) {
+		t.Errorf("left past the narrow calendar selected %v, want %v", p.focus.selected, visible)
+	}
+
+	// The list scrolls, so reaches the first day
+	p.focusList = true
+	press("j", 400)
+	if !p.focus.selected.Equal(first) {
+		t.Errorf("down past the list selected %v, want %v", p.focus.selected, first)
+	}
+
+	// Back to the calendar, and to a width dropping more weeks
+	press("v", 1)
+	p.SetSize(30, 40)
+	if visible, _ := p.focusVisibleRange(); !p.focus.selected.Equal(visible) {
+		t.Errorf("after narrowing, selected %v, want %v", p.focus.selected, visible)
+	}
+}
+
+// Leaving History with a toggle of today's cell still waiting writes it and
+// tells the Today page to reload.
+func TestHistoryLeaveInvalidatesToday(t *testing.T) {
+	db := openTestDB(t)
+	addTestTask(t, db, "t1", "Read")
+	p := NewHistoryPage(db, config.Default())
+	p.Update(historyDataLoadedMsg{tasks: []HistoryTask{
+		{id: "t1", title: "Read", completions: map[string]bool{}, active: true},
+	}})
+
+	p.list.StatusMessageLifetime = time.Millisecond // its clearing tick runs below
+	p.queueWrite("t1", todayKey(), true)
+	leave := p.Leave()
+	if leave == nil {
+		t.Fatal("Leave returned no command")
+	}
+	saved, ok := leave().(HistoryCompletionsSavedMsg)
+	if !ok {
+		t.Fatal("Leave didn't save the pending toggle")
+	}
+	if tasks, err := loadTodayTasks(db); err != nil || len(tasks) != 1 || !tasks[0].completed {
+		t.Fatalf("after leaving, Today loads %+v (err %v), want t1 completed", tasks, err)
+	}
+
+	_, cmd := p.Update(saved)
+	if !emits(cmd, InvalidateTodayPageMsg{}) {
+		t.Error("saving today's cell didn't invalidate the Today page")
+	}
+}
+
+// emits reports whether running cmd, and any batch it returns, produces
+// want.
+func emits(cmd tea.Cmd, want tea.Msg) bool {
+	if cmd == nil {
+		return false
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if emits(c, want) {
+				return true
+			}
+		}
+		return false
+	default:
+		return msg == want
+	}
+}
@@ -0,0 +1,63 @@
+package pages
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// TaskImport is a task definition read from an import file.
+type TaskImport struct {
+	Title       string
+	Description string
+}
+
+// ImportTasks inserts the given task definitions in a single transaction,
+// using the same insert as the Task Config page. Tasks whose title matches an
+// existing (non-deleted) task or an earlier entry, ignoring case, are skipped.
+func ImportTasks(db *sql.DB, tasks []TaskImport) (added, skipped int, err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT title FROM task_definitions WHERE deleted = false`)
+	if err != nil {
+		return 0, 0, err
+	}
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+		seen[strings.ToLower(strings.TrimSpace(title))] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	for _, t := range tasks {
+		key := strings.ToLower(strings.TrimSpace(t.Title))
+		if key == "" || seen[key] {
+			skipped++
+			continue
+		}
+		_, err := tx.Exec(`
+			INSERT INTO task_definitions (id, title, description, active)
+			VALUES (lower(hex(randomblob(16))), ?, ?, true)
+		`, strings.TrimSpace(t.Title), strings.TrimSpace(t.Description))
+		if err != nil {
+			return 0, 0, err
+		}
+		seen[key] = true
+		added++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return added, skipped, nil
+}
@@ -2,17 +2,22 @@ package pages
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"stet.codes/tui/config"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-const journalDebounceInterval = 500 * time.Millisecond
+// journalMaxUnsaved is how long edits can go unsaved while continuous typing
+// keeps pushing the autosave debounce back.
+const journalMaxUnsaved = 10 * time.Second
 
 // journalMode represents the current input mode.
 type journalMode int
@@ -25,20 +30,53 @@ const (
 
 // Message types for journal operations.
 type journalEntryLoadedMsg struct {
-	id      string
-	content string
+	id        string
+	content   string
+	createdAt string // UTC sqliteTimestamp
+	updatedAt string // version for conflict detection, see journalEntryColumns
+	private   bool
 }
 
 type journalEntryLoadFailedMsg struct {
 	err error
 }
 
-type journalEntrySavedMsg struct{}
+type journalEntrySavedMsg struct {
+	id        string
+	content   string
+	updatedAt string
+}
 
 type journalEntrySaveFailedMsg struct {
+	id  string
 	err error
 }
 
+// journalEntryConflictMsg indicates the entry changed in the database since
+// it was loaded, e.g. from another instance, so the save was not applied.
+type journalEntryConflictMsg struct {
+	id string
+}
+
+// journalPrivateSavedMsg indicates an entry was marked private or shareable.
+type journalPrivateSavedMsg struct {
+	id string
+}
+
+// journalPrivateSaveFailedMsg indicates marking an entry private or
+// shareable failed; private is the value that wasn't saved.
+type journalPrivateSaveFailedMsg struct {
+	id      string
+	private bool
+	err     error
+}
+
+// journalYesterdayLoadedMsg carries yesterday's entries, to offer as a
+// starting point for today's.
+type journalYesterdayLoadedMsg struct {
+	content string
+}
+
 type journalDebounceTickMsg struct {
 	version int
 }
@@ -50,6 +88,25 @@ type journalKeyMap struct {
 	Escape  key.Binding
 	Nav     key.Binding
 	Delete  key.Binding
+	NewNote key.Binding
+	Copy    key.Binding
+	Scratch key.Binding
+	Revert  key.Binding
+	Private key.Binding
+
+	// Today's task checklist, outside vim mode
+	Tasks      key.Binding
+	TaskNav    key.Binding
+	TaskToggle key.Binding
+
+	// Resolving a save conflict; these work in every mode
+	Reload    key.Binding
+	Overwrite key.Binding
+
+	// Calendar browsing, outside vim mode
+	Day   key.Binding
+	Month key.Binding
+	Today key.Binding
 }
 
 var journalKeys = journalKeyMap{
@@ -73,6 +130,58 @@ var journalKeys = journalKeyMap{
 		key.WithKeys("x", "d"),
 		key.WithHelp("x/dd", "delete"),
 	),
+	NewNote: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "new note"),
+	),
+	Copy: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy yesterday"),
+	),
+	Scratch: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "scratchpad"),
+	),
+	Revert: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "revert to opened"),
+	),
+	Private: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "private"),
+	),
+	Tasks: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "checklist"),
+	),
+	TaskNav: key.NewBinding(
+		key.WithKeys("j", "k", "down", "up"),
+		key.WithHelp("j/k", "select task"),
+	),
+	TaskToggle: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "toggle task"),
+	),
+	Reload: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "reload, discard mine"),
+	),
+	Overwrite: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("ctrl+o", "overwrite with mine"),
+	),
+	Day: key.NewBinding(
+		key.WithKeys("[", "]"),
+		key.WithHelp("[/]", "day"),
+	),
+	Month: key.NewBinding(
+		key.WithKeys("{", "}"),
+		key.WithHelp("{/}", "month"),
+	),
+	Today: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "today"),
+	),
 }
 
 // JournalPage allows users to create and edit daily journal entries.
@@ -81,28 +190,95 @@ type JournalPage struct {
 	textarea textarea.Model
 	mode     journalMode
 
+	// timestamped allows several notes per day: n starts a new one instead
+	// of editing the day's entry. entryTime is when the open note was started.
+	timestamped bool
+	entryTime   string
+
 	entryID          string
+	debounceInterval time.Duration
 	debounceVersion  int
+	dirtySince       time.Time // first edit not yet being saved
 	lastSavedContent string
 	pendingSave      bool
 	pendingKey       string // For multi-key sequences (gg, dd)
 
+	// baseline is the open document's content when it was loaded, before
+	// this session's edits; R restores it, even after autosave has
+	// overwritten it. confirm is the open question before doing so.
+	baseline string
+	confirm  *confirmDialog
+
+	// updatedAt is the entry's version when last loaded or saved; saves only
+	// apply if it still matches. conflict is set when one didn't, and holds
+	// autosave until the user reloads or overwrites.
+	updatedAt string
+	conflict  bool
+
+	// private marks the open entry as left out of exports unless asked for.
+	private bool
+
+	// Mini-calendar beside the editor, shown when there is room. calSelected
+	// is zero while on today; other days are shown read-only.
+	weekStart    time.Weekday
+	dateLayout   string // for the selected day above the editor
+	showCal      bool
+	calSelected  time.Time
+	calDays      map[string]bool // days of calDaysMonth with an entry
+	calDaysMonth string          // "YYYY-MM"
+	dayContent   string          // the selected day's entries
+	dayLoaded    bool
+
+	// scratch is set while the editor shows the scratchpad instead of
+	// today's entry; other holds whichever of the two isn't shown.
+	scratch bool
+	other   journalDoc
+
+	// copyDraft holds yesterday's entries while asking whether to start the
+	// empty open entry from them. notice is a one-off message for the user.
+	copyDraft string
+	notice    string
+
+	// Today's tasks, shown as a checklist below the editor (see
+	// journal_tasks.go).
+	showTasks   bool
+	tasks       []Task
+	tasksLoaded bool
+	taskCursor  int
+
 	width  int
 	height int
 	err    error
 }
 
 // NewJournalPage creates a new journal page.
-func NewJournalPage(db *sql.DB) *JournalPage {
+func NewJournalPage(db *sql.DB, cfg config.Config) *JournalPage {
 	ta := textarea.New()
 	ta.Placeholder = "Start writing your journal entry..."
 	ta.CharLimit = 0
 	ta.ShowLineNumbers = false
 
+	// Starting in a vim mode focuses the editor up front, so the page
+	// captures keys from the first frame it is shown
+	mode := journalModeView
+	switch cfg.JournalStartMode {
+	case config.JournalStartNormal:
+		mode = journalModeVimNormal
+	case config.JournalStartInsert:
+		mode = journalModeVimInsert
+	}
+	if mode != journalModeView {
+		ta.Focus()
+	}
+
 	return &JournalPage{
-		db:       db,
-		textarea: ta,
-		mode:     journalModeView,
+		db:               db,
+		textarea:         ta,
+		mode:             mode,
+		timestamped:      cfg.JournalEntries == config.JournalEntriesTimestamped,
+		weekStart:        cfg.WeekStart.Weekday(),
+		dateLayout:       cfg.Formats.DateLong,
+		debounceInterval: cfg.JournalAutosaveDelay,
 	}
 }
 
@@ -122,28 +298,129 @@ func (p *JournalPage) SetSize(width, height int) {
 	p.height = height
 
 	contentWidth := max(width-DocStyle.GetHorizontalFrameSize()-4, 40)
-	contentHeight := max(height-6, 5)
+	contentHeight := max(height-6-p.tasksHeight(), 3)
+
+	// The calendar takes space from the editor only if that leaves it the
+	// usual minimum width
+	p.showCal = contentWidth-calWidth-calGap >= 40
+	if p.showCal {
+		contentWidth -= calWidth + calGap
+	}
 
 	p.textarea.SetWidth(contentWidth)
 	p.textarea.SetHeight(contentHeight)
 }
 
 func (p *JournalPage) InitCmd() tea.Cmd {
-	return loadOrCreateJournalEntryCmd(p.db)
+	cmds := []tea.Cmd{
+		loadOrCreateJournalEntryCmd(p.db, p.timestamped),
+		loadJournalDaysCmd(p.db, monthOf(p.selectedDay())),
+	}
+	if p.textarea.Focused() {
+		cmds = append(cmds, textarea.Blink)
+	}
+	return tea.Batch(cmds...)
+}
+
+// selectedDay returns midnight on the day selected in the calendar.
+func (p *JournalPage) selectedDay() time.Time {
+	if p.browsing() {
+		return p.calSelected
+	}
+	return startOfDay(homeNow())
+}
+
+// browsing reports whether a day other than today is selected.
+func (p *JournalPage) browsing() bool {
+	return !p.calSelected.IsZero() && !p.scratch
+}
+
+// selectDay moves the calendar selection to day, loading the month's dots
+// and the day's entries as needed. Future days can't be selected.
+func (p *JournalPage) selectDay(day time.Time) tea.Cmd {
+	today := todayKey()
+	if date := day.Format("2006-01-02"); date >= today {
+		p.calSelected = time.Time{}
+	} else {
+		p.calSelected = day
+	}
+
+	var cmds []tea.Cmd
+	if month := monthOf(p.selectedDay()); month.Format("2006-01") != p.calDaysMonth {
+		cmds = append(cmds, loadJournalDaysCmd(p.db, month))
+	}
+	if p.browsing() {
+		p.dayContent = ""
+		p.dayLoaded = false
+		cmds = append(cmds, loadJournalDayCmd(p.db, p.calSelected.Format("2006-01-02")))
+	}
+	return tea.Batch(cmds...)
+}
+
+// canCopyYesterday reports whether the open entry can be started from
+// yesterday's: it is today's, empty, and not waiting on a save.
+func (p *JournalPage) canCopyYesterday() bool {
+	return p.entryID != "" && !p.scratch && !p.browsing() && !p.conflict && !p.pendingSave &&
+		strings.TrimSpace(p.textarea.Value()) == ""
+}
+
+// canMarkPrivate reports whether the open entry can be marked private:
+// it is today's dated entry. The scratchpad is never exported.
+func (p *JournalPage) canMarkPrivate() bool {
+	return p.entryID != "" && !p.scratch && !p.browsing()
 }
 
 func (p *JournalPage) CapturesNavigation() bool {
-	return p.mode != journalModeView
+	return p.mode != journalModeView || p.copyDraft != "" || p.confirm != nil
 }
 
 func (p *JournalPage) CapturesGlobalKeys() bool {
 	return p.mode == journalModeVimInsert
 }
 
+// DebugLayout implements LayoutDebugger.
+func (p *JournalPage) DebugLayout() []LayoutValue {
+	return []LayoutValue{
+		{"textW", p.textarea.Width()},
+		{"textH", p.textarea.Height()},
+	}
+}
+
 func (p *JournalPage) KeyMap() []key.Binding {
+	if p.conflict {
+		return []key.Binding{journalKeys.Reload, journalKeys.Overwrite}
+	}
 	switch p.mode {
 	case journalModeView:
-		return []key.Binding{journalKeys.VimMode}
+		if p.copyDraft != "" || p.confirm != nil {
+			return nil // the prompt lists the keys
+		}
+		keys := []key.Binding{journalKeys.VimMode, journalKeys.Scratch, journalKeys.Tasks}
+		if p.showTasks {
+			keys = append(keys, journalKeys.TaskNav, journalKeys.TaskToggle)
+		}
+		if p.canRevert() {
+			keys = append(keys, journalKeys.Revert)
+		}
+		if p.scratch {
+			return keys
+		}
+		if p.timestamped {
+			keys = append(keys, journalKeys.NewNote)
+		}
+		if p.canCopyYesterday() {
+			keys = append(keys, journalKeys.Copy)
+		}
+		if p.canMarkPrivate() {
+			keys = append(keys, journalKeys.Private)
+		}
+		if p.showCal {
+			keys = append(keys, journalKeys.Day, journalKeys.Month)
+		}
+		if p.browsing() {
+			keys = append(keys, journalKeys.Today)
+		}
+		return keys
 	case journalModeVimNormal:
 		return []key.Binding{journalKeys.Nav, journalKeys.Edit, journalKeys.Delete, journalKeys.VimMode}
 	case journalModeVimInsert:
@@ -152,13 +429,51 @@ func (p *JournalPage) KeyMap() []key.Binding {
 	return nil
 }
 
+// Shutdown implements Shutdowner by saving any edits still waiting on the
+// autosave debounce, in both today's entry and the scratchpad, so quitting
+// right after typing loses nothing. Edits that conflict with another
+// instance's are not written.
+func (p *JournalPage) Shutdown() error {
+	return errors.Join(p.saveOnShutdown(p.current()), p.saveOnShutdown(p.other))
+}
+
+// save starts writing the textarea to the open entry. Only one save is in
+// flight at a time so each carries the version the previous one produced;
+// edits made meanwhile are saved when it completes.
+func (p *JournalPage) save(force bool) tea.Cmd {
+	if p.entryID == "" || p.pendingSave || (p.conflict && !force) {
+		return nil
+	}
+	p.pendingSave = true
+	p.dirtySince = time.Time{}
+	return saveJournalEntryCmd(p.db, p.entryID, p.textarea.Value(), p.base(), force)
+}
+
+// base returns the entry state the textarea's edits are relative to.
+func (p *JournalPage) base() journalEntryBase {
+	return journalEntryBase{content: p.lastSavedContent, updatedAt: p.updatedAt}
+}
+
 func (p *JournalPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 	switch msg := msg.(type) {
 	case journalEntryLoadedMsg:
+		if (msg.id == journalScratchpadID) != p.scratch {
+			return p, nil // for the document no longer shown; loaded again when it is
+		}
 		p.entryID = msg.id
+		p.entryTime = homeClock(msg.createdAt)
+		p.updatedAt = msg.updatedAt
+		p.private = msg.private
+		p.conflict = false
 		p.textarea.SetValue(msg.content)
 		p.lastSavedContent = msg.content
+		p.baseline = msg.content
+		p.copyDraft = ""
+		p.confirm = nil
 		p.err = nil
+		// Ignore pending autosave ticks meant for the previous note
+		p.debounceVersion++
+		p.dirtySince = time.Time{}
 		return p, nil
 
 	case journalEntryLoadFailedMsg:
@@ -166,23 +481,147 @@ func (p *JournalPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		return p, nil
 
 	case journalEntrySavedMsg:
+		if msg.id == p.other.id && msg.id != p.entryID {
+			p.other.pendingSave = false
+			p.other.conflict = false
+			p.other.lastSavedContent = msg.content
+			p.other.updatedAt = msg.updatedAt
+			return p, p.saveOther()
+		}
+		if msg.id != p.entryID {
+			return p, nil // save of a note that is no longer open
+		}
 		p.pendingSave = false
-		p.lastSavedContent = p.textarea.Value()
-		return p, nil
+		p.conflict = false
+		p.lastSavedContent = msg.content
+		p.updatedAt = msg.updatedAt
+		var cmds []tea.Cmd
+		// Today's dot may have appeared or gone
+		today := homeNow()
+		hasContent := strings.TrimSpace(msg.content) != ""
+		if msg.id != journalScratchpadID && today.Format("2006-01") == p.calDaysMonth &&
+			hasContent != p.calDays[today.Format("2006-01-02")] {
+			cmds = append(cmds, loadJournalDaysCmd(p.db, monthOf(today)))
+		}
+		if p.textarea.Value() != p.lastSavedContent {
+			cmds = append(cmds, p.save(false)) // edits made while saving
+		}
+		return p, tea.Batch(cmds...)
 
 	case journalEntrySaveFailedMsg:
-		p.pendingSave = false
+		logger.Errorf("journal: save entry %s: %v", msg.id, msg.err)
+		if msg.id == p.other.id && msg.id != p.entryID {
+			p.other.pendingSave = false
+		} else if msg.id == p.entryID {
+			p.pendingSave = false
+		}
 		p.err = msg.err
 		return p, nil
 
+	case journalPrivateSavedMsg:
+		return p, func() tea.Msg { return InvalidateHistoryPageMsg{} }
+
+	case journalPrivateSaveFailedMsg:
+		logger.Errorf("journal: mark entry %s private=%t: %v", msg.id, msg.private, msg.err)
+		if msg.id == p.entryID && p.private == msg.private {
+			p.private = !msg.private // revert
+		}
+		p.err = msg.err
+		return p, nil
+
+	case activeTasksLoadedMsg, activeTasksLoadFailedMsg, taskCompletionSavedMsg, taskCompletionSaveFailedMsg,
+		taskCountSavedMsg, taskCountSaveFailedMsg:
+		return p, p.updateTasks(msg)
+
+	case journalDaysLoadedMsg:
+		p.calDays = msg.days
+		p.calDaysMonth = msg.month
+		return p, nil
+
+	case journalDaysLoadFailedMsg:
+		p.err = msg.err
+		return p, nil
+
+	case journalDayLoadedMsg:
+		if p.browsing() && msg.date == p.calSelected.Format("2006-01-02") {
+			p.dayContent = msg.content
+			p.dayLoaded = true
+		}
+		return p, nil
+
+	case journalDayLoadFailedMsg:
+		if p.browsing() && msg.date == p.calSelected.Format("2006-01-02") {
+			p.err = msg.err
+		}
+		return p, nil
+
+	case journalYesterdayLoadedMsg:
+		switch {
+		case !p.canCopyYesterday() || p.mode != journalModeView:
+			// Something was written or opened meanwhile; never replace it
+		case strings.TrimSpace(msg.content) == "":
+			p.notice = "No entry yesterday to copy."
+		default:
+			p.copyDraft = msg.content
+		}
+		return p, nil
+
+	case confirmResultMsg:
+		if msg.id == journalConfirmRevert && msg.confirmed && msg.target == p.entryID {
+			return p, p.revert()
+		}
+		return p, nil
+
+	case tea.MouseMsg:
+		if p.copyDraft != "" || p.confirm != nil {
+			return p, nil
+		}
+		return p, p.handleCalendarMouse(msg)
+
+	case journalEntryConflictMsg:
+		if msg.id == p.other.id && msg.id != p.entryID {
+			p.other.pendingSave = false
+			p.other.conflict = true // shown when switched back
+			return p, nil
+		}
+		if msg.id != p.entryID {
+			return p, nil
+		}
+		p.pendingSave = false
+		p.conflict = true
+		return p, nil
+
 	case journalDebounceTickMsg:
-		if msg.version == p.debounceVersion && p.textarea.Value() != p.lastSavedContent {
-			p.pendingSave = true
-			return p, saveJournalEntryCmd(p.db, p.entryID, p.textarea.Value())
+		if p.textarea.Value() == p.lastSavedContent {
+			return p, nil
+		}
+		// Ticks for earlier edits still save once edits have waited too long
+		if msg.version == p.debounceVersion ||
+			(!p.dirtySince.IsZero() && time.Since(p.dirtySince) >= journalMaxUnsaved) {
+			return p, p.save(false)
 		}
 		return p, nil
 
 	case tea.KeyMsg:
+		if p.conflict {
+			switch {
+			case key.Matches(msg, journalKeys.Reload):
+				return p, loadJournalEntryCmd(p.db, p.entryID)
+			case key.Matches(msg, journalKeys.Overwrite):
+				return p, p.save(true)
+			}
+		}
+		p.notice = ""
+		if p.confirm != nil {
+			cmd, answered := p.confirm.update(msg)
+			if answered {
+				p.confirm = nil
+			}
+			return p, cmd
+		}
+		if p.copyDraft != "" {
+			return p, p.handleCopyConfirm(msg)
+		}
 		return p.handleKeyMsg(msg)
 	}
 
@@ -198,8 +637,7 @@ func (p *JournalPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		}
 
 		if p.textarea.Value() != oldValue {
-			p.debounceVersion++
-			cmds = append(cmds, startDebounceCmd(p.debounceVersion))
+			cmds = append(cmds, p.edited())
 		}
 
 		return p, tea.Batch(cmds...)
@@ -222,13 +660,154 @@ func (p *JournalPage) handleKeyMsg(msg tea.KeyMsg) (Page, tea.Cmd) {
 
 func (p *JournalPage) handleViewMode(msg tea.KeyMsg) (Page, tea.Cmd) {
 	if msg.String() == "ctrl+v" {
+		// Editing always happens on today's entry, with room to write
+		cmd := p.selectDay(homeNow())
+		p.closeTasks()
 		p.mode = journalModeVimNormal
 		p.textarea.Focus()
-		return p, textarea.Blink
+		return p, tea.Batch(cmd, textarea.Blink)
+	}
+	if key.Matches(msg, journalKeys.Scratch) {
+		return p, p.toggleScratchpad()
+	}
+	if key.Matches(msg, journalKeys.Tasks) {
+		return p, p.toggleTasks()
+	}
+	if key.Matches(msg, journalKeys.Revert) && p.canRevert() {
+		p.confirmRevert()
+		return p, nil
+	}
+	if p.showTasks {
+		switch {
+		case key.Matches(msg, journalKeys.TaskNav):
+			step := 1
+			if msg.String() == "k" || msg.String() == "up" {
+				step = -1
+			}
+			p.moveTaskCursor(step)
+			return p, nil
+		case key.Matches(msg, journalKeys.TaskToggle):
+			return p, p.toggleSelectedTask()
+		}
+	}
+	if p.scratch {
+		return p, nil // the rest is about dated entries
+	}
+	if p.timestamped && key.Matches(msg, journalKeys.NewNote) {
+		return p, tea.Batch(p.selectDay(homeNow()), p.startNewNote())
+	}
+	if key.Matches(msg, journalKeys.Copy) && p.canCopyYesterday() {
+		return p, loadYesterdayJournalCmd(p.db)
+	}
+	if key.Matches(msg, journalKeys.Private) && p.canMarkPrivate() {
+		p.private = !p.private
+		return p, setJournalPrivateCmd(p.db, p.entryID, p.private)
+	}
+	if !p.showCal {
+		return p, nil
+	}
+	switch {
+	case key.Matches(msg, journalKeys.Day):
+		step := 1
+		if msg.String() == "[" {
+			step = -1
+		}
+		return p, p.selectDay(p.selectedDay().AddDate(0, 0, step))
+	case key.Matches(msg, journalKeys.Month):
+		step := 1
+		if msg.String() == "{" {
+			step = -1
+		}
+		// Keep the day of the month where possible, e.g. Mar 31 -> Feb 28
+		day := p.selectedDay()
+		month := monthOf(day).AddDate(0, step, 0)
+		last := month.AddDate(0, 1, -1).Day()
+		return p, p.selectDay(month.AddDate(0, 0, min(day.Day(), last)-1))
+	case key.Matches(msg, journalKeys.Today):
+		return p, p.selectDay(homeNow())
 	}
 	return p, nil
 }
 
+// handleCopyConfirm answers the prompt to start the open entry from
+// yesterday's.
+func (p *JournalPage) handleCopyConfirm(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "y", "Y":
+		draft := p.copyDraft
+		p.copyDraft = ""
+		if !p.canCopyYesterday() {
+			return nil
+		}
+		p.textarea.SetValue(draft)
+		p.debounceVersion++
+		return p.save(false)
+	case "n", "N", "esc":
+		p.copyDraft = ""
+	}
+	return nil
+}
+
+// journalConfirmRevert identifies the revert confirmation's result.
+const journalConfirmRevert = "revert"
+
+// canRevert reports whether the open document differs from its baseline
+// and can be written back to it.
+func (p *JournalPage) canRevert() bool {
+	return p.entryID != "" && !p.browsing() && !p.conflict && p.textarea.Value() != p.baseline
+}
+
+// confirmRevert asks before replacing the open document with its baseline.
+func (p *JournalPage) confirmRevert() {
+	what := "today's entry"
+	if p.scratch {
+		what = "the scratchpad"
+	}
+	p.confirm = newConfirmDialog(journalConfirmRevert, p.entryID, "Revert",
+		fmt.Sprintf("Restore %s to how it was when you opened it?", what))
+	p.confirm.warning = "Everything written since is discarded, including edits already saved."
+}
+
+// revert restores the open document's baseline and saves it.
+func (p *JournalPage) revert() tea.Cmd {
+	if !p.canRevert() {
+		return nil
+	}
+	p.textarea.SetValue(p.baseline)
+	p.debounceVersion++ // pending autosaves would write the same thing
+	p.dirtySince = time.Time{}
+	p.notice = "Reverted to how it was when opened."
+	return p.save(false)
+}
+
+// handleCalendarMouse selects the calendar day under a click, outside vim
+// mode. The calendar sits to the right of the editor, level with its top.
+func (p *JournalPage) handleCalendarMouse(msg tea.MouseMsg) tea.Cmd {
+	if !p.showCal || p.scratch || p.mode != journalModeView || !isClick(msg) {
+		return nil
+	}
+	x := msg.X - lipgloss.Width(p.editorView()) - calGap
+	y := msg.Y - journalEditorTop
+	if day, ok := calendarDayAt(x, y, monthOf(p.selectedDay()), p.weekStart); ok {
+		return p.selectDay(day)
+	}
+	return nil
+}
+
+// startNewNote saves the open note and creates a new timestamped one for
+// today. An empty note is reused rather than leaving blanks behind.
+func (p *JournalPage) startNewNote() tea.Cmd {
+	if p.entryID == "" || p.scratch || strings.TrimSpace(p.textarea.Value()) == "" || p.conflict || p.pendingSave {
+		return nil
+	}
+	var cmds []tea.Cmd
+	if p.textarea.Value() != p.lastSavedContent {
+		cmds = append(cmds, saveJournalEntryCmd(p.db, p.entryID, p.textarea.Value(), p.base(), false))
+	}
+	cmds = append(cmds, createJournalEntryCmd(p.db))
+	return tea.Batch(cmds...)
+}
+
 func (p *JournalPage) handleVimNormalMode(msg tea.KeyMsg) (Page, tea.Cmd) {
 	keyStr := msg.String()
 
@@ -248,7 +827,7 @@ func (p *JournalPage) handleVimNormalMode(msg tea.KeyMsg) (Page, tea.Cmd) {
 		if keyStr == "d" {
 			// dd - delete line
 			p.deleteLine()
-			return p, startDebounceCmd(p.debounceVersion)
+			return p, p.edited()
 		}
 		// Invalid sequence, ignore
 		return p, nil
@@ -261,8 +840,7 @@ func (p *JournalPage) handleVimNormalMode(msg tea.KeyMsg) (Page, tea.Cmd) {
 		p.textarea.Blur()
 		// Save if modified
 		if p.textarea.Value() != p.lastSavedContent {
-			p.pendingSave = true
-			return p, saveJournalEntryCmd(p.db, p.entryID, p.textarea.Value())
+			return p, p.save(false)
 		}
 		return p, nil
 
@@ -303,8 +881,7 @@ func (p *JournalPage) handleVimNormalMode(msg tea.KeyMsg) (Page, tea.Cmd) {
 	// Delete character
 	case "x":
 		p.textarea, _ = p.textarea.Update(tea.KeyMsg{Type: tea.KeyDelete})
-		p.debounceVersion++
-		return p, startDebounceCmd(p.debounceVersion)
+		return p, p.edited()
 
 	// Mode entry - insert variants
 	case "i":
@@ -328,15 +905,13 @@ func (p *JournalPage) handleVimNormalMode(msg tea.KeyMsg) (Page, tea.Cmd) {
 		p.textarea, _ = p.textarea.Update(tea.KeyMsg{Type: tea.KeyEnd})
 		p.textarea, _ = p.textarea.Update(tea.KeyMsg{Type: tea.KeyEnter})
 		p.mode = journalModeVimInsert
-		p.debounceVersion++
-		return p, startDebounceCmd(p.debounceVersion)
+		return p, p.edited()
 	case "O":
 		p.textarea, _ = p.textarea.Update(tea.KeyMsg{Type: tea.KeyHome})
 		p.textarea, _ = p.textarea.Update(tea.KeyMsg{Type: tea.KeyEnter})
 		p.textarea, _ = p.textarea.Update(tea.KeyMsg{Type: tea.KeyUp})
 		p.mode = journalModeVimInsert
-		p.debounceVersion++
-		return p, startDebounceCmd(p.debounceVersion)
+		return p, p.edited()
 	}
 
 	return p, nil
@@ -347,8 +922,7 @@ func (p *JournalPage) handleVimInsertMode(msg tea.KeyMsg) (Page, tea.Cmd) {
 		p.mode = journalModeVimNormal
 		// Save if modified
 		if p.textarea.Value() != p.lastSavedContent {
-			p.pendingSave = true
-			return p, saveJournalEntryCmd(p.db, p.entryID, p.textarea.Value())
+			return p, p.save(false)
 		}
 		return p, nil
 	}
@@ -364,8 +938,7 @@ func (p *JournalPage) handleVimInsertMode(msg tea.KeyMsg) (Page, tea.Cmd) {
 	}
 
 	if p.textarea.Value() != oldValue {
-		p.debounceVersion++
-		cmds = append(cmds, startDebounceCmd(p.debounceVersion))
+		cmds = append(cmds, p.edited())
 	}
 
 	return p, tea.Batch(cmds...)
@@ -381,23 +954,46 @@ func (p *JournalPage) deleteLine() {
 	p.textarea, _ = p.textarea.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
 	// Delete the newline character if present
 	p.textarea, _ = p.textarea.Update(tea.KeyMsg{Type: tea.KeyDelete})
-	p.debounceVersion++
 }
 
 func (p *JournalPage) View() string {
+	if p.confirm != nil {
+		return p.confirm.View()
+	}
+
 	var b strings.Builder
 
 	modeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
 	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B"))
 	statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
 
-	today := time.Now().Format("Monday, January 2, 2006")
-	b.WriteString(lipgloss.NewStyle().Bold(true).Render(today))
+	day := p.selectedDay().Format(p.dateLayout)
+	if p.scratch {
+		day = "Scratchpad"
+	}
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(day))
+	if p.timestamped && p.entryTime != "" && !p.browsing() && !p.scratch {
+		b.WriteString(modeStyle.Render(" · note from " + p.entryTime))
+	}
+	if p.private && p.canMarkPrivate() {
+		b.WriteString(modeStyle.Render(" · 🔒 private"))
+	}
 	b.WriteString("\n")
 
 	switch p.mode {
 	case journalModeView:
-		b.WriteString(modeStyle.Render("Press ctrl+v for vim mode"))
+		if p.copyDraft != "" {
+			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render(
+				"Start today's entry from yesterday's? (y to confirm, n or esc to cancel)"))
+		} else if p.scratch {
+			b.WriteString(modeStyle.Render("Press ctrl+v for vim mode, s to return to the journal"))
+		} else if p.browsing() {
+			b.WriteString(modeStyle.Render("Read-only · t for today, ctrl+v to edit today's entry"))
+		} else if p.timestamped {
+			b.WriteString(modeStyle.Render("Press ctrl+v for vim mode, n for a new note"))
+		} else {
+			b.WriteString(modeStyle.Render("Press ctrl+v for vim mode"))
+		}
 	case journalModeVimNormal:
 		indicator := "-- NORMAL --"
 		if p.pendingKey != "" {
@@ -409,7 +1005,23 @@ func (p *JournalPage) View() string {
 	}
 	b.WriteString("\n\n")
 
-	b.WriteString(p.textarea.View())
+	editor := p.editorView()
+	if p.showCal && !p.scratch {
+		today := todayKey()
+		selected := p.selectedDay()
+		var days map[string]bool
+		if selected.Format("2006-01") == p.calDaysMonth {
+			days = p.calDays
+		}
+		cal := renderMiniCalendar(monthOf(selected), p.weekStart, days, today, selected.Format("2006-01-02"))
+		editor = lipgloss.JoinHorizontal(lipgloss.Top, editor, strings.Repeat(" ", calGap), cal)
+	}
+	b.WriteString(editor)
+
+	if p.showTasks {
+		b.WriteString("\n\n")
+		b.WriteString(p.tasksView(lipgloss.Width(editor)))
+	}
 
 	if p.err != nil {
 		b.WriteString("\n")
@@ -417,7 +1029,14 @@ func (p *JournalPage) View() string {
 	}
 
 	b.WriteString("\n")
-	if p.pendingSave {
+	if p.browsing() {
+		// Nothing to save while viewing another day
+	} else if p.conflict {
+		b.WriteString(errorStyle.Render(
+			"Changed in another window; not saved. ctrl+r: reload and discard mine · ctrl+o: overwrite with mine"))
+	} else if p.notice != "" {
+		b.WriteString(statusStyle.Render(p.notice))
+	} else if p.pendingSave {
 		b.WriteString(statusStyle.Render("Saving..."))
 	} else if p.textarea.Value() != p.lastSavedContent {
 		b.WriteString(statusStyle.Render("Modified"))
@@ -428,53 +1047,186 @@ func (p *JournalPage) View() string {
 	return b.String()
 }
 
+// journalEditorTop is the row the editor starts on, below the date, the
+// mode line and a blank line.
+const journalEditorTop = 3
+
+// editorView renders the editor, or the selected day's entries read-only
+// in its place while browsing the calendar.
+func (p *JournalPage) editorView() string {
+	ta := p.textarea.View()
+	if !p.browsing() {
+		return ta
+	}
+	width, height := lipgloss.Width(ta), p.textarea.Height()
+	content := p.dayContent
+	switch {
+	case !p.dayLoaded:
+		content = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Render("Loading...")
+	case strings.TrimSpace(content) == "":
+		content = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Italic(true).Render("No entry for this day.")
+	default:
+		content = truncateContent(content, width, height)
+	}
+	return lipgloss.NewStyle().Width(width).Height(height).Render(content)
+}
+
 // Database commands
 
-func loadOrCreateJournalEntryCmd(db *sql.DB) tea.Cmd {
+// journalEntryColumns selects an entry with its start time (UTC; see
+// homeClock), its version and whether it is private. strftime keeps the driver from parsing the timestamps
+// into time.Time values; the version keeps milliseconds so saves in quick
+// succession still differ.
+const journalEntryColumns = `id, content, COALESCE(strftime('%Y-%m-%d %H:%M:%S', created_at), ''), ` +
+	journalEntryVersion + `, private`
+
+// journalEntryVersion is the version of an entry compared when saving.
+const journalEntryVersion = `COALESCE(strftime('%Y-%m-%d %H:%M:%f', updated_at), '')`
+
+// loadOrCreateJournalEntryCmd opens today's entry, creating it if there is
+// none. With several notes for the day, daily mode edits the first and
+// timestamped mode resumes the latest.
+func loadOrCreateJournalEntryCmd(db *sql.DB, latest bool) tea.Cmd {
+	order := "created_at, id"
+	if latest {
+		order = "created_at DESC, id DESC"
+	}
 	return func() tea.Msg {
-		var id, content string
+		var msg journalEntryLoadedMsg
 		err := db.QueryRow(`
-			SELECT id, content FROM journal_entries
-			WHERE entry_date = date('now', 'localtime')
-		`).Scan(&id, &content)
+			SELECT `+journalEntryColumns+` FROM journal_entries
+			WHERE entry_date = ?
+			ORDER BY `+order+`
+			LIMIT 1
+		`, todayKey()).Scan(&msg.id, &msg.content, &msg.createdAt, &msg.updatedAt, &msg.private)
 
 		if err == sql.ErrNoRows {
-			err = db.QueryRow(`
-				INSERT INTO journal_entries (id, entry_date, content)
-				VALUES (lower(hex(randomblob(16))), date('now', 'localtime'), '')
-				RETURNING id
-			`).Scan(&id)
-			if err != nil {
-				return journalEntryLoadFailedMsg{err: err}
-			}
-			return journalEntryLoadedMsg{id: id, content: ""}
+			return createJournalEntryCmd(db)()
 		}
 
 		if err != nil {
 			return journalEntryLoadFailedMsg{err: err}
 		}
 
-		return journalEntryLoadedMsg{id: id, content: content}
+		return msg
 	}
 }
 
-func saveJournalEntryCmd(db *sql.DB, entryID, content string) tea.Cmd {
+// createJournalEntryCmd adds a new empty entry for today.
+func createJournalEntryCmd(db *sql.DB) tea.Cmd {
 	return func() tea.Msg {
-		_, err := db.Exec(`
-			UPDATE journal_entries
-			SET content = ?, updated_at = CURRENT_TIMESTAMP
-			WHERE id = ?
-		`, content, entryID)
+		var msg journalEntryLoadedMsg
+		err := db.QueryRow(`
+			INSERT INTO journal_entries (id, entry_date, content)
+			VALUES (lower(hex(randomblob(16))), ?, '')
+			RETURNING `+journalEntryColumns+`
+		`, todayKey()).Scan(&msg.id, &msg.content, &msg.createdAt, &msg.updatedAt, &msg.private)
+		if err != nil {
+			return journalEntryLoadFailedMsg{err: err}
+		}
+		return msg
+	}
+}
 
+// loadJournalEntryCmd reloads an entry by id, e.g. to take another
+// instance's changes after a save conflict.
+func loadJournalEntryCmd(db *sql.DB, entryID string) tea.Cmd {
+	return func() tea.Msg {
+		var msg journalEntryLoadedMsg
+		err := db.QueryRow(`
+			SELECT `+journalEntryColumns+` FROM `+journalTable(entryID)+` WHERE id = ?
+		`, entryID).Scan(&msg.id, &msg.content, &msg.createdAt, &msg.updatedAt, &msg.private)
 		if err != nil {
-			return journalEntrySaveFailedMsg{err: err}
+			return journalEntryLoadFailedMsg{err: err}
 		}
-		return journalEntrySavedMsg{}
+		return msg
+	}
+}
+
+// journalEntryBase is the state of an entry an edit started from.
+type journalEntryBase struct {
+	content   string
+	updatedAt string
+}
+
+// saveJournalEntryCmd writes an entry's content if the stored entry still
+// matches base, reporting a conflict otherwise. Comparing the content as
+// well as the version catches writes within the same millisecond. force
+// skips the check.
+func saveJournalEntryCmd(db *sql.DB, entryID, content string, base journalEntryBase, force bool) tea.Cmd {
+	return func() tea.Msg {
+		var version string
+		err := db.QueryRow(`
+			UPDATE `+journalTable(entryID)+`
+			SET content = ?, updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+			WHERE id = ? AND (? OR (content = ? AND `+journalEntryVersion+` = ?))
+			RETURNING `+journalEntryVersion+`
+		`, content, entryID, force, base.content, base.updatedAt).Scan(&version)
+
+		if err == sql.ErrNoRows {
+			return journalEntryConflictMsg{id: entryID}
+		}
+		if err != nil {
+			return journalEntrySaveFailedMsg{id: entryID, err: err}
+		}
+		return journalEntrySavedMsg{id: entryID, content: content, updatedAt: version}
+	}
+}
+
+// setJournalPrivateCmd marks an entry private or shareable. It is not an
+// edit, so the entry's version is left alone.
+func setJournalPrivateCmd(db *sql.DB, entryID string, private bool) tea.Cmd {
+	return func() tea.Msg {
+		_, err := db.Exec(`UPDATE journal_entries SET private = ? WHERE id = ?`, private, entryID)
+		if err != nil {
+			return journalPrivateSaveFailedMsg{id: entryID, private: private, err: err}
+		}
+		return journalPrivateSavedMsg{id: entryID}
+	}
+}
+
+// loadYesterdayJournalCmd loads yesterday's entries as a starting point for
+// today's.
+func loadYesterdayJournalCmd(db *sql.DB) tea.Cmd {
+	return func() tea.Msg {
+		yesterday := dateKey(addDays(homeNow(), -1))
+		content, err := loadJournalDay(db, yesterday)
+		if err != nil {
+			return journalEntryLoadFailedMsg{err: err}
+		}
+		return journalYesterdayLoadedMsg{content: content}
+	}
+}
+
+// NormalizeJournalDates rewrites entry_date values stored with a time part
+// (e.g. "2025-01-02T00:00:00Z") to the date-only form the Journal page
+// writes, so same-day lookups can compare with plain equality. The calendar
+// date is kept as written rather than converted between time zones. It
+// returns the number of rows changed.
+func NormalizeJournalDates(db *sql.DB) (int64, error) {
+	res, err := db.Exec(`
+		UPDATE journal_entries
+		SET entry_date = substr(entry_date, 1, 10)
+		WHERE length(entry_date) > 10
+		  AND entry_date GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*'
+	`)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// edited records an edit to the textarea and restarts the autosave debounce.
+func (p *JournalPage) edited() tea.Cmd {
+	p.debounceVersion++
+	if p.dirtySince.IsZero() {
+		p.dirtySince = time.Now()
 	}
+	return startDebounceCmd(p.debounceInterval, p.debounceVersion)
 }
 
-func startDebounceCmd(version int) tea.Cmd {
-	return tea.Tick(journalDebounceInterval, func(t time.Time) tea.Msg {
+func startDebounceCmd(interval time.Duration, version int) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return journalDebounceTickMsg{version: version}
 	})
 }
@@ -0,0 +1,177 @@
+package pages
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The Journal page shows a month calendar beside the editor with a dot on
+// each day that has an entry. Selecting another day shows its entry
+// read-only in place of the editor.
+
+const (
+	calCellWidth  = 3 // day number plus the entry dot
+	calWidth      = 7 * calCellWidth
+	calGap        = 2 // columns between the editor and the calendar
+	calHeaderRows = 2 // month name and weekday names
+	calWeekRows   = 6 // enough for any month, so the height never changes
+)
+
+var (
+	calTitleStyle   = lipgloss.NewStyle().Bold(true).Width(calWidth).Align(lipgloss.Center)
+	calWeekdayStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	calDotStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#00CED1"))
+	calTodayStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#00CED1"))
+)
+
+type journalDaysLoadedMsg struct {
+	month string // "YYYY-MM"
+	days  map[string]bool
+}
+
+type journalDaysLoadFailedMsg struct {
+	err error
+}
+
+type journalDayLoadedMsg struct {
+	date    string
+	content string
+}
+
+type journalDayLoadFailedMsg struct {
+	date string
+	err  error
+}
+
+// monthOf returns midnight on the first of t's month.
+func monthOf(t time.Time) time.Time {
+	return midnight(t.Year(), t.Month(), 1, t.Location())
+}
+
+// loadJournalDaysCmd finds the days of month that have a non-empty entry.
+func loadJournalDaysCmd(db *sql.DB, month time.Time) tea.Cmd {
+	from := month.Format("2006-01-02")
+	to := month.AddDate(0, 1, -1).Format("2006-01-02")
+	return func() tea.Msg {
+		rows, err := db.Query(`
+			SELECT DISTINCT date(entry_date) FROM journal_entries
+			WHERE entry_date BETWEEN ? AND ? AND trim(content) != ''
+		`, from, to)
+		if err != nil {
+			return journalDaysLoadFailedMsg{err: err}
+		}
+		defer rows.Close()
+
+		days := make(map[string]bool)
+		for rows.Next() {
+			var date string
+			if err := rows.Scan(&date); err != nil {
+				return journalDaysLoadFailedMsg{err: err}
+			}
+			days[date] = true
+		}
+		if err := rows.Err(); err != nil {
+			return journalDaysLoadFailedMsg{err: err}
+		}
+		return journalDaysLoadedMsg{month: month.Format("2006-01"), days: days}
+	}
+}
+
+// loadJournalDayCmd loads a day's entries for read-only viewing.
+func loadJournalDayCmd(db *sql.DB, date string) tea.Cmd {
+	return func() tea.Msg {
+		content, err := loadJournalDay(db, date)
+		if err != nil {
+			return journalDayLoadFailedMsg{date: date, err: err}
+		}
+		return journalDayLoadedMsg{date: date, content: content}
+	}
+}
+
+// loadJournalDay returns the entries for date ("YYYY-MM-DD"), combined the
+// same way the History journal table shows them.
+func loadJournalDay(db *sql.DB, date string) (string, error) {
+	rows, err := db.Query(`
+		SELECT COALESCE(strftime('%Y-%m-%d %H:%M:%S', created_at), ''), content
+		FROM journal_entries
+		WHERE entry_date = ?
+		ORDER BY created_at, id
+	`, date)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var notes []journalNote
+	for rows.Next() {
+		var n journalNote
+		if err := rows.Scan(&n.createdAt, &n.content); err != nil {
+			return "", err
+		}
+		n.createdAt = homeClock(n.createdAt)
+		notes = append(notes, n)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return combineNotes(notes), nil
+}
+
+// renderMiniCalendar draws month with weeks starting on first. Days in days
+// get a dot; today and selected ("YYYY-MM-DD") are highlighted.
+func renderMiniCalendar(month time.Time, first time.Weekday, days map[string]bool, today, selected string) string {
+	var b strings.Builder
+	b.WriteString(calTitleStyle.Render(month.Format("January 2006")))
+	b.WriteString("\n")
+
+	start := weekStartOn(month, first)
+	for d := 0; d < 7; d++ {
+		name := start.AddDate(0, 0, d).Format("Mon")[:2]
+		b.WriteString(calWeekdayStyle.Render(fmt.Sprintf("%-*s", calCellWidth, name)))
+	}
+
+	for w := 0; w < calWeekRows; w++ {
+		b.WriteString("\n")
+		for d := 0; d < 7; d++ {
+			day := start.AddDate(0, 0, w*7+d)
+			if day.Month() != month.Month() {
+				b.WriteString(strings.Repeat(" ", calCellWidth))
+				continue
+			}
+			date := day.Format("2006-01-02")
+			style := lipgloss.NewStyle()
+			if date == today {
+				style = calTodayStyle
+			}
+			if date == selected {
+				style = style.Reverse(true)
+			}
+			b.WriteString(style.Render(fmt.Sprintf("%2d", day.Day())))
+			if days[date] {
+				b.WriteString(calDotStyle.Render("•"))
+			} else {
+				b.WriteString(" ")
+			}
+		}
+	}
+	return b.String()
+}
+
+// calendarDayAt returns the day drawn at column x, row y of the calendar
+// rendered by renderMiniCalendar, if any.
+func calendarDayAt(x, y int, month time.Time, first time.Weekday) (time.Time, bool) {
+	row, col := y-calHeaderRows, x/calCellWidth
+	if x < 0 || row < 0 || row >= calWeekRows || col >= 7 {
+		return time.Time{}, false
+	}
+	day := weekStartOn(month, first).AddDate(0, 0, row*7+col)
+	if day.Month() != month.Month() {
+		return time.Time{}, false
+	}
+	return day, true
+}
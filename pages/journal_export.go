@@ -0,0 +1,53 @@
+package pages
+
+import "database/sql"
+
+// JournalExportEntry is one day's journal, as written out by the
+// export-journal command.
+type JournalExportEntry struct {
+	Date    string // "YYYY-MM-DD"
+	Content string // the day's notes, combined as History shows them
+}
+
+// LoadJournalExport loads every day with something written, oldest first.
+// Private entries are left out unless includePrivate is set; the scratchpad
+// is never included.
+func LoadJournalExport(db *sql.DB, includePrivate bool) ([]JournalExportEntry, error) {
+	rows, err := db.Query(`
+		SELECT date(entry_date), COALESCE(strftime('%Y-%m-%d %H:%M:%S', created_at), ''), content
+		FROM journal_entries
+		WHERE (private = false OR ?) AND trim(content) != ''
+		ORDER BY entry_date, created_at, id
+	`, includePrivate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []JournalExportEntry
+	var notes []journalNote
+	flush := func() {
+		if len(notes) > 0 {
+			days[len(days)-1].Content = combineNotes(notes)
+			notes = nil
+		}
+	}
+	for rows.Next() {
+		var date string
+		var n journalNote
+		if err := rows.Scan(&date, &n.createdAt, &n.content); err != nil {
+			return nil, err
+		}
+		n.createdAt = homeClock(n.createdAt)
+		if len(days) == 0 || days[len(days)-1].Date != date {
+			flush()
+			days = append(days, JournalExportEntry{Date: date})
+		}
+		notes = append(notes, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return days, nil
+}
@@ -0,0 +1,128 @@
+package pages
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The scratchpad is one undated note kept beside the journal, e.g. for a
+// shopping list. It is edited in the Journal page's editor like an entry but
+// stored in its own table, so it never shows up in the calendar or History.
+
+// journalScratchpadID is the id of the scratchpad's only row.
+const journalScratchpadID = "scratchpad"
+
+// journalTable returns the table the document with id is stored in.
+func journalTable(id string) string {
+	if id == journalScratchpadID {
+		return "journal_scratchpad"
+	}
+	return "journal_entries"
+}
+
+// journalDoc is the editing state of the document not in the editor while
+// switching between today's entry and the scratchpad. An empty id means it
+// hasn't been loaded.
+type journalDoc struct {
+	id               string
+	entryTime        string
+	content          string // the editor's text, saved or not
+	lastSavedContent string
+	baseline         string
+	updatedAt        string
+	private          bool
+	pendingSave      bool
+	conflict         bool
+}
+
+// current returns the state of the document in the editor.
+func (p *JournalPage) current() journalDoc {
+	return journalDoc{
+		id:               p.entryID,
+		entryTime:        p.entryTime,
+		content:          p.textarea.Value(),
+		lastSavedContent: p.lastSavedContent,
+		baseline:         p.baseline,
+		updatedAt:        p.updatedAt,
+		private:          p.private,
+		pendingSave:      p.pendingSave,
+		conflict:         p.conflict,
+	}
+}
+
+// toggleScratchpad swaps the editor between today's entry and the
+// scratchpad. Unsaved edits are saved first; either document's save can
+// finish while the other is shown.
+func (p *JournalPage) toggleScratchpad() tea.Cmd {
+	var cmds []tea.Cmd
+	if p.textarea.Value() != p.lastSavedContent {
+		cmds = append(cmds, p.save(false))
+	}
+
+	next := p.other
+	p.other = p.current()
+	p.scratch = !p.scratch
+	p.calSelected = time.Time{} // back to today's entry, not a past day
+
+	p.entryID = next.id
+	p.entryTime = next.entryTime
+	p.textarea.SetValue(next.content)
+	p.lastSavedContent = next.lastSavedContent
+	p.baseline = next.baseline
+	p.updatedAt = next.updatedAt
+	p.private = next.private
+	p.pendingSave = next.pendingSave
+	p.conflict = next.conflict
+	p.err = nil
+	// Ticks were for the other document
+	p.debounceVersion++
+	p.dirtySince = time.Time{}
+
+	switch {
+	case next.id == "" && p.scratch:
+		cmds = append(cmds, loadJournalEntryCmd(p.db, journalScratchpadID))
+	case next.id == "":
+		cmds = append(cmds, loadOrCreateJournalEntryCmd(p.db, p.timestamped))
+	case next.content != next.lastSavedContent:
+		cmds = append(cmds, p.edited())
+	}
+	return tea.Batch(cmds...)
+}
+
+// saveOther continues saving the document not in the editor after one of
+// its saves completed.
+func (p *JournalPage) saveOther() tea.Cmd {
+	if p.other.pendingSave || p.other.conflict || p.other.content == p.other.lastSavedContent {
+		return nil
+	}
+	p.other.pendingSave = true
+	base := journalEntryBase{content: p.other.lastSavedContent, updatedAt: p.other.updatedAt}
+	return saveJournalEntryCmd(p.db, p.other.id, p.other.content, base, false)
+}
+
+// saveOnShutdown writes a document's unsaved edits synchronously. Edits
+// that conflict with another instance's are not written.
+func (p *JournalPage) saveOnShutdown(doc journalDoc) error {
+	if doc.id == "" || doc.content == doc.lastSavedContent {
+		return nil
+	}
+	what := "journal"
+	if doc.id == journalScratchpadID {
+		what = "scratchpad"
+	}
+	if doc.conflict {
+		return fmt.Errorf("save %s: changed elsewhere; unsaved edits discarded", what)
+	}
+	// With a save of ours still in flight the version may already be stale,
+	// and the conflict would only be with ourselves.
+	base := journalEntryBase{content: doc.lastSavedContent, updatedAt: doc.updatedAt}
+	switch msg := saveJournalEntryCmd(p.db, doc.id, doc.content, base, doc.pendingSave)().(type) {
+	case journalEntrySaveFailedMsg:
+		return fmt.Errorf("save %s: %w", what, msg.err)
+	case journalEntryConflictMsg:
+		return fmt.Errorf("save %s: changed elsewhere; unsaved edits discarded", what)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package pages
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// The Journal page can show today's tasks as a checklist below the editor,
+// to tick off something while writing about it. It is collapsed by default
+// and while editing, and reloads each time it is opened so it matches the
+// Today page.
+
+// journalTasksMaxRows caps the checklist's height; longer lists scroll.
+const journalTasksMaxRows = 6
+
+var (
+	journalTasksHeaderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	journalTaskCursorStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+	journalTaskDoneStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
+)
+
+// toggleTasks opens or collapses the checklist, loading today's tasks when
+// it opens.
+func (p *JournalPage) toggleTasks() tea.Cmd {
+	p.showTasks = !p.showTasks
+	p.SetSize(p.width, p.height)
+	if !p.showTasks {
+		return nil
+	}
+	p.tasksLoaded = false
+	return loadTodayDataCmd(p.db)
+}
+
+// closeTasks collapses the checklist, e.g. to make room for editing.
+func (p *JournalPage) closeTasks() {
+	if p.showTasks {
+		p.showTasks = false
+		p.SetSize(p.width, p.height)
+	}
+}
+
+// tasksHeight is the number of rows the checklist takes below the editor,
+// including the blank line above it.
+func (p *JournalPage) tasksHeight() int {
+	if !p.showTasks {
+		return 0
+	}
+	return 2 + p.taskRows()
+}
+
+func (p *JournalPage) taskRows() int {
+	return max(min(len(p.tasks), journalTasksMaxRows), 1)
+}
+
+// moveTaskCursor moves the checklist selection by step, clamped to the list.
+func (p *JournalPage) moveTaskCursor(step int) {
+	p.taskCursor = max(min(p.taskCursor+step, len(p.tasks)-1), 0)
+}
+
+// toggleSelectedTask flips the selected task's completion optimistically,
+// or counts a counter task once more, and returns the command to persist it.
+func (p *JournalPage) toggleSelectedTask() tea.Cmd {
+	if !p.tasksLoaded || p.taskCursor >= len(p.tasks) {
+		return nil
+	}
+	t := &p.tasks[p.taskCursor]
+	if t.counter() {
+		t.addCount(1)
+		return saveTaskCountCmd(p.db, t.id, t.title, 1, t.target)
+	}
+	t.ToggleCompleted()
+	return saveTaskCompletionCmd(p.db, t.id, t.title, t.completed)
+}
+
+// updateTasks handles the checklist's load and save results.
+func (p *JournalPage) updateTasks(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case activeTasksLoadedMsg:
+		p.tasks = msg.tasks
+		p.tasksLoaded = true
+		p.moveTaskCursor(0)
+		p.SetSize(p.width, p.height)
+
+	case activeTasksLoadFailedMsg:
+		p.err = msg.err
+
+	case taskCompletionSavedMsg, taskCountSavedMsg:
+		return tea.Batch(
+			func() tea.Msg { return InvalidateTodayPageMsg{} },
+			func() tea.Msg { return InvalidateHistoryPageMsg{} },
+		)
+
+	case taskCompletionSaveFailedMsg:
+		logger.Errorf("journal: save completion of task %s: %v", msg.taskID, msg.err)
+		for i := range p.tasks {
+			if p.tasks[i].id == msg.taskID && p.tasks[i].completed == msg.completed {
+				p.tasks[i].ToggleCompleted() // revert
+				break
+			}
+		}
+		p.err = msg.err
+
+	case taskCountSaveFailedMsg:
+		logger.Errorf("journal: save count of task %s: %v", msg.taskID, msg.err)
+		p.err = msg.err
+		return loadTodayDataCmd(p.db)
+	}
+	return nil
+}
+
+// tasksView renders the checklist, scrolled to keep the selection visible.
+func (p *JournalPage) tasksView(width int) string {
+	var b strings.Builder
+	done := 0
+	for _, t := range p.tasks {
+		if t.completed {
+			done++
+		}
+	}
+	b.WriteString(journalTasksHeaderStyle.Render(fmt.Sprintf("Today's tasks (%d/%d)", done, len(p.tasks))))
+
+	switch {
+	case !p.tasksLoaded:
+		b.WriteString("\n" + journalTaskDoneStyle.Render("Loading..."))
+		return b.String()
+	case len(p.tasks) == 0:
+		b.WriteString("\n" + journalTaskDoneStyle.Italic(true).Render("No tasks today."))
+		return b.String()
+	}
+
+	rows := p.taskRows()
+	first := max(min(p.taskCursor-rows/2, len(p.tasks)-rows), 0)
+	for i := first; i < first+rows; i++ {
+		t := p.tasks[i]
+		checkbox := "□"
+		if t.completed {
+			checkbox = "■"
+		} else if t.satisfiedWeek {
+			checkbox = "▣"
+		} else if t.counter() && t.count > 0 {
+			checkbox = "◧"
+		}
+		title := t.title
+		if t.counter() {
+			title += fmt.Sprintf(" %d/%d", t.count, t.target)
+		}
+		line := ansi.Truncate(checkbox+" "+title, max(width-2, 1), ellipsis)
+		switch {
+		case i == p.taskCursor:
+			line = journalTaskCursorStyle.Render("> " + line)
+		case t.completed || t.satisfiedWeek:
+			line = "  " + journalTaskDoneStyle.Render(line)
+		default:
+			line = "  " + line
+		}
+		b.WriteString("\n" + line)
+	}
+	return b.String()
+}
@@ -0,0 +1,127 @@
+package pages
+
+import (
+	"testing"
+
+	"stet.codes/tui/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// An autosave tick only saves for the latest edit; ticks for earlier edits
+// are left to the one it started.
+func TestJournalDebounceSavesLatestEdit(t *testing.T) {
+	db := openTestDB(t)
+	p := NewJournalPage(db, config.Default())
+	p.Update(loadOrCreateJournalEntryCmd(db, false)())
+	if p.entryID == "" {
+		t.Fatal("no entry loaded")
+	}
+
+	p.textarea.SetValue("first")
+	p.edited()
+	stale := p.debounceVersion
+	p.textarea.SetValue("first and second")
+	p.edited()
+	current := p.debounceVersion
+
+	if _, cmd := p.Update(journalDebounceTickMsg{version: stale}); cmd != nil {
+		t.Fatalf("stale tick started a save: %#v", cmd())
+	}
+	if got := storedJournalContent(t, p); got != "" {
+		t.Fatalf("stale tick saved %q", got)
+	}
+
+	_, cmd := p.Update(journalDebounceTickMsg{version: current})
+	if cmd == nil {
+		t.Fatal("current tick didn't save")
+	}
+	saved, ok := cmd().(journalEntrySavedMsg)
+	if !ok {
+		t.Fatal("current tick's save failed")
+	}
+	p.Update(saved)
+	if got := storedJournalContent(t, p); got != "first and second" {
+		t.Errorf("saved %q, want %q", got, "first and second")
+	}
+}
+
+func storedJournalContent(t *testing.T, p *JournalPage) string {
+	t.Helper()
+	var content string
+	if err := p.db.QueryRow(`SELECT content FROM journal_entries WHERE id = ?`, p.entryID).Scan(&content); err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestNormalizeJournalDates(t *testing.T) {
+	db := openTestDB(t)
+	seed := map[string]string{ // id -> entry_date as stored
+		"plain":    "2025-01-02",
+		"utc":      "2025-01-03T00:00:00Z",
+		"space":    "2025-01-04 00:00:00",
+		"offset":   "2025-01-05T23:30:00-05:00",
+		"fraction": "2025-01-06 12:34:56.789",
+	}
+	for id, date := range seed {
+		if _, err := db.Exec(`
+			INSERT INTO journal_entries (id, entry_date, content) VALUES (?, ?, 'x')
+		`, id, date); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n, err := NormalizeJournalDates(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 4 {
+		t.Errorf("changed %d rows, want 4", n)
+	}
+
+	for id, date := range seed {
+		// Concatenated so the driver returns the text as stored rather than
+		// parsing the DATE column
+		var got string
+		if err := db.QueryRow(`SELECT entry_date || '' FROM journal_entries WHERE id = ?`, id).Scan(&got); err != nil {
+			t.Fatal(err)
+		}
+		if want := date[:10]; got != want {
+			t.Errorf("%s: entry_date is %q, want %q", id, got, want)
+		}
+	}
+
+	if n, err := NormalizeJournalDates(db); err != nil || n != 0 {
+		t.Errorf("second run changed %d rows (err %v), want 0", n, err)
+	}
+}
+
+// p marks today's entry private, but does nothing on the scratchpad.
+func TestJournalPrivateKey(t *testing.T) {
+	db := openTestDB(t)
+	p := NewJournalPage(db, config.Default())
+	p.Update(loadOrCreateJournalEntryCmd(db, false)())
+	press := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")}
+
+	p.scratch = true
+	if _, cmd := p.Update(press); cmd != nil || p.private {
+		t.Fatal("p on the scratchpad marked it private")
+	}
+
+	p.scratch = false
+	_, cmd := p.Update(press)
+	if cmd == nil || !p.private {
+		t.Fatal("p on today's entry didn't mark it private")
+	}
+	if _, ok := cmd().(journalPrivateSavedMsg); !ok {
+		t.Fatal("marking the entry private failed")
+	}
+	var private bool
+	if err := db.QueryRow(`SELECT private FROM journal_entries WHERE id = ?`, p.entryID).Scan(&private); err != nil {
+		t.Fatal(err)
+	}
+	if !private {
+		t.Error("entry isn't private in the database")
+	}
+}
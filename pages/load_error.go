@@ -0,0 +1,21 @@
+package pages
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderLoadError renders the persistent failed-load state shown in place of
+// a page's content until a reload succeeds.
+func renderLoadError(what string, err error) string {
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B")).
+		Bold(true)
+	hintStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	return errorStyle.Render(fmt.Sprintf("Failed to load %s", what)) + "\n\n" +
+		fmt.Sprintf("%v", err) + "\n\n" +
+		hintStyle.Render("press r to retry")
+}
@@ -0,0 +1,15 @@
+package pages
+
+import "stet.codes/tui/logging"
+
+// logger receives diagnostic output from pages. It discards everything until
+// SetLogger is called so pages stay quiet when used without a log sink.
+var logger = logging.Discard()
+
+// SetLogger sets the logger used for page diagnostics.
+func SetLogger(l *logging.Logger) {
+	if l == nil {
+		l = logging.Discard()
+	}
+	logger = l
+}
@@ -0,0 +1,61 @@
+package pages
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Mouse messages reach pages with X and Y relative to the page's content,
+// i.e. the top-left cell of the string returned by View.
+
+// isClick reports whether msg is a left button press.
+func isClick(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft
+}
+
+// scrollList moves the list's cursor for a scroll wheel event and reports
+// whether msg was one.
+func scrollList(l *list.Model, msg tea.MouseMsg) bool {
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		l.CursorUp()
+	case tea.MouseButtonWheelDown:
+		l.CursorDown()
+	default:
+		return false
+	}
+	return true
+}
+
+// listHeaderHeight returns the number of rows a list renders above its first
+// item: the title bar and the status bar, when shown.
+func listHeaderHeight(l list.Model) int {
+	h := 0
+	if l.ShowTitle() || (l.ShowFilter() && l.FilteringEnabled()) {
+		h += lipgloss.Height(l.Styles.TitleBar.Render(l.Styles.Title.Render(l.Title)))
+	}
+	if l.ShowStatusBar() {
+		h += lipgloss.Height(l.Styles.StatusBar.Render(" "))
+	}
+	return h
+}
+
+// listItemAt returns the index into l.VisibleItems() of the item drawn on
+// row y of the list's view, or -1 if there is none. d is the list's delegate.
+func listItemAt(l list.Model, d list.ItemDelegate, y int) int {
+	row := y - listHeaderHeight(l)
+	step := d.Height() + d.Spacing()
+	if row < 0 || step <= 0 || row%step >= d.Height() {
+		return -1
+	}
+	pos := row / step
+	if pos >= l.Paginator.PerPage {
+		return -1
+	}
+	idx := l.Paginator.Page*l.Paginator.PerPage + pos
+	if idx >= len(l.VisibleItems()) {
+		return -1
+	}
+	return idx
+}
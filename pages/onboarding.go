@@ -0,0 +1,239 @@
+package pages
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// On a fresh install the app opens on an empty Today page. Onboarding is a
+// short introduction shown over it instead: what each page is for, a few
+// sample tasks to start with, and where to set up Oura and Planta. It is
+// shown once; finishing or skipping it records that in app_state.
+
+// onboardedKey is the app_state key set once onboarding is done.
+const onboardedKey = "onboarded"
+
+// onboardingSamples are the tasks offered to start with.
+var onboardingSamples = []string{
+	"Drink a glass of water",
+	"Read for 20 minutes",
+	"Stretch",
+}
+
+const (
+	onboardingWelcome = iota
+	onboardingTasks
+	onboardingIntegrations
+	onboardingSteps
+)
+
+// OnboardingFinishedMsg reports that onboarding was saved: the sample tasks
+// chosen were added and it won't be shown again.
+type OnboardingFinishedMsg struct {
+	Tasks int // sample tasks added
+	Err   error
+}
+
+type onboardingKeyMap struct {
+	Next   key.Binding
+	Nav    key.Binding
+	Toggle key.Binding
+	Skip   key.Binding
+}
+
+var onboardingKeys = onboardingKeyMap{
+	Next: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "continue"),
+	),
+	Nav: key.NewBinding(
+		key.WithKeys("up", "down", "k", "j"),
+		key.WithHelp("↑/↓", "move"),
+	),
+	Toggle: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "toggle"),
+	),
+	Skip: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "skip"),
+	),
+}
+
+var (
+	onboardingTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#04B575"))
+	onboardingHintStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	onboardingKeyStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+)
+
+// Onboarding is the first-run introduction. The app shows it in place of
+// the active page and passes it keys until Done.
+type Onboarding struct {
+	db     *sql.DB
+	step   int
+	chosen []bool // per onboardingSamples
+	cursor int
+	done   bool
+	width  int
+}
+
+// NewOnboarding returns the introduction, with every sample task chosen.
+func NewOnboarding(db *sql.DB) *Onboarding {
+	chosen := make([]bool, len(onboardingSamples))
+	for i := range chosen {
+		chosen[i] = true
+	}
+	return &Onboarding{db: db, chosen: chosen}
+}
+
+// NeedsOnboarding reports whether the database looks like a first run: no
+// tasks, not even deleted ones, and onboarding not yet done.
+func NeedsOnboarding(db *sql.DB) (bool, error) {
+	var tasks, onboarded int
+	err := db.QueryRow(`
+		SELECT (SELECT COUNT(*) FROM task_definitions),
+		       (SELECT COUNT(*) FROM app_state WHERE key = ?)
+	`, onboardedKey).Scan(&tasks, &onboarded)
+	if err != nil {
+		return false, err
+	}
+	return tasks == 0 && onboarded == 0, nil
+}
+
+// finishOnboardingCmd adds the chosen sample tasks and records that
+// onboarding is done, together.
+func finishOnboardingCmd(db *sql.DB, titles []string) tea.Cmd {
+	return func() tea.Msg {
+		tx, err := db.Begin()
+		if err != nil {
+			return OnboardingFinishedMsg{Err: err}
+		}
+		defer tx.Rollback()
+
+		for _, title := range titles {
+			if _, err := tx.Exec(`
+				INSERT INTO task_definitions (id, title, description)
+				VALUES (lower(hex(randomblob(16))), ?, '')
+			`, title); err != nil {
+				return OnboardingFinishedMsg{Err: err}
+			}
+		}
+		if _, err := tx.Exec(`
+			INSERT OR REPLACE INTO app_state (key, value) VALUES (?, 'true')
+		`, onboardedKey); err != nil {
+			return OnboardingFinishedMsg{Err: err}
+		}
+		if err := tx.Commit(); err != nil {
+			return OnboardingFinishedMsg{Err: err}
+		}
+		return OnboardingFinishedMsg{Tasks: len(titles)}
+	}
+}
+
+// Done reports whether onboarding has been finished or skipped.
+func (o *Onboarding) Done() bool {
+	return o.done
+}
+
+func (o *Onboarding) SetSize(width, height int) {
+	o.width = width
+}
+
+// Update handles a key. Enter moves on, finishing after the last step; esc
+// skips the rest without adding sample tasks.
+func (o *Onboarding) Update(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, onboardingKeys.Skip):
+		o.done = true
+		return finishOnboardingCmd(o.db, nil)
+
+	case key.Matches(msg, onboardingKeys.Next):
+		o.step++
+		if o.step < onboardingSteps {
+			return nil
+		}
+		o.done = true
+		var titles []string
+		for i, title := range onboardingSamples {
+			if o.chosen[i] {
+				titles = append(titles, title)
+			}
+		}
+		return finishOnboardingCmd(o.db, titles)
+	}
+
+	if o.step != onboardingTasks {
+		return nil
+	}
+	switch {
+	case msg.String() == "up" || msg.String() == "k":
+		o.cursor = max(o.cursor-1, 0)
+	case msg.String() == "down" || msg.String() == "j":
+		o.cursor = min(o.cursor+1, len(onboardingSamples)-1)
+	case key.Matches(msg, onboardingKeys.Toggle):
+		o.chosen[o.cursor] = !o.chosen[o.cursor]
+	}
+	return nil
+}
+
+func (o *Onboarding) View() string {
+	var b strings.Builder
+	step := onboardingHintStyle.Render(fmt.Sprintf("(%d/%d)", o.step+1, onboardingSteps))
+	width := max(o.width-DocStyle.GetHorizontalFrameSize(), 20)
+	wrap := lipgloss.NewStyle().Width(min(width, 72))
+
+	switch o.step {
+	case onboardingWelcome:
+		b.WriteString(onboardingTitleStyle.Render("Welcome to stet") + " " + step + "\n\n")
+		b.WriteString(wrap.Render("stet keeps your daily habits and journal, along with your Oura readiness and Planta plant care, in one place. Move between pages with ← and →:"))
+		b.WriteString("\n\n")
+		for _, p := range [][2]string{
+			{"Today", "check off today's tasks"},
+			{"Journal", "write about your day"},
+			{"History", "see streaks and backfill days you missed"},
+			{"Configure", "add, edit and pause tasks"},
+			{"Oura, Planta", "readiness and plants due for care, once set up"},
+		} {
+			b.WriteString(fmt.Sprintf("  %-13s %s\n", p[0], onboardingHintStyle.Render(p[1])))
+		}
+		b.WriteString("\n")
+		b.WriteString(onboardingHintStyle.Render("Press ? on any page to see its keys."))
+
+	case onboardingTasks:
+		b.WriteString(onboardingTitleStyle.Render("Start with a few tasks?") + " " + step + "\n\n")
+		b.WriteString(wrap.Render("Tasks are habits you check off each day on the Today page. Keep the ones you want; you can change or delete them on the Configure page."))
+		b.WriteString("\n\n")
+		for i, title := range onboardingSamples {
+			checkbox := "□"
+			if o.chosen[i] {
+				checkbox = "■"
+			}
+			line := "  " + checkbox + " " + title
+			if i == o.cursor {
+				line = journalTaskCursorStyle.Render("> " + checkbox + " " + title)
+			}
+			b.WriteString(line + "\n")
+		}
+
+	case onboardingIntegrations:
+		b.WriteString(onboardingTitleStyle.Render("Connect Oura and Planta") + " " + step + "\n\n")
+		b.WriteString(wrap.Render("Both are optional. To connect them, add their credentials to the .env file next to the stet binary and restart:"))
+		b.WriteString("\n\n")
+		b.WriteString("  Oura    " + onboardingKeyStyle.Render("OURA_CLIENT_ID") + ", " + onboardingKeyStyle.Render("OURA_CLIENT_SECRET") + "\n")
+		b.WriteString("  Planta  " + onboardingKeyStyle.Render("PLANTA_APP_CODE") + "\n\n")
+		b.WriteString(wrap.Render("The Oura page then asks you to sign in; Planta connects on its own. .env.example lists every other setting."))
+	}
+	return b.String()
+}
+
+func (o *Onboarding) KeyMap() []key.Binding {
+	if o.step == onboardingTasks {
+		return []key.Binding{onboardingKeys.Nav, onboardingKeys.Toggle, onboardingKeys.Next, onboardingKeys.Skip}
+	}
+	return []key.Binding{onboardingKeys.Next, onboardingKeys.Skip}
+}
@@ -2,12 +2,15 @@ package pages
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"stet.codes/tui/clients"
+	"stet.codes/tui/config"
 
+	"github.com/NimbleMarkets/ntcharts/canvas"
 	"github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
@@ -21,12 +24,15 @@ const ouraPollInterval = 20 * time.Second
 type ouraTickMsg time.Time
 
 type OuraDataLoadedMsg struct {
+	fetchID   int
 	readiness *clients.DailyReadiness
+	previous  *clients.DailyReadiness // yesterday's, nil if missing
 	heartRate []clients.HeartRatePoint
 }
 
 type OuraDataFailedMsg struct {
-	err error
+	fetchID int
+	err     error
 }
 
 type ouraAuthCompleteMsg struct {
@@ -39,8 +45,11 @@ type ouraAuthFailedMsg struct {
 
 // ouraKeyMap defines key bindings for the Oura page.
 type ouraKeyMap struct {
-	Auth    key.Binding
-	Refresh key.Binding
+	Auth      key.Binding
+	Refresh   key.Binding
+	ExactTime key.Binding
+	Bars      key.Binding
+	Export    key.Binding
 }
 
 var ouraKeys = ouraKeyMap{
@@ -52,6 +61,48 @@ var ouraKeys = ouraKeyMap{
 		key.WithKeys("r"),
 		key.WithHelp("r", "refresh"),
 	),
+	ExactTime: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "exact time"),
+	),
+	Bars: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "bars/numbers"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "export csv"),
+	),
+}
+
+// ouraTimeLayouts lists the timestamp layouts accepted from the Oura API, most
+// common first. Layouts without a zone offset are interpreted in local time.
+var ouraTimeLayouts = []struct {
+	layout string
+	local  bool
+}{
+	{time.RFC3339Nano, false},
+	{time.RFC3339, false},
+	{"2006-01-02T15:04:05.999999999Z0700", false},  // offset without colon
+	{"2006-01-02 15:04:05.999999999Z07:00", false}, // space separator
+	{"2006-01-02T15:04:05.999999999", true},
+}
+
+// parseOuraTime parses an Oura API timestamp, trying each known layout in turn.
+func parseOuraTime(s string) (time.Time, error) {
+	for _, l := range ouraTimeLayouts {
+		var t time.Time
+		var err error
+		if l.local {
+			t, err = time.ParseInLocation(l.layout, s, time.Local)
+		} else {
+			t, err = time.Parse(l.layout, s)
+		}
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized Oura timestamp %q", s)
 }
 
 // hrHighlightStyle is the style for the vertical line on the chart at the selected time
@@ -61,6 +112,7 @@ var hrHighlightStyle = lipgloss.NewStyle().Background(lipgloss.Color("#444444"))
 type OuraPage struct {
 	client       *clients.OuraClient
 	readiness    *clients.DailyReadiness
+	previous     *clients.DailyReadiness // yesterday's, to show what changed
 	heartRate    []clients.HeartRatePoint
 	hrChart      timeserieslinechart.Model
 	hrTable      table.Model
@@ -74,15 +126,46 @@ type OuraPage struct {
 	authCancel   context.CancelFunc
 	width        int
 	height       int
+
+	relativeUpdated bool // show the fetch time as a live age
+	showExactTime   bool // show the clock time instead, toggled with t
+	timeLayout      string
+
+	timeouts config.ClientTimeouts
+	fetches  *fetcher
+
+	contributorBars bool // draw contributors as bars, toggled with b
+
+	exportDir string // where e exports to, see oura_export.go
+	notice    string // outcome of the last export, until the next key
+
+	chartHeight int // preferred heart rate chart height
+	chartStyle  config.ChartStyle
+
+	// Bookkeeping for incremental chart updates: how many heartRate samples
+	// the chart holds, the timestamp of the last one, the size it was built
+	// at and the column currently highlighted (zero if none).
+	chartPushed      int
+	chartLastSample  string
+	chartW, chartH   int
+	chartHighlighted time.Time
 }
 
 // NewOuraPage creates and initializes the Oura page.
-func NewOuraPage(client *clients.OuraClient) *OuraPage {
+func NewOuraPage(client *clients.OuraClient, cfg config.Config) *OuraPage {
 	needsAuth := !client.Auth().HasCredentials() || !client.IsAuthenticated()
 	return &OuraPage{
-		client:    client,
-		needsAuth: needsAuth,
-		loading:   !needsAuth,
+		client:          client,
+		needsAuth:       needsAuth,
+		loading:         !needsAuth,
+		relativeUpdated: cfg.LastUpdated == config.LastUpdatedRelative,
+		timeLayout:      cfg.Formats.Time,
+		timeouts:        cfg.OuraTimeouts,
+		contributorBars: cfg.Contributors == config.ContributorsBars,
+		exportDir:       config.ExportDir(),
+		fetches:         newFetcher(),
+		chartHeight:     cfg.HeartRateChartHeight,
+		chartStyle:      cfg.HeartRateChartStyle,
 	}
 }
 
@@ -119,7 +202,7 @@ func (p *OuraPage) InitCmd() tea.Cmd {
 		return nil // Don't start polling if auth is needed
 	}
 	return tea.Batch(
-		p.fetchDataCmd(),
+		p.fetchDataCmd(false),
 		ouraTickCmd(),
 	)
 }
@@ -132,29 +215,55 @@ func ouraTickCmd() tea.Cmd {
 }
 
 // fetchDataCmd returns a command that fetches readiness and heart rate data.
-func (p *OuraPage) fetchDataCmd() tea.Cmd {
+// When force is set, cached responses are discarded first and the shorter
+// interactive timeout applies.
+//
+// Starting a fetch cancels the previous one if it is still running.
+func (p *OuraPage) fetchDataCmd(force bool) tea.Cmd {
+	ctx, cancel, id := p.fetches.start(p.timeouts.For(force))
 	return func() tea.Msg {
-		readiness, err := p.client.GetTodayReadiness()
+		defer cancel()
+
+		if force {
+			p.client.ClearCache()
+		}
+
+		readiness, previous, err := p.client.GetRecentReadiness(ctx)
 		if err != nil {
-			return OuraDataFailedMsg{err: err}
+			return OuraDataFailedMsg{fetchID: id, err: err}
 		}
 
-		heartRate, err := p.client.GetTodayHeartRate()
+		heartRate, err := p.client.GetTodayHeartRate(ctx)
+		if ctx.Err() != nil {
+			// Cancelled, not failed; a partial result would blank the chart
+			return OuraDataFailedMsg{fetchID: id, err: ctx.Err()}
+		}
 		if err != nil {
 			// Don't fail completely if heart rate fails, just log it
 			heartRate = nil
 		}
 
-		return OuraDataLoadedMsg{readiness: readiness, heartRate: heartRate}
+		return OuraDataLoadedMsg{fetchID: id, readiness: readiness, previous: previous, heartRate: heartRate}
+	}
+}
+
+// RefreshCmd implements Refresher by refetching without the cache.
+func (p *OuraPage) RefreshCmd() tea.Cmd {
+	if p.needsAuth || p.authPending {
+		return nil
 	}
+	p.loading = true
+	return p.fetchDataCmd(true)
 }
 
 // startAuthCmd starts the OAuth2 flow.
+// The context is created here rather than in the command so that Shutdown
+// can cancel it without racing the command goroutine.
 func (p *OuraPage) startAuthCmd() tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-		p.authCancel = cancel
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	p.authCancel = cancel
 
+	return func() tea.Msg {
 		tokensChan, errChan := p.client.Auth().StartAuthFlow(ctx)
 
 		select {
@@ -172,6 +281,26 @@ func (p *OuraPage) startAuthCmd() tea.Cmd {
 	}
 }
 
+// Leave implements Leaver by cancelling a fetch still in flight; the next
+// poll fetches again.
+func (p *OuraPage) Leave() tea.Cmd {
+	if p.loading {
+		p.fetches.abort()
+		p.loading = false
+	}
+	return nil
+}
+
+// Shutdown implements Shutdowner by cancelling any in-progress auth flow,
+// which also stops its local callback server, and any request in flight.
+func (p *OuraPage) Shutdown() error {
+	if p.authCancel != nil {
+		p.authCancel()
+	}
+	p.fetches.shutdown()
+	return nil
+}
+
 func (p *OuraPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 	switch msg := msg.(type) {
 	case ouraTickMsg:
@@ -180,18 +309,22 @@ func (p *OuraPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		}
 		p.pollCount++
 		p.loading = true
-		return p, tea.Batch(p.fetchDataCmd(), ouraTickCmd())
+		return p, tea.Batch(p.fetchDataCmd(false), ouraTickCmd())
 
 	case OuraDataLoadedMsg:
+		if !p.fetches.current(msg.fetchID) {
+			return p, nil // superseded by a later fetch
+		}
 		p.readiness = msg.readiness
+		p.previous = msg.previous
 		p.heartRate = msg.heartRate
 		p.lastPoll = time.Now()
 		p.loading = false
 		p.err = nil
 
-		// Build the heart rate chart and table if we have data
+		// Update the heart rate chart and rebuild the table if we have data
 		if len(p.heartRate) > 0 {
-			p.buildHeartRateChart()
+			p.updateHeartRateChart()
 			p.buildHeartRateTable()
 			// Initialize highlight at the first row (most recent data point)
 			p.updateChartHighlight()
@@ -199,6 +332,9 @@ func (p *OuraPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		return p, nil
 
 	case OuraDataFailedMsg:
+		if !p.fetches.current(msg.fetchID) || errors.Is(msg.err, context.Canceled) {
+			return p, nil // superseded, or cancelled on leaving the page
+		}
 		p.err = msg.err
 		p.loading = false
 		// Check if it's an auth error
@@ -213,14 +349,23 @@ func (p *OuraPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		p.loading = true
 		p.err = nil
 		// Start fetching data now that we're authenticated
-		return p, tea.Batch(p.fetchDataCmd(), ouraTickCmd())
+		return p, tea.Batch(p.fetchDataCmd(true), ouraTickCmd())
 
 	case ouraAuthFailedMsg:
 		p.authPending = false
 		p.err = msg.err
 		return p, nil
 
+	case ouraExportedMsg:
+		p.notice = "Exported to " + msg.path
+		return p, nil
+
+	case ouraExportFailedMsg:
+		p.notice = fmt.Sprintf("Export failed: %v", msg.err)
+		return p, nil
+
 	case tea.KeyMsg:
+		p.notice = ""
 		switch {
 		case key.Matches(msg, ouraKeys.Auth):
 			if !p.client.Auth().HasCredentials() {
@@ -239,7 +384,21 @@ func (p *OuraPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 				return p, nil
 			}
 			p.loading = true
-			return p, p.fetchDataCmd()
+			return p, p.fetchDataCmd(true)
+
+		case key.Matches(msg, ouraKeys.Bars):
+			p.contributorBars = !p.contributorBars
+			return p, nil
+
+		case key.Matches(msg, ouraKeys.Export):
+			if p.needsAuth || p.authPending {
+				return p, nil
+			}
+			return p, p.exportCmd()
+
+		case key.Matches(msg, ouraKeys.ExactTime) && p.relativeUpdated:
+			p.showExactTime = !p.showExactTime
+			return p, nil
 		}
 
 		// Forward key events to the table for navigation
@@ -255,31 +414,170 @@ func (p *OuraPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 	return p, nil
 }
 
+// chartSize returns the heart rate chart dimensions for the current page size.
+func (p *OuraPage) chartSize() (width, height int) {
+	// Give up chart rows before the table drops below its minimum
+	available := p.height - ouraFixedHeight - DocStyle.GetVerticalFrameSize() - ouraMinTableRows
+	return max(p.width-DocStyle.GetHorizontalFrameSize()-4, 40),
+		max(min(p.chartHeight, available), ouraMinChartRows)
+}
+
+// ouraContributorLabelWidth fits the longest contributor name in bar mode.
+const ouraContributorLabelWidth = 17
+
+// contributorBandStyle colors a contributor score by Oura's bands: optimal
+// from 85, good from 70, pay attention below.
+func contributorBandStyle(score int) lipgloss.Style {
+	switch {
+	case score >= 85:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+	case score >= 70:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B"))
+	}
+}
+
+// ouraDeltaWidth is the width of a change since yesterday, e.g. " ▲12".
+const ouraDeltaWidth = 5
+
+// renderDelta shows the change from before to now as an arrow and the
+// difference, green for better and red for worse, padded to ouraDeltaWidth.
+// The score and every contributor are better higher.
+func renderDelta(before, now int) string {
+	d := now - before
+	switch {
+	case d > 0:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575")).Render(fmt.Sprintf(" ▲%-3d", d))
+	case d < 0:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render(fmt.Sprintf(" ▼%-3d", -d))
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#555555")).Render(" =   ")
+	}
+}
+
+// Oura page layout. ouraFixedHeight accounts for: title(2) + score(2) +
+// contributors header+grid(5) + hr chart header, summary and gap(3) +
+// "Recent Samples" header(1) + status(2) + padding; the chart itself comes
+// on top.
+const (
+	ouraFixedHeight  = 15
+	ouraMinChartRows = 3
+	ouraMinTableRows = 5
+)
+
+// Early in the day the heart rate data is sparse. Below ouraMinChartPoints
+// samples the chart is replaced by a note, and the chart always spans at
+// least ouraMinChartSpan so a few samples aren't stretched across it.
+const (
+	ouraMinChartPoints = 6
+	ouraMinChartSpan   = 2 * time.Hour
+)
+
+// heartRateStats returns the lowest, mean (rounded) and highest BPM of
+// points, or false if there are none.
+func heartRateStats(points []clients.HeartRatePoint) (lo, avg, hi int, ok bool) {
+	if len(points) == 0 {
+		return 0, 0, 0, false
+	}
+	lo, hi = points[0].BPM, points[0].BPM
+	sum := 0
+	for _, hr := range points {
+		lo = min(lo, hr.BPM)
+		hi = max(hi, hr.BPM)
+		sum += hr.BPM
+	}
+	n := len(points)
+	return lo, (sum + n/2) / n, hi, true
+}
+
+// tableHeight returns the number of rows available to the heart rate table.
+func (p *OuraPage) tableHeight() int {
+	_, chartHeight := p.chartSize()
+	fixedContentHeight := ouraFixedHeight + chartHeight + DocStyle.GetVerticalFrameSize()
+	return max(p.height-fixedContentHeight, ouraMinTableRows)
+}
+
 // buildHeartRateChart creates the heart rate chart from the data.
 func (p *OuraPage) buildHeartRateChart() {
-	chartWidth := max(p.width-DocStyle.GetHorizontalFrameSize()-4, 40)
-	chartHeight := 8
+	p.chartW, p.chartH = p.chartSize()
+	p.hrChart = timeserieslinechart.New(p.chartW, p.chartH)
+	// Start from the minimum span ending now; samples outside it widen it
+	end := time.Now()
+	start := end.Add(-ouraMinChartSpan)
+	p.hrChart.SetTimeRange(start, end)
+	p.hrChart.SetViewTimeRange(start, end)
+	p.chartPushed = 0
+	p.pushHeartRatePoints()
+	p.drawHeartRateChart()
+}
 
-	p.hrChart = timeserieslinechart.New(chartWidth, chartHeight)
+// updateHeartRateChart brings the chart up to date with p.heartRate. When the
+// new data only appends samples (the usual poll result) just those are
+// pushed; anything else, such as a new day or a resize, rebuilds the chart.
+func (p *OuraPage) updateHeartRateChart() {
+	w, h := p.chartSize()
+	n := p.chartPushed
+	if n == 0 || w != p.chartW || h != p.chartH ||
+		len(p.heartRate) < n || p.heartRate[n-1].Timestamp != p.chartLastSample {
+		p.buildHeartRateChart()
+		return
+	}
+	if len(p.heartRate) == n {
+		return // nothing new; keep the drawing and highlight as they are
+	}
+	p.pushHeartRatePoints()
+	p.drawHeartRateChart()
+}
 
-	// Add heart rate points to chart
-	for _, hr := range p.heartRate {
-		// Parse timestamp (ISO 8601 format)
-		t, err := time.Parse(time.RFC3339, hr.Timestamp)
+// pushHeartRatePoints adds the samples not yet in the chart.
+func (p *OuraPage) pushHeartRatePoints() {
+	for _, hr := range p.heartRate[p.chartPushed:] {
+		t, err := parseOuraTime(hr.Timestamp)
 		if err != nil {
+			logger.Warnf("oura: dropping heart rate point: %v", err)
 			continue
 		}
 		p.hrChart.Push(timeserieslinechart.TimePoint{Time: t, Value: float64(hr.BPM)})
 	}
+	p.chartPushed = len(p.heartRate)
+	if p.chartPushed > 0 {
+		p.chartLastSample = p.heartRate[p.chartPushed-1].Timestamp
+	}
+}
 
-	// Draw the chart using braille characters for higher resolution
-	p.hrChart.DrawBraille()
+// drawHeartRateChart redraws the chart canvas, which clears any highlight.
+func (p *OuraPage) drawHeartRateChart() {
+	p.chartHighlighted = time.Time{}
+	switch p.chartStyle {
+	case config.ChartStyleLines:
+		p.hrChart.Draw()
+	case config.ChartStylePoints:
+		p.drawHeartRatePoints()
+	default:
+		// Braille characters give the highest resolution
+		p.hrChart.DrawBraille()
+	}
+}
+
+// drawHeartRatePoints marks each sample with a dot. The chart already holds
+// the points, so this only redraws the axes and plots them.
+func (p *OuraPage) drawHeartRatePoints() {
+	p.hrChart.Clear()
+	p.hrChart.DrawXYAxisAndLabel()
+	for _, hr := range p.heartRate {
+		t, err := parseOuraTime(hr.Timestamp)
+		if err != nil {
+			continue
+		}
+		p.hrChart.DrawRune(canvas.Float64Point{X: float64(t.Unix()), Y: float64(hr.BPM)}, '•')
+	}
 }
 
 // buildHeartRateTable creates the heart rate table from the data.
 func (p *OuraPage) buildHeartRateTable() {
 	columns := []table.Column{
-		{Title: "Time", Width: 10},
+		{Title: "Time", Width: max(lipgloss.Width(time.Now().Format(p.timeLayout))+2, 10)},
 		{Title: "BPM", Width: 6},
 		{Title: "Source", Width: 10},
 	}
@@ -288,11 +586,13 @@ func (p *OuraPage) buildHeartRateTable() {
 	rows := make([]table.Row, 0, len(p.heartRate))
 	for i := len(p.heartRate) - 1; i >= 0; i-- {
 		hr := p.heartRate[i]
-		// Parse timestamp and format as HH:MM:SS in local time
-		t, err := time.Parse(time.RFC3339, hr.Timestamp)
+		// Parse timestamp and format as a local clock time
+		t, err := parseOuraTime(hr.Timestamp)
 		timeStr := hr.Timestamp
 		if err == nil {
-			timeStr = t.Local().Format("15:04:05")
+			timeStr = t.Local().Format(p.timeLayout)
+		} else {
+			logger.Warnf("oura: showing raw heart rate timestamp: %v", err)
 		}
 		rows = append(rows, table.Row{timeStr, fmt.Sprintf("%d", hr.BPM), hr.Source})
 	}
@@ -309,17 +609,11 @@ func (p *OuraPage) buildHeartRateTable() {
 		Background(lipgloss.Color("#8B5CF6")).
 		Bold(false)
 
-	// Calculate available height for the table
-	// Account for: title(2) + score(2) + contributors header+grid(5) +
-	// hr chart section(11) + "Recent Samples" header(1) + status(2) + padding
-	fixedContentHeight := 23 + DocStyle.GetVerticalFrameSize()
-	tableHeight := max(p.height-fixedContentHeight, 5) // minimum 5 rows
-
 	p.hrTable = table.New(
 		table.WithColumns(columns),
 		table.WithRows(rows),
 		table.WithFocused(true),
-		table.WithHeight(tableHeight),
+		table.WithHeight(p.tableHeight()),
 		table.WithStyles(s),
 	)
 }
@@ -341,16 +635,24 @@ func (p *OuraPage) updateChartHighlight() {
 	}
 
 	// Parse the timestamp of the selected point
-	t, err := time.Parse(time.RFC3339, p.heartRate[hrIndex].Timestamp)
+	t, err := parseOuraTime(p.heartRate[hrIndex].Timestamp)
 	if err != nil {
+		logger.Warnf("oura: cannot highlight heart rate point: %v", err)
 		return
 	}
 
 	p.selectedTime = t
 
-	// Rebuild the chart to clear previous highlight, then apply new one
-	p.buildHeartRateChart()
+	// Clear the previous highlight by resetting its column's background,
+	// rather than redrawing the whole chart
+	if t.Equal(p.chartHighlighted) {
+		return
+	}
+	if !p.chartHighlighted.IsZero() {
+		p.hrChart.SetColumnBackgroundStyle(p.chartHighlighted, lipgloss.NewStyle())
+	}
 	p.hrChart.SetColumnBackgroundStyle(t, hrHighlightStyle)
+	p.chartHighlighted = t
 }
 
 func (p *OuraPage) View() string {
@@ -426,6 +728,10 @@ func (p *OuraPage) View() string {
 		// Display score prominently
 		scoreLabel := fmt.Sprintf(" Readiness Score: %d ", p.readiness.Score)
 		b.WriteString(scoreStyle.Render(scoreLabel))
+		if p.previous != nil {
+			b.WriteString(renderDelta(p.previous.Score, p.readiness.Score))
+			b.WriteString(infoStyle.Render("since yesterday"))
+		}
 		b.WriteString("\n\n")
 
 		// Display contributors in a grid (these are contribution scores 0-100, not raw values)
@@ -433,22 +739,36 @@ func (p *OuraPage) View() string {
 		b.WriteString("\n")
 		contributorStyle := lipgloss.NewStyle().Width(contentWidth / 2)
 
+		now, before := p.readiness.Contributors, clients.Contributors{}
+		if p.previous != nil {
+			before = p.previous.Contributors
+		}
 		contributors := []struct {
-			name  string
-			value int
+			name          string
+			value, before int
 		}{
-			{"Activity Balance", p.readiness.Contributors.ActivityBalance},
-			{"Body Temp", p.readiness.Contributors.BodyTemperature},
-			{"HRV Balance", p.readiness.Contributors.HRVBalance},
-			{"Prev Day Activity", p.readiness.Contributors.PreviousDayActivity},
-			{"Previous Night", p.readiness.Contributors.PreviousNight},
-			{"Recovery Index", p.readiness.Contributors.RecoveryIndex},
-			{"Resting HR", p.readiness.Contributors.RestingHeartRate},
-			{"Sleep Balance", p.readiness.Contributors.SleepBalance},
+			{"Activity Balance", now.ActivityBalance, before.ActivityBalance},
+			{"Body Temp", now.BodyTemperature, before.BodyTemperature},
+			{"HRV Balance", now.HRVBalance, before.HRVBalance},
+			{"Prev Day Activity", now.PreviousDayActivity, before.PreviousDayActivity},
+			{"Previous Night", now.PreviousNight, before.PreviousNight},
+			{"Recovery Index", now.RecoveryIndex, before.RecoveryIndex},
+			{"Resting HR", now.RestingHeartRate, before.RestingHeartRate},
+			{"Sleep Balance", now.SleepBalance, before.SleepBalance},
 		}
 
 		for i, c := range contributors {
-			line := fmt.Sprintf("%-22s %3d", c.name, c.value)
+			// The change since yesterday, if there is a yesterday
+			delta, deltaWidth := "", 0
+			if p.previous != nil {
+				delta, deltaWidth = renderDelta(c.before, c.value), ouraDeltaWidth
+			}
+			line := fmt.Sprintf("%-22s %3d", c.name, c.value) + delta
+			if p.contributorBars {
+				barWidth := contentWidth/2 - ouraContributorLabelWidth - 6 - deltaWidth
+				line = fmt.Sprintf("%-*s %s", ouraContributorLabelWidth, c.name,
+					renderBarLabel(c.value, 100, barWidth, contributorBandStyle(c.value), fmt.Sprintf("%3d", c.value))) + delta
+			}
 			if i%2 == 0 {
 				b.WriteString(contributorStyle.Render(line))
 			} else {
@@ -462,23 +782,21 @@ func (p *OuraPage) View() string {
 		if len(p.heartRate) > 0 {
 			b.WriteString(infoStyle.Render("Heart Rate (BPM):"))
 			b.WriteString("\n")
-			b.WriteString(p.hrChart.View())
+			if len(p.heartRate) < ouraMinChartPoints {
+				// Same size as the chart, so the layout doesn't jump when it appears
+				note := fmt.Sprintf("Collecting data… %d of %d readings needed for the chart", len(p.heartRate), ouraMinChartPoints)
+				b.WriteString(lipgloss.Place(p.chartW, p.chartH, lipgloss.Center, lipgloss.Center, infoStyle.Render(note)))
+			} else {
+				b.WriteString(p.hrChart.View())
+			}
 			b.WriteString("\n")
 
-			// Show min/max/avg heart rate
-			var minHR, maxHR, sumHR int
-			minHR = 999
-			for _, hr := range p.heartRate {
-				if hr.BPM < minHR {
-					minHR = hr.BPM
-				}
-				if hr.BPM > maxHR {
-					maxHR = hr.BPM
-				}
-				sumHR += hr.BPM
+			minHR, avgHR, maxHR, _ := heartRateStats(p.heartRate)
+			readings := "readings"
+			if len(p.heartRate) == 1 {
+				readings = "reading"
 			}
-			avgHR := sumHR / len(p.heartRate)
-			b.WriteString(infoStyle.Render(fmt.Sprintf("Min: %d  Avg: %d  Max: %d  (%d readings)", minHR, avgHR, maxHR, len(p.heartRate))))
+			b.WriteString(infoStyle.Render(fmt.Sprintf("Min: %d  Avg: %d  Max: %d  (%d %s)", minHR, avgHR, maxHR, len(p.heartRate), readings)))
 			b.WriteString("\n\n")
 
 			// Display heart rate table
@@ -488,7 +806,11 @@ func (p *OuraPage) View() string {
 			b.WriteString("\n")
 		}
 	} else if p.err == nil {
-		b.WriteString("No readiness data available for today yet.\n")
+		// Leave room for the status line below
+		height := p.height - lipgloss.Height(b.String()) - 2
+		b.WriteString(emptyState(p.Title().Color, "◌", "No readiness data for today yet",
+			"It appears once your ring syncs after waking · r to refresh", contentWidth, height))
+		b.WriteString("\n")
 	}
 
 	// Error display
@@ -503,22 +825,38 @@ func (p *OuraPage) View() string {
 	statusParts := []string{}
 	statusParts = append(statusParts, fmt.Sprintf("Poll count: %d", p.pollCount))
 	if !p.lastPoll.IsZero() {
-		statusParts = append(statusParts, fmt.Sprintf("Last updated: %s", p.lastPoll.Format("15:04:05")))
+		statusParts = append(statusParts, formatUpdated("Last updated", p.lastPoll, p.relativeUpdated && !p.showExactTime, p.timeLayout))
 	}
 	if p.loading {
 		statusParts = append(statusParts, "Refreshing...")
 	}
+	if p.notice != "" {
+		statusParts = append(statusParts, p.notice)
+	}
 	b.WriteString(infoStyle.Render(strings.Join(statusParts, " | ")))
 
 	return b.String()
 }
 
+// DebugLayout implements LayoutDebugger.
+func (p *OuraPage) DebugLayout() []LayoutValue {
+	chartWidth, chartHeight := p.chartSize()
+	return []LayoutValue{
+		{"chartW", chartWidth},
+		{"chartH", chartHeight},
+		{"tableH", p.tableHeight()},
+	}
+}
+
 func (p *OuraPage) KeyMap() []key.Binding {
 	if p.needsAuth && p.client.Auth().HasCredentials() {
 		return []key.Binding{ouraKeys.Auth}
 	}
 	if !p.needsAuth && !p.authPending {
-		return []key.Binding{ouraKeys.Refresh}
+		if p.relativeUpdated {
+			return []key.Binding{ouraKeys.Refresh, ouraKeys.Bars, ouraKeys.Export, ouraKeys.ExactTime}
+		}
+		return []key.Binding{ouraKeys.Refresh, ouraKeys.Bars, ouraKeys.Export}
 	}
 	return []key.Binding{}
 }
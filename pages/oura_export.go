@@ -0,0 +1,88 @@
+package pages
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"stet.codes/tui/clients"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The Oura page exports what it shows to a CSV under config.ExportDir, one
+// file per day that a later export of the same day replaces: the readiness
+// score and contributors as key/value rows, a blank row, then the heart rate
+// samples.
+
+type ouraExportedMsg struct {
+	path string
+}
+
+type ouraExportFailedMsg struct {
+	err error
+}
+
+// ouraExportCmd writes readiness and heartRate for day ("YYYY-MM-DD") to
+// a CSV in dir. Either may be empty.
+func ouraExportCmd(dir, day string, readiness *clients.DailyReadiness, heartRate []clients.HeartRatePoint) tea.Cmd {
+	return func() tea.Msg {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if readiness != nil {
+			c := readiness.Contributors
+			rows := [][]string{
+				{"key", "value"},
+				{"day", readiness.Day},
+				{"score", strconv.Itoa(readiness.Score)},
+				{"temperature_deviation", strconv.FormatFloat(readiness.TemperatureDeviation, 'f', -1, 64)},
+				{"temperature_trend_deviation", strconv.FormatFloat(readiness.TemperatureTrendDeviation, 'f', -1, 64)},
+				{"activity_balance", strconv.Itoa(c.ActivityBalance)},
+				{"body_temperature", strconv.Itoa(c.BodyTemperature)},
+				{"hrv_balance", strconv.Itoa(c.HRVBalance)},
+				{"previous_day_activity", strconv.Itoa(c.PreviousDayActivity)},
+				{"previous_night", strconv.Itoa(c.PreviousNight)},
+				{"recovery_index", strconv.Itoa(c.RecoveryIndex)},
+				{"resting_heart_rate", strconv.Itoa(c.RestingHeartRate)},
+				{"sleep_balance", strconv.Itoa(c.SleepBalance)},
+				{},
+			}
+			for _, row := range rows {
+				w.Write(row)
+			}
+		}
+		w.Write([]string{"timestamp", "bpm", "source"})
+		for _, hr := range heartRate {
+			w.Write([]string{hr.Timestamp, strconv.Itoa(hr.BPM), hr.Source})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return ouraExportFailedMsg{err: err}
+		}
+
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return ouraExportFailedMsg{err: fmt.Errorf("failed to create exports directory: %w", err)}
+		}
+		path := filepath.Join(dir, "oura-"+day+".csv")
+		if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+			return ouraExportFailedMsg{err: err}
+		}
+		return ouraExportedMsg{path: path}
+	}
+}
+
+// exportCmd exports the data on screen, if there is any.
+func (p *OuraPage) exportCmd() tea.Cmd {
+	if p.readiness == nil && len(p.heartRate) == 0 {
+		p.notice = "Nothing to export yet"
+		return nil
+	}
+	day := todayKey()
+	if p.readiness != nil && p.readiness.Day != "" {
+		day = p.readiness.Day
+	}
+	return ouraExportCmd(p.exportDir, day, p.readiness, p.heartRate)
+}
@@ -0,0 +1,42 @@
+package pages
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseOuraTime(t *testing.T) {
+	est := time.FixedZone("", -5*60*60)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2025-01-02T08:30:00-05:00", time.Date(2025, 1, 2, 8, 30, 0, 0, est)},
+		{"2025-01-02T08:30:00+05:30", time.Date(2025, 1, 2, 8, 30, 0, 0, time.FixedZone("", 5*60*60+30*60))},
+		{"2025-01-02T13:30:00Z", time.Date(2025, 1, 2, 13, 30, 0, 0, time.UTC)},
+		{"2025-01-02T13:30:00.123Z", time.Date(2025, 1, 2, 13, 30, 0, 123e6, time.UTC)},
+		{"2025-01-02T08:30:00.123456-05:00", time.Date(2025, 1, 2, 8, 30, 0, 123456e3, est)},
+		{"2025-01-02T08:30:00-0500", time.Date(2025, 1, 2, 8, 30, 0, 0, est)},
+		{"2025-01-02 08:30:00-05:00", time.Date(2025, 1, 2, 8, 30, 0, 0, est)},
+		{"2025-01-02T08:30:00", time.Date(2025, 1, 2, 8, 30, 0, 0, time.Local)},
+		{"2025-01-02T08:30:00.5", time.Date(2025, 1, 2, 8, 30, 0, 5e8, time.Local)},
+	}
+	for _, tt := range tests {
+		got, err := parseOuraTime(tt.in)
+		if err != nil {
+			t.Errorf("parseOuraTime(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseOuraTime(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseOuraTimeInvalid(t *testing.T) {
+	for _, in := range []string{"", "yesterday", "2025-01-02", "08:30:00", "2025-13-02T08:30:00Z", "02/01/2025 08:30"} {
+		if got, err := parseOuraTime(in); err == nil {
+			t.Errorf("parseOuraTime(%q) = %v, want an error", in, got)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package pages
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	panelBorderStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("#555555"))
+
+	panelTitleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#888888"))
+
+	panelEmptyStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#555555")).
+			Italic(true)
+)
+
+// panel is a titled box with a rounded border. Width and height are the outer
+// size including the border; content that doesn't fit is truncated.
+type panel struct {
+	title   string
+	content string
+	empty   string // shown dimmed when content is blank
+	width   int
+	height  int
+}
+
+// panelChrome is the number of rows a panel uses besides its content: the
+// top and bottom border plus the title.
+const panelChrome = 3
+
+// View renders the panel. A panel always takes exactly height rows, so
+// callers can budget for it in their layout.
+func (pn panel) View() string {
+	innerWidth := max(pn.width-panelBorderStyle.GetHorizontalFrameSize(), 1)
+	lines := max(pn.height-panelChrome, 1)
+
+	var content string
+	if strings.TrimSpace(pn.content) == "" {
+		content = panelEmptyStyle.Render(ansi.Truncate(pn.empty, innerWidth, ellipsis))
+	} else {
+		content = truncateContent(pn.content, innerWidth, lines)
+	}
+
+	return panelBorderStyle.
+		Width(innerWidth).
+		Height(lines + 1).
+		Render(panelTitleStyle.Render(ansi.Truncate(pn.title, innerWidth, ellipsis)) + "\n" + content)
+}
+
+// truncateContent keeps at most maxLines lines of content, each cut to width
+// cells. If lines were dropped the last kept line becomes an ellipsis.
+func truncateContent(content string, width, maxLines int) string {
+	lines := strings.Split(content, "\n")
+	var result []string
+	for i, line := range lines {
+		if i >= maxLines {
+			break
+		}
+		result = append(result, ansi.Truncate(line, width, ellipsis))
+	}
+	if len(lines) > maxLines && len(result) > 0 {
+		result[len(result)-1] = ellipsis
+	}
+	return strings.Join(result, "\n")
+}
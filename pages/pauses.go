@@ -0,0 +1,132 @@
+package pages
+
+import (
+	"database/sql"
+	"time"
+)
+
+// taskPause is an inclusive range of days ("YYYY-MM-DD") during which a task
+// is paused: hidden from Today, drawn neutral in History and skipped over by
+// streaks rather than counted as missed.
+type taskPause struct {
+	start string
+	end   string
+}
+
+// covers reports whether date falls inside the pause.
+func (tp taskPause) covers(date string) bool {
+	return date >= tp.start && date <= tp.end
+}
+
+// pausedOn reports whether any of pauses covers date.
+func pausedOn(pauses []taskPause, date string) bool {
+	for _, tp := range pauses {
+		if tp.covers(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadTaskPauses returns, per task, the pauses overlapping [from, to]. Empty
+// bounds leave that side open.
+func loadTaskPauses(db *sql.DB, from, to string) (map[string][]taskPause, error) {
+	if from == "" {
+		from = "0000-01-01"
+	}
+	if to == "" {
+		to = "9999-12-31"
+	}
+	rows, err := db.Query(`
+		SELECT task_id, date(start_date), date(end_date)
+		FROM task_pauses
+		WHERE end_date >= ? AND start_date <= ?
+		ORDER BY task_id, start_date
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pauses := make(map[string][]taskPause)
+	for rows.Next() {
+		var taskID string
+		var tp taskPause
+		if err := rows.Scan(&taskID, &tp.start, &tp.end); err != nil {
+			return nil, err
+		}
+		pauses[taskID] = append(pauses[taskID], tp)
+	}
+	return pauses, rows.Err()
+}
+
+// pausedDays expands pauses into a set of days between from and to, for
+// lookups while rendering.
+func pausedDays(pauses []taskPause, from, to string) map[string]bool {
+	days := make(map[string]bool)
+	for _, tp := range pauses {
+		// UTC, where stepping by a day never lands on the same date twice
+		start, err := time.Parse("2006-01-02", max(tp.start, from))
+		if err != nil {
+			continue
+		}
+		last := min(tp.end, to)
+		for d := start; d.Format("2006-01-02") <= last; d = d.AddDate(0, 0, 1) {
+			days[d.Format("2006-01-02")] = true
+		}
+	}
+	return days
+}
+
+// setTaskPause pauses a task from today through until, extending or
+// shortening a pause already in effect. An empty until resumes the task:
+// a pause in effect ends yesterday, keeping the days already paused.
+func setTaskPause(db *sql.DB, taskID, until string) error {
+	now := homeNow()
+	today := dateKey(now)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Pauses that haven't started yet are replaced either way
+	if _, err := tx.Exec(`
+		DELETE FROM task_pauses
+		WHERE task_id = ? AND start_date > ?
+	`, taskID, today); err != nil {
+		return err
+	}
+
+	var id, start string
+	err = tx.QueryRow(`
+		SELECT id, date(start_date) FROM task_pauses
+		WHERE task_id = ? AND ? BETWEEN start_date AND end_date
+	`, taskID, today).Scan(&id, &start)
+	switch {
+	case err == sql.ErrNoRows:
+		if until != "" {
+			_, err = tx.Exec(`
+				INSERT INTO task_pauses (id, task_id, start_date, end_date)
+				VALUES (lower(hex(randomblob(16))), ?, ?, ?)
+			`, taskID, today, until)
+		} else {
+			err = nil
+		}
+	case err != nil:
+		return err
+	case until != "":
+		_, err = tx.Exec(`UPDATE task_pauses SET end_date = ? WHERE id = ?`, until, id)
+	case start == today:
+		_, err = tx.Exec(`DELETE FROM task_pauses WHERE id = ?`, id)
+	default:
+		_, err = tx.Exec(`
+			UPDATE task_pauses SET end_date = ? WHERE id = ?
+		`, dateKey(addDays(now, -1)), id)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
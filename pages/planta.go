@@ -1,28 +1,57 @@
 package pages
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"stet.codes/tui/clients"
+	"stet.codes/tui/config"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 const plantaPollInterval = 4 * time.Hour
 
+// After a failed fetch the page retries sooner than the next poll, starting
+// at plantaRetryInterval and doubling with each further failure up to
+// plantaPollInterval.
+const plantaRetryInterval = 2 * time.Minute
+
+// plantaRetryDelay returns how long to wait after failures consecutive
+// failed fetches.
+func plantaRetryDelay(failures int) time.Duration {
+	delay := plantaRetryInterval
+	for i := 1; i < failures && delay < plantaPollInterval; i++ {
+		delay *= 2
+	}
+	return min(delay, plantaPollInterval)
+}
+
 // Planta page message types
-type plantaTickMsg time.Time
+
+// plantaTickMsg asks for a poll. Only the tick from the latest schedule
+// counts, so rescheduling after a failure or recovery replaces the pending
+// one.
+type plantaTickMsg struct {
+	version int
+}
 
 type PlantaDataLoadedMsg struct {
-	tasks []clients.PlantTask
+	fetchID int
+	tasks   []clients.PlantTask
 }
 
 type PlantaDataFailedMsg struct {
-	err error
+	fetchID int
+	err     error
 }
 
 type plantaCompleteSuccessMsg struct {
@@ -36,10 +65,15 @@ type plantaCompleteFailedMsg struct {
 
 // plantaKeyMap defines key bindings for the Planta page.
 type plantaKeyMap struct {
-	Up       key.Binding
-	Down     key.Binding
-	Complete key.Binding
-	Refresh  key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Complete  key.Binding
+	Refresh   key.Binding
+	ExactTime key.Binding
+	Filter    key.Binding
+	// While typing a filter
+	ApplyFilter key.Binding
+	ClearFilter key.Binding
 }
 
 var plantaKeys = plantaKeyMap{
@@ -59,13 +93,30 @@ var plantaKeys = plantaKeyMap{
 		key.WithKeys("r"),
 		key.WithHelp("r", "refresh"),
 	),
+	ExactTime: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "exact time"),
+	),
+	Filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter"),
+	),
+	ApplyFilter: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "apply filter"),
+	),
+	ClearFilter: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "clear filter"),
+	),
 }
 
 // PlantaPage displays plant care tasks from Planta.
 type PlantaPage struct {
 	client     *clients.PlantaClient
+	db         *sql.DB // for the local care log
 	tasks      []clients.PlantTask
-	cursor     int
+	cursor     int // into visibleTasks
 	pollCount  int
 	lastPoll   time.Time
 	err        error
@@ -74,16 +125,110 @@ type PlantaPage struct {
 	needsAuth  bool
 	width      int
 	height     int
+
+	// Consecutive failed fetches, and when the next retry is due while
+	// failing. tickVersion identifies the pending poll (see plantaTickMsg).
+	failures    int
+	nextRetry   time.Time
+	tickVersion int
+
+	// Plant name filter; the tasks shown are narrowed to matching plants
+	// while it has text. filtering is set while it is being typed.
+	filterInput textinput.Model
+	filtering   bool
+
+	relativeUpdated bool // show the fetch time as a live age
+	showExactTime   bool // show the clock time instead, toggled with t
+	formats         config.DateFormats
+	timeouts        config.ClientTimeouts
+	fetches         *fetcher
 }
 
 // NewPlantaPage creates and initializes the Planta page.
-func NewPlantaPage(client *clients.PlantaClient) *PlantaPage {
+func NewPlantaPage(client *clients.PlantaClient, db *sql.DB, cfg config.Config) *PlantaPage {
 	needsAuth := !client.Auth().HasCredentials()
+
+	fi := textinput.New()
+	fi.Prompt = "/ "
+	fi.Placeholder = "Plant name"
+	fi.CharLimit = 50
+
 	return &PlantaPage{
-		client:    client,
-		needsAuth: needsAuth,
-		loading:   !needsAuth,
+		client:          client,
+		db:              db,
+		needsAuth:       needsAuth,
+		loading:         !needsAuth,
+		relativeUpdated: cfg.LastUpdated == config.LastUpdatedRelative,
+		formats:         cfg.Formats,
+		timeouts:        cfg.PlantaTimeouts,
+		fetches:         newFetcher(),
+		filterInput:     fi,
+	}
+}
+
+// CapturesNavigation returns true while a filter is being typed.
+func (p *PlantaPage) CapturesNavigation() bool {
+	return p.filtering
+}
+
+// CapturesGlobalKeys returns true while a filter is being typed, so letters
+// go into it.
+func (p *PlantaPage) CapturesGlobalKeys() bool {
+	return p.filtering
+}
+
+// visibleTasks returns the tasks for plants whose name contains the filter,
+// ignoring case, or all of them without a filter.
+func (p *PlantaPage) visibleTasks() []clients.PlantTask {
+	query := strings.ToLower(strings.TrimSpace(p.filterInput.Value()))
+	if query == "" {
+		return p.tasks
+	}
+	var tasks []clients.PlantTask
+	for _, t := range p.tasks {
+		if strings.Contains(strings.ToLower(t.PlantName), query) {
+			tasks = append(tasks, t)
+		}
 	}
+	return tasks
+}
+
+// filtered reports whether a filter narrows the tasks shown.
+func (p *PlantaPage) filtered() bool {
+	return strings.TrimSpace(p.filterInput.Value()) != ""
+}
+
+// clampCursor keeps the cursor on a visible task.
+func (p *PlantaPage) clampCursor() {
+	p.cursor = max(min(p.cursor, len(p.visibleTasks())-1), 0)
+}
+
+// clearFilter stops filtering and shows every task again.
+func (p *PlantaPage) clearFilter() {
+	p.filtering = false
+	p.filterInput.Blur()
+	p.filterInput.Reset()
+	p.clampCursor()
+}
+
+// updateFilter handles keys while the filter is being typed.
+func (p *PlantaPage) updateFilter(msg tea.KeyMsg) (Page, tea.Cmd) {
+	switch {
+	case key.Matches(msg, plantaKeys.ClearFilter):
+		p.clearFilter()
+		return p, nil
+	case key.Matches(msg, plantaKeys.ApplyFilter):
+		p.filtering = false
+		p.filterInput.Blur()
+		if !p.filtered() {
+			p.filterInput.Reset()
+		}
+		return p, nil
+	}
+	var cmd tea.Cmd
+	p.filterInput, cmd = p.filterInput.Update(msg)
+	p.clampCursor()
+	return p, cmd
 }
 
 func (p *PlantaPage) ID() PageID {
@@ -112,42 +257,77 @@ func (p *PlantaPage) InitCmd() tea.Cmd {
 		return nil
 	}
 	return tea.Batch(
-		p.fetchDataCmd(),
-		plantaTickCmd(),
+		p.fetchDataCmd(false),
+		p.scheduleTick(),
 	)
 }
 
-// plantaTickCmd returns a command that sends a tick message after the poll interval.
-func plantaTickCmd() tea.Cmd {
-	return tea.Tick(plantaPollInterval, func(t time.Time) tea.Msg {
-		return plantaTickMsg(t)
+// scheduleTick schedules the next poll, replacing any pending one: after
+// the poll interval normally, or sooner while fetches are failing.
+func (p *PlantaPage) scheduleTick() tea.Cmd {
+	delay := plantaPollInterval
+	if p.failures > 0 {
+		delay = plantaRetryDelay(p.failures)
+		p.nextRetry = time.Now().Add(delay)
+	}
+	p.tickVersion++
+	version := p.tickVersion
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return plantaTickMsg{version: version}
 	})
 }
 
 // fetchDataCmd returns a command that fetches plant tasks.
-func (p *PlantaPage) fetchDataCmd() tea.Cmd {
+// When force is set, cached responses are discarded first and the shorter
+// interactive timeout applies.
+//
+// Starting a fetch cancels the previous one if it is still running.
+func (p *PlantaPage) fetchDataCmd(force bool) tea.Cmd {
+	ctx, cancel, id := p.fetches.start(p.timeouts.For(force))
 	return func() tea.Msg {
+		defer cancel()
+
+		if force {
+			p.client.ClearCache()
+		}
+
 		// Ensure authenticated (exchanges code if needed)
-		if err := p.client.EnsureAuthenticated(); err != nil {
-			return PlantaDataFailedMsg{err: err}
+		if err := p.client.EnsureAuthenticated(ctx); err != nil {
+			return PlantaDataFailedMsg{fetchID: id, err: err}
 		}
 
-		tasks, err := p.client.GetDueTasks(3) // Today + next 3 days
+		tasks, err := p.client.GetDueTasks(ctx, 3) // Today + next 3 days
 		if err != nil {
-			return PlantaDataFailedMsg{err: err}
+			return PlantaDataFailedMsg{fetchID: id, err: err}
 		}
 
-		return PlantaDataLoadedMsg{tasks: tasks}
+		return PlantaDataLoadedMsg{fetchID: id, tasks: tasks}
+	}
+}
+
+// RefreshCmd implements Refresher by refetching without the cache.
+func (p *PlantaPage) RefreshCmd() tea.Cmd {
+	if p.needsAuth || p.completing {
+		return nil
 	}
+	p.loading = true
+	return p.fetchDataCmd(true)
 }
 
 // completeTaskCmd returns a command that completes a task.
 func (p *PlantaPage) completeTaskCmd(task clients.PlantTask) tea.Cmd {
+	ctx, cancel := p.fetches.request(p.timeouts.Interactive)
 	return func() tea.Msg {
-		err := p.client.CompleteAction(task.PlantID, task.ActionType)
+		defer cancel()
+
+		err := p.client.CompleteAction(ctx, task.PlantID, task.ActionType)
 		if err != nil {
 			return plantaCompleteFailedMsg{err: err}
 		}
+		// Done in Planta either way; the log only feeds the activity feed
+		if err := logPlantCare(p.db, task, homeNow()); err != nil {
+			logger.Warnf("planta: logging care for %s: %v", task.PlantName, err)
+		}
 		return plantaCompleteSuccessMsg{
 			plantID:    task.PlantID,
 			actionType: task.ActionType,
@@ -155,34 +335,75 @@ func (p *PlantaPage) completeTaskCmd(task clients.PlantTask) tea.Cmd {
 	}
 }
 
+// logPlantCare records a completed care action in plant_care_log.
+func logPlantCare(db *sql.DB, task clients.PlantTask, at time.Time) error {
+	_, err := db.Exec(`
+		INSERT INTO plant_care_log (id, plant_id, plant_name, action_type, completed_at)
+		VALUES (lower(hex(randomblob(16))), ?, ?, ?, ?)
+	`, task.PlantID, task.PlantName, string(task.ActionType), completedAtKey(at))
+	return err
+}
+
+// Leave implements Leaver by cancelling a fetch still in flight; the next
+// poll fetches again. Completing a task is left to finish.
+func (p *PlantaPage) Leave() tea.Cmd {
+	if p.loading {
+		p.fetches.abort()
+		p.loading = false
+	}
+	return nil
+}
+
+// Shutdown implements Shutdowner by cancelling any request in flight.
+func (p *PlantaPage) Shutdown() error {
+	p.fetches.shutdown()
+	return nil
+}
+
 func (p *PlantaPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 	switch msg := msg.(type) {
 	case plantaTickMsg:
+		if msg.version != p.tickVersion {
+			return p, nil // replaced by a later schedule
+		}
 		if p.needsAuth || p.completing {
-			return p, plantaTickCmd()
+			return p, p.scheduleTick()
 		}
 		p.pollCount++
 		p.loading = true
-		return p, tea.Batch(p.fetchDataCmd(), plantaTickCmd())
+		return p, tea.Batch(p.fetchDataCmd(false), p.scheduleTick())
 
 	case PlantaDataLoadedMsg:
+		if !p.fetches.current(msg.fetchID) {
+			return p, nil // superseded by a later fetch
+		}
 		p.tasks = msg.tasks
 		p.lastPoll = time.Now()
 		p.loading = false
 		p.err = nil
 		// Clamp cursor to valid range
-		if p.cursor >= len(p.tasks) {
-			p.cursor = max(len(p.tasks)-1, 0)
+		p.clampCursor()
+		if p.failures > 0 {
+			// Recovered; back to the usual poll interval
+			p.failures = 0
+			p.nextRetry = time.Time{}
+			return p, p.scheduleTick()
 		}
 		return p, nil
 
 	case PlantaDataFailedMsg:
+		if !p.fetches.current(msg.fetchID) || errors.Is(msg.err, context.Canceled) {
+			return p, nil // superseded, or cancelled on leaving the page
+		}
 		p.err = msg.err
 		p.loading = false
 		if strings.Contains(msg.err.Error(), "missing PLANTA_APP_CODE") {
 			p.needsAuth = true
+			return p, nil
 		}
-		return p, nil
+		// Retry sooner than the next poll, backing off while it keeps failing
+		p.failures++
+		return p, p.scheduleTick()
 
 	case plantaCompleteSuccessMsg:
 		p.completing = false
@@ -194,9 +415,7 @@ func (p *PlantaPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 			}
 		}
 		// Clamp cursor
-		if p.cursor >= len(p.tasks) {
-			p.cursor = max(len(p.tasks)-1, 0)
-		}
+		p.clampCursor()
 		return p, nil
 
 	case plantaCompleteFailedMsg:
@@ -205,7 +424,18 @@ func (p *PlantaPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		return p, nil
 
 	case tea.KeyMsg:
+		if p.filtering {
+			return p.updateFilter(msg)
+		}
 		switch {
+		case key.Matches(msg, plantaKeys.Filter) && !p.needsAuth:
+			p.filtering = true
+			return p, p.filterInput.Focus()
+
+		case key.Matches(msg, plantaKeys.ClearFilter) && p.filtered():
+			p.clearFilter()
+			return p, nil
+
 		case key.Matches(msg, plantaKeys.Up):
 			if p.cursor > 0 {
 				p.cursor--
@@ -213,16 +443,17 @@ func (p *PlantaPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 			return p, nil
 
 		case key.Matches(msg, plantaKeys.Down):
-			if p.cursor < len(p.tasks)-1 {
+			if p.cursor < len(p.visibleTasks())-1 {
 				p.cursor++
 			}
 			return p, nil
 
 		case key.Matches(msg, plantaKeys.Complete):
-			if len(p.tasks) == 0 || p.completing || p.needsAuth {
+			visible := p.visibleTasks()
+			if len(visible) == 0 || p.completing || p.needsAuth {
 				return p, nil
 			}
-			task := p.tasks[p.cursor]
+			task := visible[p.cursor]
 			if !task.Completable {
 				p.err = fmt.Errorf("%s cannot be completed via API", task.ActionType)
 				return p, nil
@@ -236,7 +467,11 @@ func (p *PlantaPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 				return p, nil
 			}
 			p.loading = true
-			return p, p.fetchDataCmd()
+			return p, p.fetchDataCmd(true)
+
+		case key.Matches(msg, plantaKeys.ExactTime) && p.relativeUpdated:
+			p.showExactTime = !p.showExactTime
+			return p, nil
 		}
 	}
 
@@ -296,13 +531,31 @@ func (p *PlantaPage) View() string {
 		return lipgloss.NewStyle().Height(p.height).Render(b.String())
 	}
 
+	// Filter, while typed or applied
+	visible := p.visibleTasks()
+	if p.filtering {
+		b.WriteString(p.filterInput.View())
+		b.WriteString("\n\n")
+	} else if p.filtered() {
+		b.WriteString(infoStyle.Render(fmt.Sprintf("Plants matching \"%s\" · esc to clear",
+			strings.TrimSpace(p.filterInput.Value()))))
+		b.WriteString("\n\n")
+	}
+
 	// No tasks
 	if len(p.tasks) == 0 {
-		b.WriteString(infoStyle.Render("No tasks due in the next 3 days."))
+		// Leave room for the status line below
+		width := max(p.width-DocStyle.GetHorizontalFrameSize(), 40)
+		height := p.height - lipgloss.Height(b.String()) - 2
+		b.WriteString(emptyState(p.Title().Color, "✿", "No tasks due in the next 3 days",
+			"Your plants are looked after · r to refresh", width, height))
+		b.WriteString("\n")
+	} else if len(visible) == 0 {
+		b.WriteString(infoStyle.Render("No plants match the filter."))
 		b.WriteString("\n")
 	} else {
 		// Render task list
-		for i, task := range p.tasks {
+		for i, task := range visible {
 			// Icon for action type
 			var icon string
 			switch task.ActionType {
@@ -321,11 +574,11 @@ func (p *PlantaPage) View() string {
 			}
 
 			// Date display
-			dateStr := task.DueDate.Format("Mon Jan 2")
+			dateStr := task.DueDate.Format(p.formats.DateShort)
 			if task.IsToday {
 				dateStr = "Today"
 			} else if task.IsOverdue {
-				dateStr = task.DueDate.Format("Jan 2") + " (overdue)"
+				dateStr = task.DueDate.Format(p.formats.DateDay) + " (overdue)"
 			}
 
 			// Truncate plant name if too long
@@ -386,12 +639,19 @@ func (p *PlantaPage) View() string {
 	// Status line
 	b.WriteString("\n")
 	statusParts := []string{}
-	statusParts = append(statusParts, fmt.Sprintf("Tasks: %d", len(p.tasks)))
+	if p.filtered() {
+		statusParts = append(statusParts, fmt.Sprintf("Tasks: %d of %d", len(visible), len(p.tasks)))
+	} else {
+		statusParts = append(statusParts, fmt.Sprintf("Tasks: %d", len(p.tasks)))
+	}
 	if !p.lastPoll.IsZero() {
-		statusParts = append(statusParts, fmt.Sprintf("Updated: %s", p.lastPoll.Format("15:04:05")))
+		statusParts = append(statusParts, formatUpdated("Updated", p.lastPoll, p.relativeUpdated && !p.showExactTime, p.formats.Time))
 	}
 	if p.loading {
 		statusParts = append(statusParts, "Refreshing...")
+	} else if p.failures > 0 {
+		statusParts = append(statusParts, fmt.Sprintf("Failed %d× · retrying at %s",
+			p.failures, p.nextRetry.Format(p.formats.Time)))
 	}
 	b.WriteString(infoStyle.Render(strings.Join(statusParts, " | ")))
 
@@ -399,14 +659,50 @@ func (p *PlantaPage) View() string {
 	return lipgloss.NewStyle().Height(p.height).Render(b.String())
 }
 
+// DebugLayout implements LayoutDebugger.
+func (p *PlantaPage) DebugLayout() []LayoutValue {
+	return []LayoutValue{
+		{"h", p.height},
+	}
+}
+
+// Badge implements Badger with the number of plant tasks due today or
+// overdue.
+func (p *PlantaPage) Badge() string {
+	if p.needsAuth || p.err != nil {
+		return ""
+	}
+	n := 0
+	for _, task := range p.tasks {
+		if task.IsToday || task.IsOverdue {
+			n++
+		}
+	}
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
 func (p *PlantaPage) KeyMap() []key.Binding {
 	if p.needsAuth {
 		return []key.Binding{}
 	}
-	return []key.Binding{
+	if p.filtering {
+		return []key.Binding{plantaKeys.ApplyFilter, plantaKeys.ClearFilter}
+	}
+	keys := []key.Binding{
 		plantaKeys.Up,
 		plantaKeys.Down,
 		plantaKeys.Complete,
 		plantaKeys.Refresh,
+		plantaKeys.Filter,
+	}
+	if p.filtered() {
+		keys = append(keys, plantaKeys.ClearFilter)
+	}
+	if p.relativeUpdated {
+		keys = append(keys, plantaKeys.ExactTime)
 	}
+	return keys
 }
@@ -0,0 +1,141 @@
+package pages
+
+import (
+	"database/sql"
+	"time"
+)
+
+// loadTaskStreaks returns the current streak for every task with one: the
+// number of consecutive days with a completion, ending today or yesterday.
+// A streak ending yesterday is still alive, but at risk until the task is
+// completed today. Paused days are bridged: they neither break a streak nor
+// add to it.
+func loadTaskStreaks(db *sql.DB) (map[string]int, error) {
+	now := homeNow()
+	today := dateKey(now)
+
+	pauses, err := loadTaskPauses(db, "", today)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(`
+		SELECT task_id, date(completed_date)
+		FROM task_completions
+		WHERE completed_date <= ?
+		ORDER BY task_id, completed_date DESC
+	`, today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	todayStart := startOfDay(now)
+
+	streaks := make(map[string]int)
+	var (
+		curTask  string
+		expected time.Time // next day that would extend the current task's run
+		broken   bool
+	)
+	// activeOnOrBefore steps back from d past any paused days.
+	activeOnOrBefore := func(taskID string, d time.Time) time.Time {
+		for pausedOn(pauses[taskID], d.Format("2006-01-02")) {
+			d = addDays(d, -1)
+		}
+		return d
+	}
+	for rows.Next() {
+		var taskID, date string
+		if err := rows.Scan(&taskID, &date); err != nil {
+			return nil, err
+		}
+
+		if taskID != curTask {
+			curTask = taskID
+			broken = false
+			// The run may end today or, if today isn't done yet, on the
+			// active day before it
+			expected = activeOnOrBefore(taskID, todayStart)
+			if date != expected.Format("2006-01-02") {
+				expected = activeOnOrBefore(taskID, addDays(expected, -1))
+			}
+		}
+		if broken {
+			continue
+		}
+
+		want := expected.Format("2006-01-02")
+		switch {
+		case date > want:
+			continue // completed on a paused day; neutral
+		case date < want:
+			broken = true
+			continue
+		}
+
+		streaks[taskID]++
+		expected = activeOnOrBefore(taskID, addDays(expected, -1))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return streaks, nil
+}
+
+// loadTaskRecord returns how many times a task has been completed and its
+// longest streak ever. Paused days are bridged as in loadTaskStreaks.
+func loadTaskRecord(db *sql.DB, taskID string) (completions, longest int, err error) {
+	pauses, err := loadTaskPauses(db, "", "")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rows, err := db.Query(`
+		SELECT date(completed_date)
+		FROM task_completions
+		WHERE task_id = ? AND completed_date <= ?
+		ORDER BY completed_date
+	`, taskID, todayKey())
+	if err != nil {
+		return 0, 0, err
+	}
+	defer rows.Close()
+
+	var (
+		run      int
+		expected string // next active day after the current run
+	)
+	for rows.Next() {
+		var date string
+		if err := rows.Scan(&date); err != nil {
+			return 0, 0, err
+		}
+		completions++
+
+		switch {
+		case expected == "" || date > expected:
+			run = 1
+		case date < expected:
+			continue // completed on a paused day; neutral
+		default:
+			run++
+		}
+		longest = max(longest, run)
+
+		// Dates are stepped in UTC, where days are always 24 hours long
+		d, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return 0, 0, err
+		}
+		d = d.AddDate(0, 0, 1)
+		for pausedOn(pauses[taskID], d.Format("2006-01-02")) {
+			d = d.AddDate(0, 0, 1)
+		}
+		expected = d.Format("2006-01-02")
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+	return completions, longest, nil
+}
@@ -0,0 +1,38 @@
+package pages
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// TaskSummary is a plain snapshot of one of today's active tasks, for use
+// outside the interactive app (e.g. the summary command).
+type TaskSummary struct {
+	Title     string
+	Completed bool
+	Streak    int // current streak; see loadTaskStreaks
+}
+
+// LoadTaskSummary loads today's active tasks with completion state and streaks,
+// using the same queries as the Today page.
+func LoadTaskSummary(db *sql.DB) ([]TaskSummary, error) {
+	var tasks []Task
+	switch msg := loadTodayDataCmd(db)().(type) {
+	case activeTasksLoadedMsg:
+		tasks = msg.tasks
+	case activeTasksLoadFailedMsg:
+		return nil, msg.err
+	default:
+		return nil, fmt.Errorf("unexpected message %T", msg)
+	}
+
+	summary := make([]TaskSummary, len(tasks))
+	for i, t := range tasks {
+		summary[i] = TaskSummary{
+			Title:     t.title,
+			Completed: t.completed,
+			Streak:    t.streak,
+		}
+	}
+	return summary, nil
+}
@@ -4,7 +4,12 @@ import (
 	"database/sql"
 	"fmt"
 	"io"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
+
+	"stet.codes/tui/config"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -24,6 +29,12 @@ type TaskDefinition struct {
 	title       string
 	description string
 	active      bool
+	inbox       bool   // captured for later triage; inactive until then
+	promptNote  bool   // ask for a note when completed on the Today page
+	url         string // link opened from the Today page, or ""
+	pausedUntil string // last day of the pause in effect, "YYYY-MM-DD", or ""
+	created     string // day created in the home zone, "YYYY-MM-DD", or ""
+	target      int    // times a day to be done; see Task.counter
 }
 
 func (t TaskDefinition) FilterValue() string { return t.title }
@@ -54,6 +65,30 @@ type taskAddFailedMsg struct {
 	err error
 }
 
+// taskTriagedMsg indicates an inbox task was scheduled.
+type taskTriagedMsg struct {
+	taskID string
+}
+
+// taskTriageFailedMsg indicates scheduling an inbox task failed.
+type taskTriageFailedMsg struct {
+	taskID string
+	err    error
+}
+
+// taskRecordLoadedMsg carries a task's history ahead of deactivating it.
+type taskRecordLoadedMsg struct {
+	taskID      string
+	completions int
+	longest     int
+}
+
+// taskRecordLoadFailedMsg indicates loading a task's history failed.
+type taskRecordLoadFailedMsg struct {
+	taskID string
+	err    error
+}
+
 // taskActiveToggledMsg indicates the active status was toggled.
 type taskActiveToggledMsg struct {
 	taskID string
@@ -67,12 +102,27 @@ type taskActiveToggleFailedMsg struct {
 	err    error
 }
 
-// taskDeletedMsg indicates a task was soft-deleted.
+// taskNotePromptToggledMsg indicates the completion-note prompt flag was toggled.
+type taskNotePromptToggledMsg struct {
+	taskID     string
+	promptNote bool
+}
+
+// taskNotePromptToggleFailedMsg indicates toggling the note prompt flag failed.
+type taskNotePromptToggleFailedMsg struct {
+	taskID     string
+	promptNote bool
+	err        error
+}
+
+// taskDeletedMsg indicates a task was deleted; purged is set if it was
+// hard-deleted along with its history.
 type taskDeletedMsg struct {
 	taskID string
+	purged bool
 }
 
-// taskDeleteFailedMsg indicates soft-delete failed.
+// taskDeleteFailedMsg indicates deleting a task failed.
 type taskDeleteFailedMsg struct {
 	taskID string
 	err    error
@@ -89,22 +139,56 @@ type taskEditFailedMsg struct {
 	err    error
 }
 
+// taskPauseSetMsg indicates a task's pause was set or cleared.
+type taskPauseSetMsg struct {
+	taskID string
+	until  string // "" when resumed
+}
+
+// taskPauseSetFailedMsg indicates setting a task's pause failed.
+type taskPauseSetFailedMsg struct {
+	taskID string
+	err    error
+}
+
+// taskTargetSetMsg indicates a task's daily target was set.
+type taskTargetSetMsg struct {
+	taskID string
+	target int
+}
+
+// taskTargetSetFailedMsg indicates setting a task's daily target failed.
+type taskTargetSetFailedMsg struct {
+	taskID string
+	err    error
+}
+
 // InvalidateTodayPageMsg signals AppModel to reset Today page's initialized state.
 type InvalidateTodayPageMsg struct{}
 
+// InvalidateHistoryPageMsg signals AppModel to reset History page's initialized state.
+type InvalidateHistoryPageMsg struct{}
+
 /**
  * Database commands
  */
 
-// loadTaskDefinitionsCmd queries all non-deleted task definitions.
+// loadTaskDefinitionsCmd queries all non-deleted task definitions, inbox
+// tasks first.
 func loadTaskDefinitionsCmd(db *sql.DB) tea.Cmd {
 	return func() tea.Msg {
 		rows, err := db.Query(`
-			SELECT id, title, description, active
+			SELECT id, title, description, active, inbox, prompt_note, url,
+			       COALESCE((
+			           SELECT MAX(date(end_date)) FROM task_pauses
+			           WHERE task_id = task_definitions.id
+			             AND ? BETWEEN start_date AND end_date
+			       ), ''),
+			       COALESCE(strftime('%Y-%m-%d %H:%M:%S', created_at), ''), target
 			FROM task_definitions
 			WHERE deleted = false
-			ORDER BY created_at ASC
-		`)
+			ORDER BY inbox DESC, created_at ASC
+		`, todayKey())
 		if err != nil {
 			return taskDefinitionsLoadFailedMsg{err: err}
 		}
@@ -113,9 +197,12 @@ func loadTaskDefinitionsCmd(db *sql.DB) tea.Cmd {
 		var tasks []TaskDefinition
 		for rows.Next() {
 			var t TaskDefinition
-			if err := rows.Scan(&t.id, &t.title, &t.description, &t.active); err != nil {
+			if err := rows.Scan(&t.id, &t.title, &t.description, &t.active, &t.inbox, &t.promptNote, &t.url, &t.pausedUntil, &t.created, &t.target); err != nil {
 				return taskDefinitionsLoadFailedMsg{err: err}
 			}
+			if created, ok := fromUTC(t.created); ok {
+				t.created = dateKey(created)
+			}
 			tasks = append(tasks, t)
 		}
 		if err := rows.Err(); err != nil {
@@ -125,15 +212,16 @@ func loadTaskDefinitionsCmd(db *sql.DB) tea.Cmd {
 	}
 }
 
-// addTaskDefinitionCmd inserts a new task definition.
-func addTaskDefinitionCmd(db *sql.DB, title, description string) tea.Cmd {
+// addTaskDefinitionCmd inserts a new task definition. Tasks captured to the
+// inbox start inactive so they stay off Today until triaged.
+func addTaskDefinitionCmd(db *sql.DB, title, description, url string, inbox bool) tea.Cmd {
 	return func() tea.Msg {
 		var id string
 		err := db.QueryRow(`
-			INSERT INTO task_definitions (id, title, description, active)
-			VALUES (lower(hex(randomblob(16))), ?, ?, true)
+			INSERT INTO task_definitions (id, title, description, url, active, inbox)
+			VALUES (lower(hex(randomblob(16))), ?, ?, ?, ?, ?)
 			RETURNING id
-		`, title, description).Scan(&id)
+		`, title, description, url, !inbox, inbox).Scan(&id)
 		if err != nil {
 			return taskAddFailedMsg{err: err}
 		}
@@ -141,11 +229,39 @@ func addTaskDefinitionCmd(db *sql.DB, title, description string) tea.Cmd {
 			id:          id,
 			title:       title,
 			description: description,
-			active:      true,
+			url:         url,
+			active:      !inbox,
+			inbox:       inbox,
+			target:      1,
 		}}
 	}
 }
 
+// triageTaskCmd takes a task out of the inbox and activates it.
+func triageTaskCmd(db *sql.DB, taskID string) tea.Cmd {
+	return func() tea.Msg {
+		_, err := db.Exec(`
+			UPDATE task_definitions SET inbox = false, active = true WHERE id = ?
+		`, taskID)
+		if err != nil {
+			return taskTriageFailedMsg{taskID: taskID, err: err}
+		}
+		return taskTriagedMsg{taskID: taskID}
+	}
+}
+
+// loadTaskRecordCmd loads a task's completion count and longest streak, to
+// show what deactivating it would retire.
+func loadTaskRecordCmd(db *sql.DB, taskID string) tea.Cmd {
+	return func() tea.Msg {
+		completions, longest, err := loadTaskRecord(db, taskID)
+		if err != nil {
+			return taskRecordLoadFailedMsg{taskID: taskID, err: err}
+		}
+		return taskRecordLoadedMsg{taskID: taskID, completions: completions, longest: longest}
+	}
+}
+
 // toggleTaskActiveCmd toggles the active status of a task definition.
 func toggleTaskActiveCmd(db *sql.DB, taskID string, newActive bool) tea.Cmd {
 	return func() tea.Msg {
@@ -159,6 +275,115 @@ func toggleTaskActiveCmd(db *sql.DB, taskID string, newActive bool) tea.Cmd {
 	}
 }
 
+// setTaskNotePromptCmd sets whether completing a task prompts for a note.
+func setTaskNotePromptCmd(db *sql.DB, taskID string, promptNote bool) tea.Cmd {
+	return func() tea.Msg {
+		_, err := db.Exec(`
+			UPDATE task_definitions SET prompt_note = ? WHERE id = ?
+		`, promptNote, taskID)
+		if err != nil {
+			return taskNotePromptToggleFailedMsg{taskID: taskID, promptNote: promptNote, err: err}
+		}
+		return taskNotePromptToggledMsg{taskID: taskID, promptNote: promptNote}
+	}
+}
+
+// setTaskPauseCmd pauses a task from today through until, or resumes it when
+// until is empty.
+func setTaskPauseCmd(db *sql.DB, taskID, until string) tea.Cmd {
+	return func() tea.Msg {
+		if err := setTaskPause(db, taskID, until); err != nil {
+			return taskPauseSetFailedMsg{taskID: taskID, err: err}
+		}
+		return taskPauseSetMsg{taskID: taskID, until: until}
+	}
+}
+
+// maxTaskTarget is the most times a day a task can be set to be done.
+const maxTaskTarget = 99
+
+// setTaskTargetCmd sets how many times a day a task is to be done. Days
+// already counted keep the target they were counted against.
+func setTaskTargetCmd(db *sql.DB, taskID string, target int) tea.Cmd {
+	return func() tea.Msg {
+		_, err := db.Exec(`
+			UPDATE task_definitions SET target = ? WHERE id = ?
+		`, target, taskID)
+		if err != nil {
+			return taskTargetSetFailedMsg{taskID: taskID, err: err}
+		}
+		return taskTargetSetMsg{taskID: taskID, target: target}
+	}
+}
+
+// parseTaskTarget reads the daily target prompt: a number of times, with an
+// optional "x" ("8x"), or nothing for a task simply done once.
+func parseTaskTarget(input string) (int, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(input, "x"))
+	if err != nil || n < 1 || n > maxTaskTarget {
+		return 0, fmt.Errorf("enter a number of times from 1 to %d", maxTaskTarget)
+	}
+	return n, nil
+}
+
+// parsePauseUntil reads the pause prompt: a number of days including today
+// ("7" or "7d"), a date ("2006-01-02"), or nothing to resume. It returns the
+// last paused day.
+func parsePauseUntil(input string, now time.Time) (string, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return "", nil
+	}
+	if n, err := strconv.Atoi(strings.TrimSuffix(input, "d")); err == nil {
+		if n < 1 {
+			return "", fmt.Errorf("pause for at least one day")
+		}
+		return addDays(now, n-1).Format("2006-01-02"), nil
+	}
+	until, err := time.ParseInLocation("2006-01-02", input, now.Location())
+	if err != nil {
+		return "", fmt.Errorf("enter a number of days or a date like 2006-01-02")
+	}
+	if until.Format("2006-01-02") < now.Format("2006-01-02") {
+		return "", fmt.Errorf("that date has already passed")
+	}
+	return until.Format("2006-01-02"), nil
+}
+
+// parseCreatedDate reads a task's creation date ("2006-01-02"), which can't
+// be later than today. It returns the start of that day in now's zone.
+func parseCreatedDate(input string, now time.Time) (time.Time, error) {
+	created, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(input), now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("enter a date like 2006-01-02")
+	}
+	if dateKey(created) > dateKey(now) {
+		return time.Time{}, fmt.Errorf("a task can't be created in the future")
+	}
+	return created, nil
+}
+
+// parseTaskURL checks a task's link: an http or https URL with a host, or
+// nothing for no link.
+func parseTaskURL(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", nil
+	}
+	u, err := url.Parse(input)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("enter a link starting with http:// or https://")
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("that link has no host")
+	}
+	return input, nil
+}
+
 // softDeleteTaskCmd sets deleted=true for a task definition.
 func softDeleteTaskCmd(db *sql.DB, taskID string) tea.Cmd {
 	return func() tea.Msg {
@@ -172,21 +397,63 @@ func softDeleteTaskCmd(db *sql.DB, taskID string) tea.Cmd {
 	}
 }
 
-// updateTaskDefinitionCmd updates a task definition's title and description.
-func updateTaskDefinitionCmd(db *sql.DB, taskID, title, description string, active bool) tea.Cmd {
+// hardDeleteTaskCmd permanently removes a task definition and everything
+// that references it in one transaction. Rows referencing the task go first
+// so the foreign keys on task_id hold at every step.
+func hardDeleteTaskCmd(db *sql.DB, taskID string) tea.Cmd {
 	return func() tea.Msg {
-		_, err := db.Exec(`
-			UPDATE task_definitions SET title = ?, description = ? WHERE id = ?
-		`, title, description, taskID)
+		tx, err := db.Begin()
+		if err != nil {
+			return taskDeleteFailedMsg{taskID: taskID, err: err}
+		}
+		defer tx.Rollback()
+
+		for _, q := range []string{
+			`DELETE FROM task_pauses WHERE task_id = ?`,
+			`DELETE FROM task_history WHERE task_id = ?`,
+			`DELETE FROM task_definitions WHERE id = ?`,
+		} {
+			if _, err := tx.Exec(q, taskID); err != nil {
+				return taskDeleteFailedMsg{taskID: taskID, err: err}
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return taskDeleteFailedMsg{taskID: taskID, err: err}
+		}
+		return taskDeletedMsg{taskID: taskID, purged: true}
+	}
+}
+
+// updateTaskDefinitionCmd updates a task definition's title, description
+// and URL, and its creation date unless created is zero. The date moves
+// created_at to the start of that day, which changes where the task sorts
+// and how old it is. Whether the task is active is left alone; that goes
+// through setActive, which asks before retiring a task with a history.
+func updateTaskDefinitionCmd(db *sql.DB, taskID, title, description, url string, created time.Time) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if created.IsZero() {
+			_, err = db.Exec(`
+				UPDATE task_definitions SET title = ?, description = ?, url = ? WHERE id = ?
+			`, title, description, url, taskID)
+		} else {
+			_, err = db.Exec(`
+				UPDATE task_definitions SET title = ?, description = ?, url = ?, created_at = ? WHERE id = ?
+			`, title, description, url, created.UTC().Format(sqliteTimestamp), taskID)
+		}
 		if err != nil {
 			return taskEditFailedMsg{taskID: taskID, err: err}
 		}
-		return taskEditedMsg{task: TaskDefinition{
+		task := TaskDefinition{
 			id:          taskID,
 			title:       title,
 			description: description,
-			active:      active,
-		}}
+			url:         url,
+		}
+		if !created.IsZero() {
+			task.created = dateKey(created)
+		}
+		return taskEditedMsg{task: task}
 	}
 }
 
@@ -197,6 +464,7 @@ func updateTaskDefinitionCmd(db *sql.DB, taskID, title, description string, acti
 // taskCfgDelegate renders task definitions with active/inactive indicator.
 type taskCfgDelegate struct {
 	list.DefaultDelegate
+	dateLayout string // for the end of a pause
 }
 
 func (d *taskCfgDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
@@ -218,10 +486,15 @@ func (d *taskCfgDelegate) Render(w io.Writer, m list.Model, index int, item list
 		return
 	}
 
-	// Visual indicator: checkmark for active, circle for inactive
+	// Visual indicator: checkmark for active, circle for inactive, diamond
+	// for inbox
 	indicator := "✓"
 	indicatorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
-	if !t.active {
+	switch {
+	case t.inbox:
+		indicator = "◇"
+		indicatorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	case !t.active:
 		indicator = "○"
 		indicatorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
 	}
@@ -257,8 +530,26 @@ func (d *taskCfgDelegate) Render(w io.Writer, m list.Model, index int, item list
 		matchedRunes = m.MatchesForItem(index)
 	}
 
-	// Prepend indicator to title
+	// Prepend indicator to title, and mark tasks that prompt for a note
 	title = indicatorStyle.Render(indicator) + " " + title
+	if t.inbox {
+		title += " · inbox"
+	}
+	if t.promptNote {
+		title += " ✎"
+	}
+	if t.url != "" {
+		title += " " + taskLinkGlyph
+	}
+	if t.target > 1 {
+		title += fmt.Sprintf(" ×%d", t.target)
+	}
+	if t.pausedUntil != "" {
+		title += " ⏸"
+		if until, err := time.ParseInLocation("2006-01-02", t.pausedUntil, homeLoc); err == nil {
+			title += " until " + until.Format(d.dateLayout)
+		}
+	}
 
 	// Apply styles based on state
 	if emptyFilter {
@@ -296,8 +587,8 @@ func (d *taskCfgDelegate) Render(w io.Writer, m list.Model, index int, item list
 	}
 }
 
-func newTaskCfgDelegate() *taskCfgDelegate {
-	return &taskCfgDelegate{DefaultDelegate: list.NewDefaultDelegate()}
+func newTaskCfgDelegate(dateLayout string) *taskCfgDelegate {
+	return &taskCfgDelegate{DefaultDelegate: list.NewDefaultDelegate(), dateLayout: dateLayout}
 }
 
 /**
@@ -307,9 +598,16 @@ func newTaskCfgDelegate() *taskCfgDelegate {
 // taskCfgKeyMap defines key bindings for the Task Configuration page.
 type taskCfgKeyMap struct {
 	Add    key.Binding
+	Inbox  key.Binding
+	Triage key.Binding
 	Edit   key.Binding
+	Rename key.Binding
 	Toggle key.Binding
+	Note   key.Binding
+	Pause  key.Binding
+	Target key.Binding
 	Delete key.Binding
+	Retry  key.Binding
 }
 
 var taskCfgKeys = taskCfgKeyMap{
@@ -317,18 +615,46 @@ var taskCfgKeys = taskCfgKeyMap{
 		key.WithKeys("a"),
 		key.WithHelp("a", "add"),
 	),
+	Inbox: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "capture to inbox"),
+	),
+	Triage: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "schedule"),
+	),
 	Edit: key.NewBinding(
 		key.WithKeys("e"),
 		key.WithHelp("e", "edit"),
 	),
+	Rename: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "rename"),
+	),
 	Toggle: key.NewBinding(
 		key.WithKeys(" "),
 		key.WithHelp("space", "toggle"),
 	),
+	Note: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "note prompt"),
+	),
+	Pause: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "pause"),
+	),
+	Target: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "times a day"),
+	),
 	Delete: key.NewBinding(
 		key.WithKeys("d"),
 		key.WithHelp("d", "delete"),
 	),
+	Retry: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "retry"),
+	),
 }
 
 // taskCfgMode determines the current interaction state.
@@ -338,9 +664,18 @@ const (
 	taskCfgModeList taskCfgMode = iota
 	taskCfgModeAddTitle
 	taskCfgModeAddDesc
+	taskCfgModeAddURL
+	taskCfgModeCapture
 	taskCfgModeEditTitle
 	taskCfgModeEditDesc
-	taskCfgModeConfirmDelete
+	taskCfgModeEditURL
+	taskCfgModeEditCreated
+	taskCfgModeConfirm // confirm is open
+	taskCfgModeConfirmDeactivate
+	taskCfgModeConfirmDiscard
+	taskCfgModePause
+	taskCfgModeTarget
+	taskCfgModeRename
 )
 
 // TaskCfgPage manages task definitions.
@@ -352,22 +687,55 @@ type TaskCfgPage struct {
 	// Input fields for adding/editing tasks
 	titleInput textinput.Model
 	descInput  textinput.Model
+	urlInput   textinput.Model
+	urlErr     error
+
+	// For edit mode; the creation date is only asked for on request
+	createdInput textinput.Model
+	createdErr   error
+
+	editingTaskID   string
+	originalTitle   string // values when editing started, to detect changes
+	originalDesc    string
+	originalURL     string
+	originalCreated string
+
+	// For discard confirmation: the edit mode to return to on cancel
+	discardReturnMode taskCfgMode
+
+	// The open confirmation, if any; see confirmDialog
+	confirm    *confirmDialog
+	hardDelete bool // purge tasks and their history instead of hiding them
+
+	// For deactivate confirmation, shown when the task has a history
+	pendingDeactivate TaskDefinition
+	deactivateRecord  taskRecordLoadedMsg
+
+	// For the pause prompt
+	pauseInput     textinput.Model
+	pauseTaskID    string
+	pauseTaskTitle string
+	pauseErr       error
+
+	// For renaming, which only asks for the title; the rest is kept
+	renameTask TaskDefinition
 
-	// For edit mode
-	editingTaskID     string
-	editingTaskActive bool
+	// For the daily target prompt
+	targetInput     textinput.Model
+	targetTaskID    string
+	targetTaskTitle string
+	targetErr       error
 
-	// For delete confirmation
-	pendingDeleteID    string
-	pendingDeleteTitle string
+	// Set when loading definitions fails; cleared by the next successful load
+	loadErr error
 
 	width  int
 	height int
 }
 
 // NewTaskCfgPage creates and initializes the Task Configuration page.
-func NewTaskCfgPage(db *sql.DB) *TaskCfgPage {
-	delegate := newTaskCfgDelegate()
+func NewTaskCfgPage(db *sql.DB, cfg config.Config) *TaskCfgPage {
+	delegate := newTaskCfgDelegate(cfg.Formats.DateDay)
 	l := list.New([]list.Item{}, delegate, 0, 0)
 	l.Title = "Task Definitions"
 	l.SetShowHelp(false)
@@ -382,12 +750,37 @@ func NewTaskCfgPage(db *sql.DB) *TaskCfgPage {
 	di.Placeholder = "Description (optional, press enter to skip)..."
 	di.CharLimit = 200
 
+	// URL input
+	ui := textinput.New()
+	ui.Placeholder = "Link, e.g. https://... (optional, press enter to skip)"
+	ui.CharLimit = 500
+
+	// Creation date input
+	ci := textinput.New()
+	ci.Placeholder = "YYYY-MM-DD"
+	ci.CharLimit = 10
+
+	// Pause input
+	pi := textinput.New()
+	pi.Placeholder = "Days (e.g. 7) or last day (YYYY-MM-DD); empty to resume"
+	pi.CharLimit = 10
+
+	// Daily target input
+	gi := textinput.New()
+	gi.Placeholder = "Times a day, e.g. 8; 1 or empty for once"
+	gi.CharLimit = 3
+
 	return &TaskCfgPage{
-		list:       l,
-		db:         db,
-		mode:       taskCfgModeList,
-		titleInput: ti,
-		descInput:  di,
+		list:         l,
+		db:           db,
+		mode:         taskCfgModeList,
+		titleInput:   ti,
+		descInput:    di,
+		urlInput:     ui,
+		createdInput: ci,
+		pauseInput:   pi,
+		targetInput:  gi,
+		hardDelete:   cfg.TaskDelete == config.TaskDeleteHard,
 	}
 }
 
@@ -401,8 +794,10 @@ func (p *TaskCfgPage) CapturesNavigation() bool {
 	return p.mode != taskCfgModeList
 }
 
+// CapturesGlobalKeys returns true outside the task list, so q and ? can be
+// typed into a title, e.g. while renaming or setting a target.
 func (p *TaskCfgPage) CapturesGlobalKeys() bool {
-	return false // Allow global keys in all modes
+	return p.mode != taskCfgModeList
 }
 
 func (p *TaskCfgPage) Title() Title {
@@ -420,6 +815,10 @@ func (p *TaskCfgPage) SetSize(width, height int) {
 	p.list.SetHeight(height)
 	p.titleInput.Width = max(contentWidth-4, 0)
 	p.descInput.Width = max(contentWidth-4, 0)
+	p.urlInput.Width = max(contentWidth-4, 0)
+	p.createdInput.Width = max(contentWidth-4, 0)
+	p.pauseInput.Width = max(contentWidth-4, 0)
+	p.targetInput.Width = max(contentWidth-4, 0)
 }
 
 // InitCmd loads task definitions from database.
@@ -427,18 +826,41 @@ func (p *TaskCfgPage) InitCmd() tea.Cmd {
 	return loadTaskDefinitionsCmd(p.db)
 }
 
+// RefreshCmd implements Refresher by reloading task definitions.
+func (p *TaskCfgPage) RefreshCmd() tea.Cmd {
+	return loadTaskDefinitionsCmd(p.db)
+}
+
 func (p *TaskCfgPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 	switch p.mode {
 	case taskCfgModeAddTitle:
 		return p.updateAddTitleMode(msg)
 	case taskCfgModeAddDesc:
 		return p.updateAddDescMode(msg)
+	case taskCfgModeAddURL:
+		return p.updateAddURLMode(msg)
+	case taskCfgModeCapture:
+		return p.updateCaptureMode(msg)
 	case taskCfgModeEditTitle:
 		return p.updateEditTitleMode(msg)
 	case taskCfgModeEditDesc:
 		return p.updateEditDescMode(msg)
-	case taskCfgModeConfirmDelete:
-		return p.updateConfirmDeleteMode(msg)
+	case taskCfgModeEditURL:
+		return p.updateEditURLMode(msg)
+	case taskCfgModeEditCreated:
+		return p.updateEditCreatedMode(msg)
+	case taskCfgModeConfirm:
+		return p.updateConfirmMode(msg)
+	case taskCfgModeConfirmDeactivate:
+		return p.updateConfirmDeactivateMode(msg)
+	case taskCfgModeConfirmDiscard:
+		return p.updateConfirmDiscardMode(msg)
+	case taskCfgModePause:
+		return p.updatePauseMode(msg)
+	case taskCfgModeTarget:
+		return p.updateTargetMode(msg)
+	case taskCfgModeRename:
+		return p.updateRenameMode(msg)
 	}
 
 	var cmds []tea.Cmd
@@ -451,8 +873,17 @@ func (p *TaskCfgPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 	}
 
 	switch msg := msg.(type) {
+	case confirmResultMsg:
+		if msg.id == taskCfgConfirmDelete && msg.confirmed {
+			if p.hardDelete {
+				return p, hardDeleteTaskCmd(p.db, msg.target)
+			}
+			return p, softDeleteTaskCmd(p.db, msg.target)
+		}
+
 	// Handle loaded data
 	case taskDefinitionsLoadedMsg:
+		p.loadErr = nil
 		items := make([]list.Item, len(msg.tasks))
 		for i, t := range msg.tasks {
 			items[i] = t
@@ -460,10 +891,25 @@ func (p *TaskCfgPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		p.list.SetItems(items)
 
 	case taskDefinitionsLoadFailedMsg:
+		p.loadErr = msg.err
 		cmds = append(cmds, p.list.NewStatusMessage(fmt.Sprintf("load failed: %v", msg.err)))
 
 	// Handle add success
 	case taskAddedMsg:
+		if msg.task.inbox {
+			// After the other inbox tasks, matching the load order
+			items := p.list.Items()
+			pos := 0
+			for pos < len(items) {
+				if t, ok := items[pos].(TaskDefinition); !ok || !t.inbox {
+					break
+				}
+				pos++
+			}
+			p.list.InsertItem(pos, msg.task)
+			cmds = append(cmds, p.list.NewStatusMessage("Captured to inbox"))
+			break
+		}
 		items := p.list.Items()
 		items = append(items, msg.task)
 		p.list.SetItems(items)
@@ -471,22 +917,70 @@ func (p *TaskCfgPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		cmds = append(cmds, func() tea.Msg { return InvalidateTodayPageMsg{} })
 
 	case taskAddFailedMsg:
+		logger.Errorf("task config: add task: %v", msg.err)
 		cmds = append(cmds, p.list.NewStatusMessage(fmt.Sprintf("add failed: %v", msg.err)))
 
 	// Handle edit success
 	case taskEditedMsg:
 		for i, item := range p.list.Items() {
 			if t, ok := item.(TaskDefinition); ok && t.id == msg.task.id {
-				p.list.SetItem(i, msg.task)
+				// Only the edited fields change; keep the rest as loaded
+				t.title = msg.task.title
+				t.description = msg.task.description
+				t.url = msg.task.url
+				if msg.task.created != "" {
+					t.created = msg.task.created
+				}
+				p.list.SetItem(i, t)
 				break
 			}
 		}
 		cmds = append(cmds, p.list.NewStatusMessage("Task updated"))
 		cmds = append(cmds, func() tea.Msg { return InvalidateTodayPageMsg{} })
+		if msg.task.created != "" {
+			// The list is in creation order
+			cmds = append(cmds, loadTaskDefinitionsCmd(p.db))
+			cmds = append(cmds, func() tea.Msg { return InvalidateHistoryPageMsg{} })
+		}
 
 	case taskEditFailedMsg:
+		logger.Errorf("task config: edit task %s: %v", msg.taskID, msg.err)
 		cmds = append(cmds, p.list.NewStatusMessage(fmt.Sprintf("edit failed: %v", msg.err)))
 
+	case taskTriagedMsg:
+		cmds = append(cmds, p.list.NewStatusMessage("scheduled"))
+		cmds = append(cmds,
+			func() tea.Msg { return InvalidateTodayPageMsg{} },
+			func() tea.Msg { return InvalidateHistoryPageMsg{} },
+		)
+
+	case taskTriageFailedMsg:
+		logger.Errorf("task config: schedule task %s: %v", msg.taskID, msg.err)
+		for i, item := range p.list.Items() {
+			if t, ok := item.(TaskDefinition); ok && t.id == msg.taskID {
+				t.inbox, t.active = true, false // Rollback
+				p.list.SetItem(i, t)
+				break
+			}
+		}
+		cmds = append(cmds, p.list.NewStatusMessage(fmt.Sprintf("schedule failed: %v", msg.err)))
+
+	case taskRecordLoadedMsg:
+		idx, item, ok := p.taskByID(msg.taskID)
+		if !ok || !item.active || item.inbox {
+			break // changed meanwhile
+		}
+		if msg.completions == 0 {
+			cmds = append(cmds, p.setActive(idx, item, false))
+			break
+		}
+		p.pendingDeactivate = item
+		p.deactivateRecord = msg
+		p.mode = taskCfgModeConfirmDeactivate
+
+	case taskRecordLoadFailedMsg:
+		cmds = append(cmds, p.list.NewStatusMessage(fmt.Sprintf("toggle failed: %v", msg.err)))
+
 	// Handle toggle success
 	case taskActiveToggledMsg:
 		statusMsg := "deactivated"
@@ -498,6 +992,7 @@ func (p *TaskCfgPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 
 	// Handle toggle failure - rollback
 	case taskActiveToggleFailedMsg:
+		logger.Errorf("task config: set task %s active=%t: %v", msg.taskID, msg.active, msg.err)
 		for i, item := range p.list.Items() {
 			if t, ok := item.(TaskDefinition); ok && t.id == msg.taskID {
 				t.active = !msg.active // Rollback
@@ -507,6 +1002,63 @@ func (p *TaskCfgPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		}
 		cmds = append(cmds, p.list.NewStatusMessage(fmt.Sprintf("toggle failed: %v", msg.err)))
 
+	case taskNotePromptToggledMsg:
+		statusMsg := "note prompt off"
+		if msg.promptNote {
+			statusMsg = "note prompt on"
+		}
+		cmds = append(cmds, p.list.NewStatusMessage(statusMsg))
+		cmds = append(cmds, func() tea.Msg { return InvalidateTodayPageMsg{} })
+
+	case taskNotePromptToggleFailedMsg:
+		logger.Errorf("task config: set task %s note prompt=%t: %v", msg.taskID, msg.promptNote, msg.err)
+		for i, item := range p.list.Items() {
+			if t, ok := item.(TaskDefinition); ok && t.id == msg.taskID {
+				t.promptNote = !msg.promptNote // Rollback
+				p.list.SetItem(i, t)
+				break
+			}
+		}
+		cmds = append(cmds, p.list.NewStatusMessage(fmt.Sprintf("note prompt failed: %v", msg.err)))
+
+	case taskPauseSetMsg:
+		for i, item := range p.list.Items() {
+			if t, ok := item.(TaskDefinition); ok && t.id == msg.taskID {
+				t.pausedUntil = msg.until
+				p.list.SetItem(i, t)
+				break
+			}
+		}
+		statusMsg := "resumed"
+		if msg.until != "" {
+			statusMsg = "paused until " + msg.until
+		}
+		cmds = append(cmds, p.list.NewStatusMessage(statusMsg))
+		cmds = append(cmds,
+			func() tea.Msg { return InvalidateTodayPageMsg{} },
+			func() tea.Msg { return InvalidateHistoryPageMsg{} },
+		)
+
+	case taskPauseSetFailedMsg:
+		logger.Errorf("task config: pause task %s: %v", msg.taskID, msg.err)
+		cmds = append(cmds, p.list.NewStatusMessage(fmt.Sprintf("pause failed: %v", msg.err)))
+
+	case taskTargetSetMsg:
+		if i, t, ok := p.taskByID(msg.taskID); ok {
+			t.target = msg.target
+			p.list.SetItem(i, t)
+		}
+		statusMsg := "done once a day"
+		if msg.target > 1 {
+			statusMsg = fmt.Sprintf("done %d times a day", msg.target)
+		}
+		cmds = append(cmds, p.list.NewStatusMessage(statusMsg))
+		cmds = append(cmds, func() tea.Msg { return InvalidateTodayPageMsg{} })
+
+	case taskTargetSetFailedMsg:
+		logger.Errorf("task config: set target of task %s: %v", msg.taskID, msg.err)
+		cmds = append(cmds, p.list.NewStatusMessage(fmt.Sprintf("target failed: %v", msg.err)))
+
 	// Handle delete success
 	case taskDeletedMsg:
 		items := p.list.Items()
@@ -517,10 +1069,16 @@ func (p *TaskCfgPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 			}
 		}
 		p.list.SetItems(items)
-		cmds = append(cmds, p.list.NewStatusMessage("Task deleted"))
+		if msg.purged {
+			cmds = append(cmds, p.list.NewStatusMessage("Task and history permanently deleted"))
+			cmds = append(cmds, func() tea.Msg { return InvalidateHistoryPageMsg{} })
+		} else {
+			cmds = append(cmds, p.list.NewStatusMessage("Task deleted"))
+		}
 		cmds = append(cmds, func() tea.Msg { return InvalidateTodayPageMsg{} })
 
 	case taskDeleteFailedMsg:
+		logger.Errorf("task config: delete task %s: %v", msg.taskID, msg.err)
 		cmds = append(cmds, p.list.NewStatusMessage(fmt.Sprintf("delete failed: %v", msg.err)))
 
 	// Key handling
@@ -529,6 +1087,14 @@ func (p *TaskCfgPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 			break // Don't intercept when filtering
 		}
 
+		// Only retry is available until the definitions have loaded
+		if p.loadErr != nil {
+			if key.Matches(msg, taskCfgKeys.Retry) {
+				return p, loadTaskDefinitionsCmd(p.db)
+			}
+			break
+		}
+
 		switch {
 		case key.Matches(msg, taskCfgKeys.Add):
 			p.mode = taskCfgModeAddTitle
@@ -536,6 +1102,12 @@ func (p *TaskCfgPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 			p.titleInput.Focus()
 			return p, textinput.Blink
 
+		case key.Matches(msg, taskCfgKeys.Inbox):
+			p.mode = taskCfgModeCapture
+			p.titleInput.Reset()
+			p.titleInput.Focus()
+			return p, textinput.Blink
+
 		case key.Matches(msg, taskCfgKeys.Edit):
 			idx := p.list.Index()
 			if idx < 0 || idx >= len(p.list.Items()) {
@@ -546,9 +1118,14 @@ func (p *TaskCfgPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 				break
 			}
 			p.editingTaskID = item.id
-			p.editingTaskActive = item.active
+			p.originalTitle = item.title
+			p.originalDesc = item.description
+			p.originalURL = item.url
+			p.originalCreated = item.created
 			p.titleInput.SetValue(item.title)
 			p.descInput.SetValue(item.description)
+			p.urlInput.SetValue(item.url)
+			p.createdInput.SetValue(item.created)
 			p.mode = taskCfgModeEditTitle
 			p.titleInput.Focus()
 			return p, textinput.Blink
@@ -562,10 +1139,47 @@ func (p *TaskCfgPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 			if !ok {
 				break
 			}
+			// Optimistic update. Activating an inbox task schedules it.
+			if item.inbox {
+				item.inbox, item.active = false, true
+				p.list.SetItem(idx, item)
+				cmds = append(cmds, triageTaskCmd(p.db, item.id))
+				break
+			}
+			if item.active {
+				// Look at what would be retired before asking
+				cmds = append(cmds, loadTaskRecordCmd(p.db, item.id))
+				break
+			}
+			cmds = append(cmds, p.setActive(idx, item, true))
+
+		case key.Matches(msg, taskCfgKeys.Triage):
+			idx := p.list.Index()
+			if idx < 0 || idx >= len(p.list.Items()) {
+				break
+			}
+			item, ok := p.list.Items()[idx].(TaskDefinition)
+			if !ok || !item.inbox {
+				break
+			}
 			// Optimistic update
-			item.active = !item.active
+			item.inbox, item.active = false, true
 			p.list.SetItem(idx, item)
-			cmds = append(cmds, toggleTaskActiveCmd(p.db, item.id, item.active))
+			cmds = append(cmds, triageTaskCmd(p.db, item.id))
+
+		case key.Matches(msg, taskCfgKeys.Note):
+			idx := p.list.Index()
+			if idx < 0 || idx >= len(p.list.Items()) {
+				break
+			}
+			item, ok := p.list.Items()[idx].(TaskDefinition)
+			if !ok {
+				break
+			}
+			// Optimistic update
+			item.promptNote = !item.promptNote
+			p.list.SetItem(idx, item)
+			cmds = append(cmds, setTaskNotePromptCmd(p.db, item.id, item.promptNote))
 
 		case key.Matches(msg, taskCfgKeys.Delete):
 			idx := p.list.Index()
@@ -576,15 +1190,74 @@ func (p *TaskCfgPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 			if !ok {
 				break
 			}
-			p.pendingDeleteID = item.id
-			p.pendingDeleteTitle = item.title
-			p.mode = taskCfgModeConfirmDelete
+			p.confirmDelete(item)
+
+		case key.Matches(msg, taskCfgKeys.Pause):
+			idx := p.list.Index()
+			if idx < 0 || idx >= len(p.list.Items()) {
+				break
+			}
+			item, ok := p.list.Items()[idx].(TaskDefinition)
+			if !ok {
+				break
+			}
+			p.pauseTaskID = item.id
+			p.pauseTaskTitle = item.title
+			p.pauseErr = nil
+			p.pauseInput.SetValue(item.pausedUntil)
+			p.pauseInput.CursorEnd()
+			p.mode = taskCfgModePause
+			p.pauseInput.Focus()
+			return p, textinput.Blink
+
+		case key.Matches(msg, taskCfgKeys.Rename):
+			item, ok := p.list.SelectedItem().(TaskDefinition)
+			if !ok {
+				break
+			}
+			p.renameTask = item
+			p.titleInput.SetValue(item.title)
+			p.titleInput.CursorEnd()
+			p.mode = taskCfgModeRename
+			p.titleInput.Focus()
+			return p, textinput.Blink
+
+		case key.Matches(msg, taskCfgKeys.Target):
+			item, ok := p.list.SelectedItem().(TaskDefinition)
+			if !ok {
+				break
+			}
+			p.targetTaskID = item.id
+			p.targetTaskTitle = item.title
+			p.targetErr = nil
+			p.targetInput.SetValue(strconv.Itoa(max(item.target, 1)))
+			p.targetInput.CursorEnd()
+			p.mode = taskCfgModeTarget
+			p.targetInput.Focus()
+			return p, textinput.Blink
 		}
 	}
 
 	return p, tea.Batch(cmds...)
 }
 
+// taskByID returns the list index and value of the task with id.
+func (p *TaskCfgPage) taskByID(id string) (int, TaskDefinition, bool) {
+	for i, item := range p.list.Items() {
+		if t, ok := item.(TaskDefinition); ok && t.id == id {
+			return i, t, true
+		}
+	}
+	return -1, TaskDefinition{}, false
+}
+
+// setActive optimistically sets a task's active state and saves it.
+func (p *TaskCfgPage) setActive(idx int, item TaskDefinition, active bool) tea.Cmd {
+	item.active = active
+	p.list.SetItem(idx, item)
+	return toggleTaskActiveCmd(p.db, item.id, active)
+}
+
 func (p *TaskCfgPage) updateAddTitleMode(msg tea.Msg) (Page, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -616,26 +1289,99 @@ func (p *TaskCfgPage) updateAddDescMode(msg tea.Msg) (Page, tea.Cmd) {
 			p.mode = taskCfgModeList
 			return p, nil
 		case "enter":
+			p.mode = taskCfgModeAddURL
+			p.urlInput.Reset()
+			p.urlErr = nil
+			p.urlInput.Focus()
+			return p, textinput.Blink
+		}
+	}
+
+	var cmd tea.Cmd
+	p.descInput, cmd = p.descInput.Update(msg)
+	return p, cmd
+}
+
+func (p *TaskCfgPage) updateAddURLMode(msg tea.Msg) (Page, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			p.mode = taskCfgModeList
+			return p, nil
+		case "enter":
+			link, err := parseTaskURL(p.urlInput.Value())
+			if err != nil {
+				p.urlErr = err
+				return p, nil
+			}
 			title := strings.TrimSpace(p.titleInput.Value())
 			desc := strings.TrimSpace(p.descInput.Value())
 			p.mode = taskCfgModeList
-			return p, addTaskDefinitionCmd(p.db, title, desc)
+			return p, addTaskDefinitionCmd(p.db, title, desc, link, false)
 		}
 	}
 
 	var cmd tea.Cmd
-	p.descInput, cmd = p.descInput.Update(msg)
+	p.urlInput, cmd = p.urlInput.Update(msg)
 	return p, cmd
 }
 
-func (p *TaskCfgPage) updateEditTitleMode(msg tea.Msg) (Page, tea.Cmd) {
+// updateCaptureMode adds a task to the inbox from just a title; the rest can
+// wait for triage.
+func (p *TaskCfgPage) updateCaptureMode(msg tea.Msg) (Page, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc":
-			p.editingTaskID = ""
 			p.mode = taskCfgModeList
 			return p, nil
+		case "enter":
+			title := strings.TrimSpace(p.titleInput.Value())
+			if title == "" {
+				return p, nil // Don't proceed with empty title
+			}
+			p.mode = taskCfgModeList
+			return p, addTaskDefinitionCmd(p.db, title, "", "", true)
+		}
+	}
+
+	var cmd tea.Cmd
+	p.titleInput, cmd = p.titleInput.Update(msg)
+	return p, cmd
+}
+
+// editHasChanges reports whether the edit inputs differ from the loaded task.
+func (p *TaskCfgPage) editHasChanges() bool {
+	return p.titleInput.Value() != p.originalTitle ||
+		p.descInput.Value() != p.originalDesc ||
+		p.urlInput.Value() != p.originalURL ||
+		p.createdInput.Value() != p.originalCreated
+}
+
+// cancelEdit leaves edit mode, asking for confirmation first if the inputs
+// have unsaved changes.
+func (p *TaskCfgPage) cancelEdit() {
+	if p.editHasChanges() {
+		p.discardReturnMode = p.mode
+		p.titleInput.Blur()
+		p.descInput.Blur()
+		p.urlInput.Blur()
+		p.createdInput.Blur()
+		p.mode = taskCfgModeConfirmDiscard
+		return
+	}
+	p.editingTaskID = ""
+	p.mode = taskCfgModeList
+}
+
+func (p *TaskCfgPage) updateEditTitleMode(msg tea.Msg) (Page, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			p.cancelEdit()
+			return p, nil
 		case "enter":
 			if strings.TrimSpace(p.titleInput.Value()) == "" {
 				return p, nil // Don't proceed with empty title
@@ -656,39 +1402,257 @@ func (p *TaskCfgPage) updateEditDescMode(msg tea.Msg) (Page, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc":
-			p.editingTaskID = ""
+			p.cancelEdit()
+			return p, nil
+		case "enter":
+			p.mode = taskCfgModeEditURL
+			p.urlErr = nil
+			p.urlInput.Focus()
+			return p, textinput.Blink
+		}
+	}
+
+	var cmd tea.Cmd
+	p.descInput, cmd = p.descInput.Update(msg)
+	return p, cmd
+}
+
+func (p *TaskCfgPage) updateEditURLMode(msg tea.Msg) (Page, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			p.cancelEdit()
+			return p, nil
+		case "tab":
+			if _, err := parseTaskURL(p.urlInput.Value()); err != nil {
+				p.urlErr = err
+				return p, nil
+			}
+			p.urlInput.Blur()
+			p.mode = taskCfgModeEditCreated
+			p.createdErr = nil
+			p.createdInput.Focus()
+			return p, textinput.Blink
+		case "enter":
+			return p, p.saveEdit()
+		}
+	}
+
+	var cmd tea.Cmd
+	p.urlInput, cmd = p.urlInput.Update(msg)
+	return p, cmd
+}
+
+func (p *TaskCfgPage) updateEditCreatedMode(msg tea.Msg) (Page, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			p.cancelEdit()
+			return p, nil
+		case "shift+tab":
+			p.createdInput.Blur()
+			p.mode = taskCfgModeEditURL
+			p.urlInput.Focus()
+			return p, textinput.Blink
+		case "enter":
+			return p, p.saveEdit()
+		}
+	}
+
+	var cmd tea.Cmd
+	p.createdInput, cmd = p.createdInput.Update(msg)
+	return p, cmd
+}
+
+// saveEdit checks the edit inputs and returns the command to save them,
+// leaving edit mode. If an input is invalid it shows why and stays put.
+func (p *TaskCfgPage) saveEdit() tea.Cmd {
+	link, err := parseTaskURL(p.urlInput.Value())
+	if err != nil {
+		p.urlErr = err
+		p.createdInput.Blur()
+		p.mode = taskCfgModeEditURL
+		p.urlInput.Focus()
+		return nil
+	}
+	// The creation date is only written when changed, so an untouched one
+	// keeps its time of day.
+	var created time.Time
+	if p.createdInput.Value() != p.originalCreated {
+		created, err = parseCreatedDate(p.createdInput.Value(), homeNow())
+		if err != nil {
+			p.createdErr = err
+			return nil
+		}
+	}
+	taskID := p.editingTaskID
+	title := strings.TrimSpace(p.titleInput.Value())
+	desc := strings.TrimSpace(p.descInput.Value())
+	p.editingTaskID = ""
+	p.createdInput.Blur()
+	p.mode = taskCfgModeList
+	return updateTaskDefinitionCmd(p.db, taskID, title, desc, link, created)
+}
+
+// taskCfgConfirmDelete identifies the delete confirmation's result.
+const taskCfgConfirmDelete = "delete"
+
+// confirmDelete asks before deleting item, warning that a purge can't be
+// undone.
+func (p *TaskCfgPage) confirmDelete(item TaskDefinition) {
+	p.confirm = newConfirmDialog(taskCfgConfirmDelete, item.id, "Delete Task",
+		fmt.Sprintf("Are you sure you want to delete \"%s\"?", item.title))
+	if p.hardDelete {
+		p.confirm.body = fmt.Sprintf("Are you sure you want to permanently delete \"%s\"?", item.title)
+		p.confirm.warning = "This also erases its entire completion history and cannot be undone."
+	}
+	p.mode = taskCfgModeConfirm
+}
+
+// updateConfirmMode passes keys to the open confirmation until it is
+// answered; its result comes back as a confirmResultMsg.
+func (p *TaskCfgPage) updateConfirmMode(msg tea.Msg) (Page, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+	cmd, answered := p.confirm.update(keyMsg)
+	if answered {
+		p.confirm = nil
+		p.mode = taskCfgModeList
+	}
+	return p, cmd
+}
+
+func (p *TaskCfgPage) updateConfirmDeactivateMode(msg tea.Msg) (Page, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "y", "Y":
+			taskID := p.pendingDeactivate.id
+			p.pendingDeactivate = TaskDefinition{}
+			p.mode = taskCfgModeList
+			if idx, item, ok := p.taskByID(taskID); ok && item.active {
+				return p, p.setActive(idx, item, false)
+			}
+		case "n", "N", "esc":
+			p.pendingDeactivate = TaskDefinition{}
+			p.mode = taskCfgModeList
+		}
+	}
+	return p, nil
+}
+
+func (p *TaskCfgPage) updatePauseMode(msg tea.Msg) (Page, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			p.pauseTaskID = ""
+			p.pauseInput.Blur()
+			p.mode = taskCfgModeList
+			return p, nil
+		case "enter":
+			until, err := parsePauseUntil(p.pauseInput.Value(), homeNow())
+			if err != nil {
+				p.pauseErr = err
+				return p, nil
+			}
+			taskID := p.pauseTaskID
+			p.pauseTaskID = ""
+			p.pauseInput.Blur()
+			p.mode = taskCfgModeList
+			return p, setTaskPauseCmd(p.db, taskID, until)
+		}
+	}
+
+	var cmd tea.Cmd
+	p.pauseInput, cmd = p.pauseInput.Update(msg)
+	return p, cmd
+}
+
+func (p *TaskCfgPage) updateTargetMode(msg tea.Msg) (Page, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			p.targetTaskID = ""
+			p.targetInput.Blur()
+			p.mode = taskCfgModeList
+			return p, nil
+		case "enter":
+			target, err := parseTaskTarget(p.targetInput.Value())
+			if err != nil {
+				p.targetErr = err
+				return p, nil
+			}
+			taskID := p.targetTaskID
+			p.targetTaskID = ""
+			p.targetInput.Blur()
+			p.mode = taskCfgModeList
+			return p, setTaskTargetCmd(p.db, taskID, target)
+		}
+	}
+
+	var cmd tea.Cmd
+	p.targetInput, cmd = p.targetInput.Update(msg)
+	return p, cmd
+}
+
+// updateRenameMode saves the new title on enter, keeping everything else
+// about the task. Esc drops it without asking, as there is only the one
+// field to lose.
+func (p *TaskCfgPage) updateRenameMode(msg tea.Msg) (Page, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			p.titleInput.Blur()
 			p.mode = taskCfgModeList
 			return p, nil
 		case "enter":
-			taskID := p.editingTaskID
-			active := p.editingTaskActive
 			title := strings.TrimSpace(p.titleInput.Value())
-			desc := strings.TrimSpace(p.descInput.Value())
-			p.editingTaskID = ""
+			if title == "" {
+				return p, nil // Don't save an empty title
+			}
+			p.titleInput.Blur()
 			p.mode = taskCfgModeList
-			return p, updateTaskDefinitionCmd(p.db, taskID, title, desc, active)
+			t := p.renameTask
+			if title == t.title {
+				return p, nil
+			}
+			return p, updateTaskDefinitionCmd(p.db, t.id, title, t.description, t.url, time.Time{})
 		}
 	}
 
 	var cmd tea.Cmd
-	p.descInput, cmd = p.descInput.Update(msg)
+	p.titleInput, cmd = p.titleInput.Update(msg)
 	return p, cmd
 }
 
-func (p *TaskCfgPage) updateConfirmDeleteMode(msg tea.Msg) (Page, tea.Cmd) {
+func (p *TaskCfgPage) updateConfirmDiscardMode(msg tea.Msg) (Page, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "y", "Y":
-			taskID := p.pendingDeleteID
-			p.pendingDeleteID = ""
-			p.pendingDeleteTitle = ""
+			p.editingTaskID = ""
 			p.mode = taskCfgModeList
-			return p, softDeleteTaskCmd(p.db, taskID)
 		case "n", "N", "esc":
-			p.pendingDeleteID = ""
-			p.pendingDeleteTitle = ""
-			p.mode = taskCfgModeList
+			// Resume editing where we left off
+			p.mode = p.discardReturnMode
+			switch p.mode {
+			case taskCfgModeEditDesc:
+				p.descInput.Focus()
+			case taskCfgModeEditURL:
+				p.urlInput.Focus()
+			case taskCfgModeEditCreated:
+				p.createdInput.Focus()
+			default:
+				p.titleInput.Focus()
+			}
+			return p, textinput.Blink
 		}
 	}
 	return p, nil
@@ -700,12 +1664,33 @@ func (p *TaskCfgPage) View() string {
 		return p.viewAddTitle()
 	case taskCfgModeAddDesc:
 		return p.viewAddDesc()
+	case taskCfgModeAddURL:
+		return p.viewURL("Add New Task")
+	case taskCfgModeCapture:
+		return p.viewCapture()
 	case taskCfgModeEditTitle:
 		return p.viewEditTitle()
 	case taskCfgModeEditDesc:
 		return p.viewEditDesc()
-	case taskCfgModeConfirmDelete:
-		return p.viewConfirmDelete()
+	case taskCfgModeEditURL:
+		return p.viewURL("Edit Task")
+	case taskCfgModeEditCreated:
+		return p.viewEditCreated()
+	case taskCfgModeConfirm:
+		return p.confirm.View()
+	case taskCfgModeConfirmDeactivate:
+		return p.viewConfirmDeactivate()
+	case taskCfgModeConfirmDiscard:
+		return p.viewConfirmDiscard()
+	case taskCfgModePause:
+		return p.viewPause()
+	case taskCfgModeTarget:
+		return p.viewTarget()
+	case taskCfgModeRename:
+		return p.viewRename()
+	}
+	if p.loadErr != nil {
+		return renderLoadError("task definitions", p.loadErr)
 	}
 	return p.list.View()
 }
@@ -719,12 +1704,21 @@ func (p *TaskCfgPage) viewAddTitle() string {
 
 func (p *TaskCfgPage) viewAddDesc() string {
 	return fmt.Sprintf(
-		"Add New Task\n\nTitle: %s\n\nDescription:\n%s\n\n(enter to save, esc to cancel)",
+		"Add New Task\n\nTitle: %s\n\nDescription:\n%s\n\n(enter to continue, esc to cancel)",
 		p.titleInput.Value(),
 		p.descInput.View(),
 	)
 }
 
+func (p *TaskCfgPage) viewCapture() string {
+	return fmt.Sprintf(
+		"Capture to Inbox\n\nTitle:\n%s\n\n"+
+			"Inbox tasks stay off Today until you schedule them (t).\n\n"+
+			"(enter to save, esc to cancel)",
+		p.titleInput.View(),
+	)
+}
+
 func (p *TaskCfgPage) viewEditTitle() string {
 	return fmt.Sprintf(
 		"Edit Task\n\nTitle:\n%s\n\n(enter to continue, esc to cancel)",
@@ -732,26 +1726,134 @@ func (p *TaskCfgPage) viewEditTitle() string {
 	)
 }
 
+func (p *TaskCfgPage) viewRename() string {
+	return fmt.Sprintf(
+		"Rename Task\n\nTitle:\n%s\n\n(enter to save, esc to cancel)",
+		p.titleInput.View(),
+	)
+}
+
 func (p *TaskCfgPage) viewEditDesc() string {
 	return fmt.Sprintf(
-		"Edit Task\n\nTitle: %s\n\nDescription:\n%s\n\n(enter to save, esc to cancel)",
+		"Edit Task\n\nTitle: %s\n\nDescription:\n%s\n\n(enter to continue, esc to cancel)",
 		p.titleInput.Value(),
 		p.descInput.View(),
 	)
 }
 
-func (p *TaskCfgPage) viewConfirmDelete() string {
+// viewURL shows the last step of adding or editing a task, under heading.
+// When editing, tab goes on to the optional creation date.
+func (p *TaskCfgPage) viewURL(heading string) string {
+	hint := "(enter to save, esc to cancel)"
+	if p.mode == taskCfgModeEditURL {
+		hint = "(enter to save, tab to change the creation date, esc to cancel)"
+	}
+	view := fmt.Sprintf(
+		"%s\n\nTitle: %s\n\nLink (opened with o on Today):\n%s\n\n%s",
+		heading,
+		p.titleInput.Value(),
+		p.urlInput.View(),
+		hint,
+	)
+	if p.urlErr != nil {
+		view += "\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render(p.urlErr.Error())
+	}
+	return view
+}
+
+func (p *TaskCfgPage) viewEditCreated() string {
+	view := fmt.Sprintf(
+		"Edit Task\n\nTitle: %s\n\nCreated:\n%s\n\n"+
+			"Tasks are listed oldest first, and History doesn't count days before\n"+
+			"this one as missed.\n\n"+
+			"(enter to save, shift+tab for the link, esc to cancel)",
+		p.titleInput.Value(),
+		p.createdInput.View(),
+	)
+	if p.createdErr != nil {
+		view += "\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render(p.createdErr.Error())
+	}
+	return view
+}
+
+func (p *TaskCfgPage) viewConfirmDeactivate() string {
+	r := p.deactivateRecord
+	noun := "completions"
+	if r.completions == 1 {
+		noun = "completion"
+	}
 	return fmt.Sprintf(
-		"Delete Task\n\nAre you sure you want to delete \"%s\"?\n\n(y to confirm, n or esc to cancel)",
-		p.pendingDeleteTitle,
+		"Deactivate Task\n\n\"%s\" has %d %s and a %d-day best streak.\n\n"+
+			"Deactivating hides it from Today and History; its history is kept\n"+
+			"and it can be reactivated at any time. Deactivate it?\n\n"+
+			"(y to confirm, n or esc to cancel)",
+		p.pendingDeactivate.title,
+		r.completions, noun,
+		r.longest,
+	)
+}
+
+func (p *TaskCfgPage) viewPause() string {
+	view := fmt.Sprintf(
+		"Pause Task\n\nPause \"%s\" from today until:\n%s\n\n"+
+			"Paused days are hidden from Today, left blank in History\nand don't break streaks.\n\n"+
+			"(enter to save, esc to cancel)",
+		p.pauseTaskTitle,
+		p.pauseInput.View(),
+	)
+	if p.pauseErr != nil {
+		view += "\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render(p.pauseErr.Error())
+	}
+	return view
+}
+
+func (p *TaskCfgPage) viewTarget() string {
+	view := fmt.Sprintf(
+		"Times a Day\n\nHow many times a day is \"%s\" done?\n%s\n\n"+
+			"Above 1, space on Today counts up to the target and the day\ncounts as done once it is reached.\n\n"+
+			"(enter to save, esc to cancel)",
+		p.targetTaskTitle,
+		p.targetInput.View(),
+	)
+	if p.targetErr != nil {
+		view += "\n\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Render(p.targetErr.Error())
+	}
+	return view
+}
+
+// DebugLayout implements LayoutDebugger.
+func (p *TaskCfgPage) DebugLayout() []LayoutValue {
+	return []LayoutValue{
+		{"listW", p.list.Width()},
+		{"listH", p.list.Height()},
+	}
+}
+
+func (p *TaskCfgPage) viewConfirmDiscard() string {
+	return fmt.Sprintf(
+		"Edit Task\n\nDiscard changes to \"%s\"?\n\n(y to discard, n or esc to keep editing)",
+		p.originalTitle,
 	)
 }
 
 func (p *TaskCfgPage) KeyMap() []key.Binding {
-	return []key.Binding{
+	if p.mode == taskCfgModeList && p.loadErr != nil {
+		return []key.Binding{taskCfgKeys.Retry}
+	}
+	keys := []key.Binding{
 		taskCfgKeys.Add,
+		taskCfgKeys.Inbox,
+	}
+	if item, ok := p.list.SelectedItem().(TaskDefinition); ok && item.inbox {
+		keys = append(keys, taskCfgKeys.Triage)
+	}
+	return append(keys,
 		taskCfgKeys.Edit,
+		taskCfgKeys.Rename,
 		taskCfgKeys.Toggle,
+		taskCfgKeys.Note,
+		taskCfgKeys.Pause,
+		taskCfgKeys.Target,
 		taskCfgKeys.Delete,
-	}
+	)
 }
@@ -0,0 +1,54 @@
+package pages
+
+import (
+	"testing"
+	"time"
+
+	"stet.codes/tui/config"
+)
+
+// An edit only changes the task's title, description, link and creation
+// date; whether it is active is left as it was, in the database and in the
+// list.
+func TestEditKeepsActive(t *testing.T) {
+	for _, active := range []bool{false, true} {
+		db := openTestDB(t)
+		if _, err := db.Exec(`
+			INSERT INTO task_definitions (id, title, description, active, deleted)
+			VALUES ('t1', 'Old title', '', ?, false)
+		`, active); err != nil {
+			t.Fatal(err)
+		}
+
+		p := NewTaskCfgPage(db, config.Default())
+		p.Update(loadTaskDefinitionsCmd(db)())
+		if n := len(p.list.Items()); n != 1 {
+			t.Fatalf("loaded %d tasks, want 1", n)
+		}
+
+		for _, created := range []time.Time{{}, time.Date(2024, 3, 1, 9, 0, 0, 0, homeLoc)} {
+			msg := updateTaskDefinitionCmd(db, "t1", "New title", "desc", "", created)()
+			edited, ok := msg.(taskEditedMsg)
+			if !ok {
+				t.Fatalf("got %#v, want taskEditedMsg", msg)
+			}
+
+			var got bool
+			if err := db.QueryRow(`SELECT active FROM task_definitions WHERE id = 't1'`).Scan(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got != active {
+				t.Errorf("active %v, created %v: database has active = %v after the edit", active, created, got)
+			}
+
+			p.Update(edited)
+			task := p.list.Items()[0].(TaskDefinition)
+			if task.active != active {
+				t.Errorf("active %v, created %v: list has active = %v after the edit", active, created, task.active)
+			}
+			if task.title != "New title" || task.description != "desc" {
+				t.Errorf("active %v, created %v: list has %q, %q after the edit", active, created, task.title, task.description)
+			}
+		}
+	}
+}
@@ -2,13 +2,20 @@ package pages
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
+
+	"stet.codes/tui/browser"
+	"stet.codes/tui/config"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
@@ -24,6 +31,26 @@ type Task struct {
 	title       string
 	description string
 	completed   bool
+	completedAt time.Time // zero when incomplete or when the time is unknown
+	promptNote  bool      // ask for a note after completing
+	url         string    // opened with o; "" if none
+
+	// satisfiedWeek marks the task as done for the current week; it is
+	// de-emphasized until the week rolls over.
+	satisfiedWeek bool
+
+	// streak is the current streak (see loadTaskStreaks), counting today
+	// once completed.
+	streak int
+
+	// doneYesterday is whether the task was completed yesterday, which Y
+	// toggles.
+	doneYesterday bool
+
+	// count is how many times a counter task has been done today, out of
+	// target; see counter.
+	count  int
+	target int
 }
 
 func (t Task) FilterValue() string { return t.title }
@@ -32,6 +59,40 @@ func (t Task) Description() string { return t.description }
 
 func (t *Task) ToggleCompleted() {
 	t.completed = !t.completed
+	if t.completed {
+		t.completedAt = homeNow()
+		t.streak++
+	} else {
+		t.completedAt = time.Time{}
+		t.streak = max(t.streak-1, 0)
+	}
+}
+
+// counter reports whether the task is counted up to a daily target, e.g.
+// "drink water" 8 times, rather than simply done or not.
+func (t Task) counter() bool {
+	return t.target > 1
+}
+
+// addCount counts a counter task step more times today, not going below
+// zero, and completes or uncompletes it as the count crosses its target. It
+// reports whether the count changed.
+func (t *Task) addCount(step int) bool {
+	count := max(t.count+step, 0)
+	if count == t.count {
+		return false
+	}
+	t.count = count
+	if (count >= t.target) != t.completed {
+		t.ToggleCompleted()
+	}
+	return true
+}
+
+// streakAtRisk reports whether the task has a streak of at least minDays
+// that ends today unless it is completed. Zero minDays never warns.
+func (t Task) streakAtRisk(minDays int) bool {
+	return minDays > 0 && !t.completed && !t.satisfiedWeek && t.streak >= minDays
 }
 
 /**
@@ -53,23 +114,22 @@ type taskCompletionSaveFailedMsg struct {
 
 // saveTaskCompletionCmd persists the task completion state to the database.
 // If completed is true, inserts a row into task_history for today.
-// If completed is false, deletes the row for today.
-func saveTaskCompletionCmd(db *sql.DB, taskID string, completed bool) tea.Cmd {
+// If completed is false, deletes the row for today. Today is the day the
+// command was created, as the History page would show it (see todayKey).
+// Once saved, the change is posted to the completion webhook, if any.
+func saveTaskCompletionCmd(db *sql.DB, taskID, title string, completed bool) tea.Cmd {
+	now := homeNow()
+	seq := nextCompletionSeq()
 	return func() tea.Msg {
 		var err error
 		if completed {
-			// Insert completion for today (ignore if already exists)
-			_, err = db.Exec(`
-				INSERT INTO task_history (id, task_id, completed_date)
-				VALUES (lower(hex(randomblob(16))), ?, date('now', 'localtime'))
-				ON CONFLICT(task_id, completed_date) DO NOTHING
-			`, taskID)
+			err = completeTaskDay(db, taskID, dateKey(now), completedAtKey(now))
 		} else {
 			// Remove completion for today
 			_, err = db.Exec(`
 				DELETE FROM task_history
-				WHERE task_id = ? AND completed_date = date('now', 'localtime')
-			`, taskID)
+				WHERE task_id = ? AND completed_date = ?
+			`, taskID, dateKey(now))
 		}
 
 		if err != nil {
@@ -79,6 +139,7 @@ func saveTaskCompletionCmd(db *sql.DB, taskID string, completed bool) tea.Cmd {
 				err:       err,
 			}
 		}
+		notifyCompletion(seq, taskID, title, dateKey(now), completed)
 		return taskCompletionSavedMsg{
 			taskID:    taskID,
 			completed: completed,
@@ -86,6 +147,246 @@ func saveTaskCompletionCmd(db *sql.DB, taskID string, completed bool) tea.Cmd {
 	}
 }
 
+// completeTaskDay records a task as done on date, at its full target, or
+// tops up a row already counted part of the way there. completedAt is ""
+// when the time of day isn't known, e.g. for a backfill: the row is stamped
+// at midnight and marked backfilled.
+func completeTaskDay(db *sql.DB, taskID, date, completedAt string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := completeTaskDayTx(tx, taskID, date, completedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// completeTaskDayTx is completeTaskDay within tx.
+func completeTaskDayTx(tx *sql.Tx, taskID, date, completedAt string) error {
+	_, err := tx.Exec(`
+		UPDATE task_history
+		SET count = target,
+		    completed_at = COALESCE(NULLIF(?3, ''), ?2 || ' 00:00:00'),
+		    backfilled = (?3 = '')
+		WHERE task_id = ?1 AND completed_date = ?2 AND count < target
+	`, taskID, date, completedAt)
+	if err != nil {
+		return err
+	}
+	// Checked explicitly rather than with ON CONFLICT so a retried or
+	// doubled toggle can't add a second row even without the unique index.
+	_, err = tx.Exec(`
+		INSERT INTO task_history (id, task_id, completed_date, completed_at, backfilled, count, target)
+		SELECT lower(hex(randomblob(16))), id, ?2, COALESCE(NULLIF(?3, ''), ?2 || ' 00:00:00'), ?3 = '',
+		       target, target
+		FROM task_definitions
+		WHERE id = ?1 AND NOT EXISTS (
+			SELECT 1 FROM task_history
+			WHERE task_id = ?1 AND completed_date = ?2
+		)
+	`, taskID, date, completedAt)
+	return err
+}
+
+// taskCountSavedMsg indicates a counter task's count for today was written.
+type taskCountSavedMsg struct {
+	taskID        string
+	count, target int
+}
+
+// taskCountSaveFailedMsg indicates writing a counter task's count failed.
+type taskCountSaveFailedMsg struct {
+	taskID string
+	err    error
+}
+
+// saveTaskCountCmd counts a counter task step times more today (or fewer,
+// when negative), out of target. The step is applied to the stored count
+// rather than writing the count the UI shows, so saves from quick presses
+// add up whatever order they land in. The row is removed at zero;
+// completed_at is the time the target was reached, and cleared if the count
+// drops below it again. Reaching the target, or dropping below it, is posted
+// to the completion webhook, if any.
+func saveTaskCountCmd(db *sql.DB, taskID, title string, step, target int) tea.Cmd {
+	now := homeNow()
+	seq := nextCompletionSeq()
+	return func() tea.Msg {
+		before, count, err := saveTaskCount(db, taskID, dateKey(now), completedAtKey(now), step, target)
+		if err != nil {
+			return taskCountSaveFailedMsg{taskID: taskID, err: err}
+		}
+		if (before >= target) != (count >= target) {
+			notifyCompletion(seq, taskID, title, dateKey(now), count >= target)
+		}
+		return taskCountSavedMsg{taskID: taskID, count: count, target: target}
+	}
+}
+
+// saveTaskCount applies step to a task's count on date and returns the
+// count before and after. The count before is worked out from the step, so
+// is exact unless a step of more than one was clamped at zero; the UI steps
+// by one.
+func saveTaskCount(db *sql.DB, taskID, date, completedAt string, step, target int) (before, count int, err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		UPDATE task_history
+		SET count = max(count + ?3, 0), target = ?4,
+		    completed_at = CASE WHEN count + ?3 >= ?4 THEN COALESCE(completed_at, ?5) END
+		WHERE task_id = ?1 AND completed_date = ?2
+		RETURNING count
+	`, taskID, date, step, target, completedAt).Scan(&count)
+	before = max(count-step, 0)
+	switch {
+	case err == sql.ErrNoRows:
+		before, count = 0, max(step, 0)
+		if count > 0 {
+			_, err = tx.Exec(`
+				INSERT INTO task_history (id, task_id, completed_date, completed_at, count, target)
+				VALUES (lower(hex(randomblob(16))), ?1, ?2, CASE WHEN ?3 >= ?4 THEN ?5 END, ?3, ?4)
+			`, taskID, date, count, target, completedAt)
+		} else {
+			err = nil
+		}
+	case err == nil && count == 0:
+		_, err = tx.Exec(`
+			DELETE FROM task_history
+			WHERE task_id = ? AND completed_date = ?
+		`, taskID, date)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return before, count, tx.Commit()
+}
+
+// completionNoteSavedMsg indicates a completion note was written.
+type completionNoteSavedMsg struct {
+	taskID string
+}
+
+// completionNoteSaveFailedMsg indicates writing a completion note failed.
+type completionNoteSaveFailedMsg struct {
+	taskID string
+	err    error
+}
+
+// errNoCompletion reports a note for a task that isn't completed today, e.g.
+// because it was unchecked, or the completion failed to save, while the
+// note was being typed.
+var errNoCompletion = errors.New("task is not completed today")
+
+// saveCompletionNoteCmd attaches a note to today's completion of a task.
+func saveCompletionNoteCmd(db *sql.DB, taskID, note string) tea.Cmd {
+	return func() tea.Msg {
+		if err := saveCompletionNote(db, taskID, note); err != nil {
+			return completionNoteSaveFailedMsg{taskID: taskID, err: err}
+		}
+		return completionNoteSavedMsg{taskID: taskID}
+	}
+}
+
+// saveCompletionNote sets the note on today's completion of a task. It
+// never creates a completion: without one it returns errNoCompletion.
+func saveCompletionNote(db *sql.DB, taskID, note string) error {
+	res, err := db.Exec(`
+		UPDATE task_history SET note = ?
+		WHERE task_id = ? AND completed_date = ?
+	`, note, taskID, todayKey())
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoCompletion
+	}
+	return nil
+}
+
+// weekSatisfiedSavedMsg indicates the done-for-the-week marker was written.
+type weekSatisfiedSavedMsg struct {
+	taskID    string
+	satisfied bool
+}
+
+// weekSatisfiedSaveFailedMsg indicates writing the done-for-the-week marker failed.
+type weekSatisfiedSaveFailedMsg struct {
+	taskID    string
+	satisfied bool
+	err       error
+}
+
+// saveWeekSatisfiedCmd marks a task as done for the current week, or clears
+// the marker. The stored week start makes it lapse on its own at week start.
+func saveWeekSatisfiedCmd(db *sql.DB, taskID string, satisfied bool) tea.Cmd {
+	return func() tea.Msg {
+		var week any
+		if satisfied {
+			week = currentWeekKey()
+		}
+		_, err := db.Exec(`
+			UPDATE task_definitions SET satisfied_week = ? WHERE id = ?
+		`, week, taskID)
+		if err != nil {
+			return weekSatisfiedSaveFailedMsg{taskID: taskID, satisfied: satisfied, err: err}
+		}
+		return weekSatisfiedSavedMsg{taskID: taskID, satisfied: satisfied}
+	}
+}
+
+// yesterdayCompletionSavedMsg indicates a task's completion for yesterday
+// was written.
+type yesterdayCompletionSavedMsg struct {
+	taskID    string
+	date      string
+	completed bool
+}
+
+// yesterdayCompletionSaveFailedMsg indicates writing a task's completion for
+// yesterday failed.
+type yesterdayCompletionSaveFailedMsg struct {
+	taskID    string
+	completed bool
+	err       error
+}
+
+// saveYesterdayCompletionCmd marks a task completed or not on date, the
+// day before today, as History does.
+func saveYesterdayCompletionCmd(db *sql.DB, taskID, date string, completed bool) tea.Cmd {
+	return func() tea.Msg {
+		err := saveHistoryCompletions(db, []historyWrite{{taskID: taskID, date: date, completed: completed}})
+		if err != nil {
+			return yesterdayCompletionSaveFailedMsg{taskID: taskID, completed: completed, err: err}
+		}
+		return yesterdayCompletionSavedMsg{taskID: taskID, date: date, completed: completed}
+	}
+}
+
+// taskURLOpenFailedMsg indicates a task's URL couldn't be opened.
+type taskURLOpenFailedMsg struct {
+	err error
+}
+
+// openTaskURLCmd opens a task's URL in the default browser.
+func openTaskURLCmd(url string) tea.Cmd {
+	return func() tea.Msg {
+		if err := browser.Open(url); err != nil {
+			return taskURLOpenFailedMsg{err: err}
+		}
+		return nil
+	}
+}
+
 // activeTasksLoadedMsg contains active tasks loaded from DB with completion status.
 type activeTasksLoadedMsg struct {
 	tasks []Task
@@ -99,68 +400,182 @@ type activeTasksLoadFailedMsg struct {
 // loadTodayDataCmd loads active, non-deleted tasks and today's completions.
 func loadTodayDataCmd(db *sql.DB) tea.Cmd {
 	return func() tea.Msg {
-		// Load active, non-deleted task definitions
-		rows, err := db.Query(`
-			SELECT id, title, description
-			FROM task_definitions
-			WHERE active = true AND deleted = false
-			ORDER BY created_at ASC
-		`)
+		tasks, err := loadTodayTasks(db)
 		if err != nil {
 			return activeTasksLoadFailedMsg{err: err}
 		}
-		defer rows.Close()
+		return activeTasksLoadedMsg{tasks: tasks}
+	}
+}
 
-		var tasks []Task
-		for rows.Next() {
-			var t Task
-			if err := rows.Scan(&t.id, &t.title, &t.description); err != nil {
-				return activeTasksLoadFailedMsg{err: err}
-			}
-			tasks = append(tasks, t)
-		}
-		if err := rows.Err(); err != nil {
-			return activeTasksLoadFailedMsg{err: err}
-		}
+// loadTodayTasks returns the tasks shown on the Today page, in creation
+// order, with today's completions and current streaks.
+func loadTodayTasks(db *sql.DB) ([]Task, error) {
+	// Load active, non-deleted task definitions that aren't paused today
+	rows, err := db.Query(`
+		SELECT id, title, description, prompt_note, url,
+		       COALESCE(satisfied_week = ?, false), target
+		FROM task_definitions
+		WHERE active = true AND deleted = false
+		  AND NOT EXISTS (
+		      SELECT 1 FROM task_pauses
+		      WHERE task_id = task_definitions.id
+		        AND ? BETWEEN start_date AND end_date
+		  )
+		ORDER BY created_at ASC
+	`, currentWeekKey(), todayKey())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-		// Load today's completions
-		compRows, err := db.Query(`
-			SELECT task_id FROM task_history
-			WHERE completed_date = date('now', 'localtime')
-		`)
-		if err != nil {
-			return activeTasksLoadFailedMsg{err: err}
+	var tasks []Task
+	for rows.Next() {
+		var t Task
+		if err := rows.Scan(&t.id, &t.title, &t.description, &t.promptNote, &t.url, &t.satisfiedWeek, &t.target); err != nil {
+			return nil, err
 		}
-		defer compRows.Close()
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-		completedIDs := make(map[string]bool)
-		for compRows.Next() {
-			var taskID string
-			if err := compRows.Scan(&taskID); err != nil {
-				return activeTasksLoadFailedMsg{err: err}
-			}
-			completedIDs[taskID] = true
+	// Load today's completions, and counts short of their target.
+	// completed_at is formatted explicitly so it scans as local wall-clock
+	// text rather than a UTC timestamp.
+	compRows, err := db.Query(`
+		SELECT task_id, COALESCE(strftime('%Y-%m-%d %H:%M:%S', completed_at), ''),
+		       count, count >= target
+		FROM task_history
+		WHERE completed_date = ?
+	`, todayKey())
+	if err != nil {
+		return nil, err
+	}
+	defer compRows.Close()
+
+	type todayRow struct {
+		completedAt time.Time
+		count       int
+		completed   bool
+	}
+	today := make(map[string]todayRow)
+	for compRows.Next() {
+		var (
+			taskID, completedAt string
+			row                 todayRow
+		)
+		if err := compRows.Scan(&taskID, &completedAt, &row.count, &row.completed); err != nil {
+			return nil, err
 		}
-		if err := compRows.Err(); err != nil {
-			return activeTasksLoadFailedMsg{err: err}
+		// A missing or unparsable time leaves the zero value, which sorts
+		// by creation order.
+		row.completedAt, _ = time.ParseInLocation("2006-01-02 15:04:05", completedAt, homeLoc)
+		today[taskID] = row
+	}
+	if err := compRows.Err(); err != nil {
+		return nil, err
+	}
+
+	yesterday := make(map[string]bool)
+	yesterdayRows, err := db.Query(`
+		SELECT task_id FROM task_completions WHERE completed_date = ?
+	`, dateKey(addDays(homeNow(), -1)))
+	if err != nil {
+		return nil, err
+	}
+	defer yesterdayRows.Close()
+	for yesterdayRows.Next() {
+		var taskID string
+		if err := yesterdayRows.Scan(&taskID); err != nil {
+			return nil, err
 		}
+		yesterday[taskID] = true
+	}
+	if err := yesterdayRows.Err(); err != nil {
+		return nil, err
+	}
 
-		// Mark tasks as completed
-		for i := range tasks {
-			if completedIDs[tasks[i].id] {
+	streaks, err := loadTaskStreaks(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mark tasks as completed
+	for i := range tasks {
+		if row, ok := today[tasks[i].id]; ok {
+			tasks[i].count = row.count
+			if row.completed {
 				tasks[i].completed = true
+				tasks[i].completedAt = row.completedAt
 			}
 		}
-
-		return activeTasksLoadedMsg{tasks: tasks}
+		tasks[i].streak = streaks[tasks[i].id]
+		tasks[i].doneYesterday = yesterday[tasks[i].id]
 	}
+
+	return tasks, nil
 }
 
 // sortTasksByCompletion moves incomplete tasks to the front, completed to the end.
-// Uses stable sort to preserve creation order within each group.
-func sortTasksByCompletion(tasks []Task) {
+// Completed tasks are ordered by completion time according to order, with
+// unknown times treated as oldest. Uses stable sort so ties keep their
+// existing (creation) order.
+func sortTasksByCompletion(tasks []Task, order config.CompletedOrder) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if a.completed != b.completed {
+			return !a.completed
+		}
+		if !a.completed {
+			return false
+		}
+		switch order {
+		case config.CompletedOrderRecentLast:
+			return a.completedAt.Before(b.completedAt)
+		case config.CompletedOrderRecentFirst:
+			return a.completedAt.After(b.completedAt)
+		}
+		return false
+	})
+}
+
+// todayOrder is how the Today page orders its tasks, shared with History
+// when it is set to match.
+type todayOrder struct {
+	completed   config.CompletedOrder
+	atRiskFirst bool // sort tasks with a streak at risk to the top
+	atRiskMin   int
+}
+
+func newTodayOrder(cfg config.Config) todayOrder {
+	return todayOrder{
+		completed:   cfg.CompletedOrder,
+		atRiskFirst: cfg.StreakAtRiskFirst,
+		atRiskMin:   cfg.StreakAtRiskMin,
+	}
+}
+
+// sort orders tasks for display: incomplete first and, if configured,
+// streaks at risk before other incomplete tasks.
+func (o todayOrder) sort(tasks []Task) {
+	sortTasksByCompletion(tasks, o.completed)
+	if o.atRiskFirst {
+		sortAtRiskFirst(tasks, o.atRiskMin)
+	}
+}
+
+// sortAtRiskFirst moves incomplete tasks with a streak at risk (see
+// Task.streakAtRisk) to the front, longest streak first, keeping the order
+// of everything else.
+func sortAtRiskFirst(tasks []Task, minDays int) {
 	sort.SliceStable(tasks, func(i, j int) bool {
-		return !tasks[i].completed && tasks[j].completed
+		a, b := tasks[i].streakAtRisk(minDays), tasks[j].streakAtRisk(minDays)
+		if a != b {
+			return a
+		}
+		return a && tasks[i].streak > tasks[j].streak
 	})
 }
 
@@ -173,6 +588,33 @@ const ellipsis = "…"
 // taskDelegate embeds list.DefaultDelegate and overrides Render to show a checkbox.
 type taskDelegate struct {
 	list.DefaultDelegate
+
+	// showNumbers prefixes the first nine tasks on the page with the digit
+	// that toggles them in quick complete mode.
+	showNumbers bool
+
+	// atRiskMin is the shortest streak flagged as at risk; zero disables
+	// the flag.
+	atRiskMin int
+}
+
+var streakAtRiskStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+
+var todayFilterStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+
+var todayYesterdayStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
+
+// taskLinkGlyph marks tasks with a URL, here and on the Configure page.
+const taskLinkGlyph = "↗"
+
+// checkboxAt reports whether column x of a rendered row is its checkbox,
+// which follows the row's left padding and any quick complete number.
+func (d *taskDelegate) checkboxAt(x int) bool {
+	start := d.Styles.NormalTitle.GetPaddingLeft()
+	if d.showNumbers {
+		start += 2
+	}
+	return x == start
 }
 
 func (d *taskDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
@@ -194,14 +636,58 @@ func (d *taskDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 		return
 	}
 
-	// Determine checkbox glyph (filled box for completed, empty box for not)
+	// Determine checkbox glyph (filled box for completed, half filled for a
+	// counter part way to its target, empty box for not)
 	checkbox := "□"
 	if t.completed {
 		checkbox = "■"
+	} else if t.satisfiedWeek {
+		checkbox = "▣"
+	} else if t.counter() && t.count > 0 {
+		checkbox = "◧"
+	}
+	// Done for the week: render like an inactive item until the week rolls over
+	deemphasize := t.satisfiedWeek && !t.completed
+
+	// Quick complete number, relative to the current list page
+	var number string
+	if d.showNumbers {
+		number = "  "
+		if pos := index - m.Paginator.Page*m.Paginator.PerPage + 1; pos >= 1 && pos <= 9 {
+			number = fmt.Sprintf("%d ", pos)
+		}
+	}
+
+	// Streak at risk flag, shown after the title
+	var atRisk string
+	if t.streakAtRisk(d.atRiskMin) {
+		atRisk = fmt.Sprintf(" 🔥%d at risk", t.streak)
+	}
+
+	// Link marker for tasks that open a URL with o
+	var link string
+	if t.url != "" {
+		link = " " + taskLinkGlyph
+	}
+
+	// Today's count for counter tasks, e.g. "5/8"
+	var progress string
+	if t.counter() {
+		progress = fmt.Sprintf(" %d/%d", t.count, t.target)
+	}
+
+	// Yesterday's state on the selected row, which Y toggles
+	var yesterday string
+	if index == m.Index() {
+		yesterday = " ✗ yesterday"
+		if t.doneYesterday {
+			yesterday = " ✓ yesterday"
+		}
 	}
 
 	// Calculate text width (same as default, no extra reservation needed since checkbox is prepended)
-	textwidth := m.Width() - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight()
+	textwidth := m.Width() - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight() - len(number) -
+		ansi.StringWidth(progress) - ansi.StringWidth(link) - ansi.StringWidth(atRisk) - ansi.StringWidth(yesterday)
 	if textwidth < 1 {
 		textwidth = 1
 	}
@@ -233,10 +719,10 @@ func (d *taskDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 	}
 
 	// Prepend checkbox to title so it appears inside the styled block (after the │ border)
-	title = checkbox + " " + title
+	title = number + checkbox + " " + title + progress + link
 
 	// Apply styles based on state
-	if emptyFilter {
+	if emptyFilter || (deemphasize && !isSelected) {
 		title = s.DimmedTitle.Render(title)
 		desc = s.DimmedDesc.Render(desc)
 	} else if isSelected && m.FilterState() != list.Filtering {
@@ -257,6 +743,11 @@ func (d *taskDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 		desc = s.NormalDesc.Render(desc)
 	}
 
+	if atRisk != "" {
+		title += streakAtRiskStyle.Render(atRisk)
+	}
+	title += todayYesterdayStyle.Render(yesterday)
+
 	// Render title (with checkbox inside) and description
 	if d.ShowDescription {
 		fmt.Fprintf(w, "%s\n%s", title, desc)
@@ -265,8 +756,8 @@ func (d *taskDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 	}
 }
 
-func newTaskDelegate() *taskDelegate {
-	return &taskDelegate{DefaultDelegate: list.NewDefaultDelegate()}
+func newTaskDelegate(atRiskMin int) *taskDelegate {
+	return &taskDelegate{DefaultDelegate: list.NewDefaultDelegate(), atRiskMin: atRiskMin}
 }
 
 /**
@@ -275,7 +766,17 @@ func newTaskDelegate() *taskDelegate {
 
 // todayKeyMap defines key bindings for the Today page.
 type todayKeyMap struct {
-	Toggle key.Binding
+	Toggle        key.Binding
+	CountDown     key.Binding
+	WeekDone      key.Binding
+	Yesterday     key.Binding
+	QuickNumbers  key.Binding
+	QuickComplete key.Binding
+	Open          key.Binding
+	Retry         key.Binding
+	SaveNote      key.Binding
+	CancelNote    key.Binding
+	ClearFilter   key.Binding
 }
 
 var todayKeys = todayKeyMap{
@@ -283,24 +784,96 @@ var todayKeys = todayKeyMap{
 		key.WithKeys(" "),
 		key.WithHelp("space", "toggle"),
 	),
+	CountDown: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "count down"),
+	),
+	WeekDone: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "done for week"),
+	),
+	// Terminals send shift+space as a plain space, so this can't be it
+	Yesterday: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "toggle yesterday"),
+	),
+	QuickNumbers: key.NewBinding(
+		key.WithKeys("#"),
+		key.WithHelp("#", "numbers"),
+	),
+	QuickComplete: key.NewBinding(
+		key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+		key.WithHelp("1-9", "toggle nth"),
+	),
+	Open: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "open link"),
+	),
+	Retry: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "retry"),
+	),
+	SaveNote: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "save note"),
+	),
+	CancelNote: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "skip note"),
+	),
+	// Handled by the list; listed for help while a filter is applied
+	ClearFilter: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "clear filter"),
+	),
 }
 
 // TodayPage displays today's tasks.
 type TodayPage struct {
-	tasks list.Model
-	db    *sql.DB
+	tasks    list.Model
+	delegate *taskDelegate
+	db       *sql.DB
+
+	keepCompletedInPlace bool // skip re-sorting when a task is toggled
+	order                todayOrder
+	dateLayout           string // for the day Y toggled, in its status
+
+	// Confetti in the list title after the last task is completed
+	celebrate        bool
+	celebrating      bool
+	celebrateVersion int
+
+	// Set when loading tasks fails; cleared by the next successful load
+	loadErr error
+
+	height int // the list's, plus the filter banner's line while shown
+
+	// Completion note prompt; active while noteTaskID is set
+	noteInput     textinput.Model
+	noteTaskID    string
+	noteTaskTitle string
 }
 
 // NewTodayPage creates and initializes the Today page.
-func NewTodayPage(db *sql.DB) *TodayPage {
-	delegate := newTaskDelegate()
+func NewTodayPage(db *sql.DB, cfg config.Config) *TodayPage {
+	delegate := newTaskDelegate(cfg.StreakAtRiskMin)
 	tasks := list.New([]list.Item{}, delegate, 0, 0)
-	tasks.Title = "Hit List"
+	tasks.Title = todayTitle
 	tasks.SetShowHelp(false)
 
+	ni := textinput.New()
+	ni.Placeholder = "How did it go? (optional)"
+	ni.CharLimit = 200
+
 	return &TodayPage{
-		tasks: tasks,
-		db:    db,
+		tasks:                tasks,
+		delegate:             delegate,
+		db:                   db,
+		keepCompletedInPlace: cfg.KeepCompletedInPlace,
+		order:                newTodayOrder(cfg),
+		dateLayout:           cfg.Formats.DateShort,
+		celebrate:            cfg.Celebrate,
+		noteInput:            ni,
 	}
 }
 
@@ -308,6 +881,23 @@ func (p *TodayPage) ID() PageID {
 	return TodayPageID
 }
 
+// CapturesNavigation returns true while the completion note prompt is open
+// or a filter is being typed.
+func (p *TodayPage) CapturesNavigation() bool {
+	return p.promptingNote() || p.tasks.SettingFilter()
+}
+
+// CapturesGlobalKeys returns true while the completion note prompt is open
+// or a filter is being typed, so typed characters aren't treated as global
+// shortcuts.
+func (p *TodayPage) CapturesGlobalKeys() bool {
+	return p.promptingNote() || p.tasks.SettingFilter()
+}
+
+func (p *TodayPage) promptingNote() bool {
+	return p.noteTaskID != ""
+}
+
 func (p *TodayPage) Title() Title {
 	return Title{
 		Text:  "Today",
@@ -318,7 +908,37 @@ func (p *TodayPage) Title() Title {
 func (p *TodayPage) SetSize(width, height int) {
 	contentWidth := max(width-DocStyle.GetHorizontalFrameSize(), 0)
 	p.tasks.SetWidth(contentWidth)
-	p.tasks.SetHeight(height)
+	p.height = height
+	p.fitList()
+}
+
+// filtered reports whether a filter is being typed or applied.
+func (p *TodayPage) filtered() bool {
+	state := p.tasks.FilterState()
+	return state == list.Filtering || state == list.FilterApplied
+}
+
+// fitList sizes the list to leave room for the filter banner while a
+// filter is on.
+func (p *TodayPage) fitList() {
+	height := p.height
+	if p.filtered() {
+		height--
+	}
+	if height = max(height, 0); height != p.tasks.Height() {
+		p.tasks.SetHeight(height)
+	}
+}
+
+// filterBanner says what the filter hides, and that toggling under a filter
+// leaves tasks where they are (see stepTask).
+func (p *TodayPage) filterBanner() string {
+	hidden := len(p.tasks.Items()) - len(p.tasks.VisibleItems())
+	banner := fmt.Sprintf("filtered: %q (%d hidden)", p.tasks.FilterValue(), hidden)
+	if p.tasks.FilterState() == list.FilterApplied {
+		banner += " · completed tasks stay in place · esc to clear"
+	}
+	return ansi.Truncate(todayFilterStyle.Render(banner), p.tasks.Width(), ellipsis)
 }
 
 // InitCmd loads active tasks and today's completions from the database.
@@ -326,9 +946,34 @@ func (p *TodayPage) InitCmd() tea.Cmd {
 	return loadTodayDataCmd(p.db)
 }
 
+// RefreshCmd implements Refresher by reloading today's tasks.
+func (p *TodayPage) RefreshCmd() tea.Cmd {
+	return loadTodayDataCmd(p.db)
+}
+
+// Leave implements Leaver by ending the celebration, so its title isn't
+// left on a frame of confetti while the page is away.
+func (p *TodayPage) Leave() tea.Cmd {
+	p.stopCelebration()
+	return nil
+}
+
 func (p *TodayPage) Update(msg tea.Msg) (Page, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && p.promptingNote() {
+		return p.updateNotePrompt(keyMsg)
+	}
+
 	var cmds []tea.Cmd
 
+	// Keep the note input's cursor blinking while the prompt is open
+	if p.promptingNote() {
+		var noteCmd tea.Cmd
+		p.noteInput, noteCmd = p.noteInput.Update(msg)
+		if noteCmd != nil {
+			cmds = append(cmds, noteCmd)
+		}
+	}
+
 	// First, let the list handle the message
 	var listCmd tea.Cmd
 	p.tasks, listCmd = p.tasks.Update(msg)
@@ -338,8 +983,9 @@ func (p *TodayPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case activeTasksLoadedMsg:
+		p.loadErr = nil
 		// Sort so incomplete tasks appear first
-		sortTasksByCompletion(msg.tasks)
+		p.sortTasks(msg.tasks)
 		items := make([]list.Item, len(msg.tasks))
 		for i, t := range msg.tasks {
 			items[i] = t
@@ -347,6 +993,7 @@ func (p *TodayPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		p.tasks.SetItems(items)
 
 	case activeTasksLoadFailedMsg:
+		p.loadErr = msg.err
 		cmds = append(cmds, p.tasks.NewStatusMessage(fmt.Sprintf("load failed: %v", msg.err)))
 
 	case taskCompletionSavedMsg:
@@ -357,21 +1004,57 @@ func (p *TodayPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		}
 		cmds = append(cmds, p.tasks.NewStatusMessage(statusMsg))
 
-		// DB write succeeded - nothing to do, UI already updated optimistically
+		// UI already updated optimistically; History may be showing today
+		cmds = append(cmds, func() tea.Msg { return InvalidateHistoryPageMsg{} })
 
-	case taskCompletionSaveFailedMsg:
+	case taskCountSavedMsg:
+		cmds = append(cmds, p.tasks.NewStatusMessage(fmt.Sprintf("%d of %d today", msg.count, msg.target)))
+		cmds = append(cmds, func() tea.Msg { return InvalidateHistoryPageMsg{} })
+
+	case taskCountSaveFailedMsg:
+		// Counts can be stepped several times before a write fails, so
+		// reload rather than undo one step
+		logger.Errorf("today: save count of task %s: %v", msg.taskID, msg.err)
+		p.stopCelebration()
 		cmds = append(cmds, p.tasks.NewStatusMessage(fmt.Sprintf("save failed: %v", msg.err)))
-		// DB write failed - revert the UI state and show error
+		cmds = append(cmds, loadTodayDataCmd(p.db))
+
+	case weekSatisfiedSavedMsg:
+		statusMsg := "week marker cleared"
+		if msg.satisfied {
+			statusMsg = "done for the week"
+		}
+		cmds = append(cmds, p.tasks.NewStatusMessage(statusMsg))
+
+	case weekSatisfiedSaveFailedMsg:
+		logger.Errorf("today: set task %s satisfied for the week=%t: %v", msg.taskID, msg.satisfied, msg.err)
 		for i, listItem := range p.tasks.Items() {
-			task, ok := listItem.(Task)
-			if !ok {
-				continue
+			if task, ok := listItem.(Task); ok && task.id == msg.taskID {
+				task.satisfiedWeek = !msg.satisfied // Revert
+				if setCmd := p.tasks.SetItem(i, task); setCmd != nil {
+					cmds = append(cmds, setCmd)
+				}
+				break
 			}
-			if task.id == msg.taskID {
-				// Revert: toggle back to the opposite of what we tried to save
-				task.completed = !msg.completed
-				setCmd := p.tasks.SetItem(i, task)
-				if setCmd != nil {
+		}
+		cmds = append(cmds, p.tasks.NewStatusMessage(fmt.Sprintf("save failed: %v", msg.err)))
+
+	case yesterdayCompletionSavedMsg:
+		statusMsg := "marked incomplete"
+		if msg.completed {
+			statusMsg = "marked completed"
+		}
+		date, _ := time.ParseInLocation("2006-01-02", msg.date, homeLoc)
+		cmds = append(cmds, p.tasks.NewStatusMessage(fmt.Sprintf("%s for yesterday, %s", statusMsg, date.Format(p.dateLayout))))
+		// Streaks count yesterday; History shows it
+		cmds = append(cmds, loadTodayDataCmd(p.db), func() tea.Msg { return InvalidateHistoryPageMsg{} })
+
+	case yesterdayCompletionSaveFailedMsg:
+		logger.Errorf("today: save yesterday's completion of task %s: %v", msg.taskID, msg.err)
+		for i, listItem := range p.tasks.Items() {
+			if task, ok := listItem.(Task); ok && task.id == msg.taskID {
+				task.doneYesterday = !msg.completed // Revert
+				if setCmd := p.tasks.SetItem(i, task); setCmd != nil {
 					cmds = append(cmds, setCmd)
 				}
 				break
@@ -379,74 +1062,382 @@ func (p *TodayPage) Update(msg tea.Msg) (Page, tea.Cmd) {
 		}
 		cmds = append(cmds, p.tasks.NewStatusMessage(fmt.Sprintf("save failed: %v", msg.err)))
 
-	case tea.KeyMsg:
-		if !key.Matches(msg, todayKeys.Toggle) {
+	case taskURLOpenFailedMsg:
+		cmds = append(cmds, p.tasks.NewStatusMessage(fmt.Sprintf("open failed: %v", msg.err)))
+
+	case completionNoteSavedMsg:
+		cmds = append(cmds, p.tasks.NewStatusMessage("note saved"))
+
+	case completionNoteSaveFailedMsg:
+		logger.Errorf("today: save note for task %s: %v", msg.taskID, msg.err)
+		cmds = append(cmds, p.tasks.NewStatusMessage(fmt.Sprintf("note save failed: %v", msg.err)))
+
+	case celebrateTickMsg:
+		cmds = append(cmds, p.updateCelebration(msg))
+
+	case taskCompletionSaveFailedMsg:
+		logger.Errorf("today: save completion of task %s: %v", msg.taskID, msg.err)
+		p.stopCelebration()
+		cmds = append(cmds, p.tasks.NewStatusMessage(fmt.Sprintf("save failed: %v", msg.err)))
+		// DB write failed - revert the UI state and show error
+		for i, listItem := range p.tasks.Items() {
+			if task, ok := listItem.(Task); ok && task.id == msg.taskID && task.completed == msg.completed {
+				task.ToggleCompleted() // revert, with its streak and completion time
+				if cmd := p.replaceTask(i, task); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+				break
+			}
+		}
+
+	case tea.MouseMsg:
+		// The note prompt is bound to the task it opened for
+		if p.promptingNote() || p.tasks.SettingFilter() || p.loadErr != nil {
+			break
+		}
+		if scrollList(&p.tasks, msg) || !isClick(msg) {
 			break
 		}
+		// Clicking a row selects it; clicking its checkbox also toggles it.
+		// The filter banner takes the line above the list while shown.
+		y := msg.Y
+		if p.filtered() {
+			y--
+		}
+		pos := listItemAt(p.tasks, p.delegate, y)
+		if pos < 0 {
+			break
+		}
+		p.tasks.Select(pos)
+		if idx, ok := p.itemIndex(pos); ok && p.delegate.checkboxAt(msg.X) {
+			cmds = append(cmds, p.toggleTask(idx)...)
+		}
 
-		// If the user is typing into the filter input, space should be treated as text.
+	case tea.KeyMsg:
+		p.stopCelebration()
+
+		// If the user is typing into the filter input, keys should be treated as text.
 		if p.tasks.SettingFilter() {
 			break
 		}
 
-		// Toggle task completion synchronously in Update
-		selectedIdx := p.tasks.GlobalIndex()
-		if selectedIdx < 0 || selectedIdx >= len(p.tasks.Items()) {
+		// Only retry is available until the tasks have loaded
+		if p.loadErr != nil {
+			if key.Matches(msg, todayKeys.Retry) {
+				cmds = append(cmds, loadTodayDataCmd(p.db))
+			}
 			break
 		}
 
-		item, ok := p.tasks.Items()[selectedIdx].(Task)
-		if !ok {
+		if key.Matches(msg, todayKeys.WeekDone) {
+			idx := p.tasks.GlobalIndex()
+			if idx < 0 || idx >= len(p.tasks.Items()) {
+				break
+			}
+			item, ok := p.tasks.Items()[idx].(Task)
+			if !ok {
+				break
+			}
+			// Optimistic update
+			item.satisfiedWeek = !item.satisfiedWeek
+			if setCmd := p.tasks.SetItem(idx, item); setCmd != nil {
+				cmds = append(cmds, setCmd)
+			}
+			cmds = append(cmds, saveWeekSatisfiedCmd(p.db, item.id, item.satisfiedWeek))
 			break
 		}
 
-		// Toggle state (optimistic UI update)
-		item.ToggleCompleted()
-
-		// Check if filter is active
-		isFiltered := p.tasks.FilterState() == list.Filtering ||
-			p.tasks.FilterState() == list.FilterApplied
-
-		if isFiltered {
-			// Filter active - just update the single item without re-sorting
-			// to preserve filter state (SetItems resets filter mapping)
-			setCmd := p.tasks.SetItem(selectedIdx, item)
-			if setCmd != nil {
+		if key.Matches(msg, todayKeys.Yesterday) {
+			idx := p.tasks.GlobalIndex()
+			if idx < 0 || idx >= len(p.tasks.Items()) {
+				break
+			}
+			item, ok := p.tasks.Items()[idx].(Task)
+			if !ok {
+				break
+			}
+			// Optimistic update
+			item.doneYesterday = !item.doneYesterday
+			if setCmd := p.tasks.SetItem(idx, item); setCmd != nil {
 				cmds = append(cmds, setCmd)
 			}
-		} else {
-			// No filter - safe to re-sort and reset items
-			allItems := p.tasks.Items()
-			tasks := make([]Task, 0, len(allItems))
-			for i, listItem := range allItems {
-				if i == selectedIdx {
-					tasks = append(tasks, item)
-				} else {
-					tasks = append(tasks, listItem.(Task))
-				}
+			yesterday := dateKey(addDays(homeNow(), -1))
+			cmds = append(cmds, saveYesterdayCompletionCmd(p.db, item.id, yesterday, item.doneYesterday))
+			break
+		}
+
+		if key.Matches(msg, todayKeys.QuickNumbers) {
+			p.delegate.showNumbers = !p.delegate.showNumbers
+			break
+		}
+
+		if key.Matches(msg, todayKeys.Open) {
+			if item, ok := p.tasks.SelectedItem().(Task); ok && item.url != "" {
+				cmds = append(cmds, openTaskURLCmd(item.url))
 			}
-			sortTasksByCompletion(tasks)
+			break
+		}
 
-			sortedItems := make([]list.Item, len(tasks))
-			for i, t := range tasks {
-				sortedItems[i] = t
+		// Digits toggle the nth task on screen; while typing a filter they
+		// never get here (see SettingFilter above)
+		if p.delegate.showNumbers && key.Matches(msg, todayKeys.QuickComplete) {
+			if idx, ok := p.nthVisibleIndex(int(msg.String()[0] - '0')); ok {
+				cmds = append(cmds, p.toggleTask(idx)...)
 			}
-			p.tasks.SetItems(sortedItems)
+			break
+		}
+
+		if key.Matches(msg, todayKeys.Toggle) {
+			cmds = append(cmds, p.toggleTask(p.tasks.GlobalIndex())...)
+			break
 		}
 
-		// Persist to DB asynchronously
-		cmds = append(cmds, saveTaskCompletionCmd(p.db, item.id, item.completed))
+		if key.Matches(msg, todayKeys.CountDown) {
+			if item, ok := p.tasks.SelectedItem().(Task); ok && item.counter() {
+				cmds = append(cmds, p.stepTask(p.tasks.GlobalIndex(), -1)...)
+			}
+		}
 	}
 
+	p.fitList() // the filter banner may have come or gone
 	return p, tea.Batch(cmds...)
 }
 
+// toggleTask flips completion of the task at selectedIdx (an index into all
+// items), or counts a counter task once more, and returns the commands to
+// persist it.
+func (p *TodayPage) toggleTask(selectedIdx int) []tea.Cmd {
+	return p.stepTask(selectedIdx, 1)
+}
+
+// stepTask counts the counter task at selectedIdx step times more (or fewer,
+// when negative), or flips completion of any other task, and returns the
+// commands to persist it.
+func (p *TodayPage) stepTask(selectedIdx, step int) []tea.Cmd {
+	var cmds []tea.Cmd
+
+	if selectedIdx < 0 || selectedIdx >= len(p.tasks.Items()) {
+		return nil
+	}
+
+	item, ok := p.tasks.Items()[selectedIdx].(Task)
+	if !ok {
+		return nil
+	}
+
+	// Toggle state (optimistic UI update)
+	wasCompleted := item.completed
+	var save tea.Cmd
+	if item.counter() {
+		if !item.addCount(step) {
+			return nil
+		}
+		save = saveTaskCountCmd(p.db, item.id, item.title, step, item.target)
+	} else {
+		item.ToggleCompleted()
+		save = saveTaskCompletionCmd(p.db, item.id, item.title, item.completed)
+	}
+	// Counting past the target doesn't complete it again
+	justCompleted := item.completed && !wasCompleted
+
+	if cmd := p.replaceTask(selectedIdx, item); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+
+	// Persist to DB asynchronously
+	cmds = append(cmds, save)
+	if justCompleted {
+		cmds = append(cmds, completionSoundCmd(item))
+	}
+
+	if justCompleted && p.allDone() {
+		cmds = append(cmds, p.startCelebration())
+	} else {
+		p.stopCelebration()
+	}
+
+	// Ask for a note; the completion is already saved either way
+	if justCompleted && item.promptNote {
+		p.noteTaskID = item.id
+		p.noteTaskTitle = item.title
+		p.noteInput.Reset()
+		cmds = append(cmds, p.noteInput.Focus(), textinput.Blink)
+	}
+
+	return cmds
+}
+
+// replaceTask puts task in place of the item at idx and re-sorts the list,
+// unless a filter is active or completed tasks are kept in place.
+func (p *TodayPage) replaceTask(idx int, task Task) tea.Cmd {
+	// Check if filter is active
+	isFiltered := p.tasks.FilterState() == list.Filtering ||
+		p.tasks.FilterState() == list.FilterApplied
+
+	if isFiltered || p.keepCompletedInPlace {
+		// Filter active - just update the single item without re-sorting
+		// to preserve filter state (SetItems resets filter mapping).
+		// Also used when configured to keep completed tasks in place;
+		// the list is re-sorted on the next load.
+		return p.tasks.SetItem(idx, task)
+	}
+
+	// No filter - safe to re-sort and reset items
+	allItems := p.tasks.Items()
+	tasks := make([]Task, 0, len(allItems))
+	for i, listItem := range allItems {
+		if i == idx {
+			tasks = append(tasks, task)
+		} else {
+			tasks = append(tasks, listItem.(Task))
+		}
+	}
+	p.sortTasks(tasks)
+
+	sortedItems := make([]list.Item, len(tasks))
+	for i, t := range tasks {
+		sortedItems[i] = t
+	}
+	p.tasks.SetItems(sortedItems)
+	return nil
+}
+
+// sortTasks orders tasks for display; see todayOrder.
+func (p *TodayPage) sortTasks(tasks []Task) {
+	p.order.sort(tasks)
+}
+
+// nthVisibleIndex maps quick complete number n (1-based, counted from the top
+// of the current list page) to an index into all items.
+func (p *TodayPage) nthVisibleIndex(n int) (int, bool) {
+	visible := p.tasks.VisibleItems()
+	pag := p.tasks.Paginator
+	pos := pag.Page*pag.PerPage + n - 1
+	if n < 1 || n > pag.PerPage || pos >= len(visible) {
+		return 0, false
+	}
+	return p.itemIndex(pos)
+}
+
+// itemIndex maps an index into the visible (possibly filtered) items to an
+// index into all items.
+func (p *TodayPage) itemIndex(visiblePos int) (int, bool) {
+	visible := p.tasks.VisibleItems()
+	if visiblePos < 0 || visiblePos >= len(visible) {
+		return 0, false
+	}
+	target, ok := visible[visiblePos].(Task)
+	if !ok {
+		return 0, false
+	}
+	for i, item := range p.tasks.Items() {
+		if t, ok := item.(Task); ok && t.id == target.id {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// updateNotePrompt handles keys while the completion note prompt is open.
+func (p *TodayPage) updateNotePrompt(msg tea.KeyMsg) (Page, tea.Cmd) {
+	switch {
+	case key.Matches(msg, todayKeys.SaveNote):
+		taskID := p.noteTaskID
+		note := strings.TrimSpace(p.noteInput.Value())
+		p.closeNotePrompt()
+		if note == "" {
+			return p, nil
+		}
+		return p, saveCompletionNoteCmd(p.db, taskID, note)
+
+	case key.Matches(msg, todayKeys.CancelNote):
+		p.closeNotePrompt()
+		return p, nil
+	}
+
+	var cmd tea.Cmd
+	p.noteInput, cmd = p.noteInput.Update(msg)
+	return p, cmd
+}
+
+func (p *TodayPage) closeNotePrompt() {
+	p.noteTaskID = ""
+	p.noteTaskTitle = ""
+	p.noteInput.Blur()
+	p.noteInput.Reset()
+}
+
 func (p *TodayPage) View() string {
+	if p.promptingNote() {
+		return fmt.Sprintf(
+			"Completed \"%s\"\n\nNote:\n%s\n\n(enter to save, esc to skip)",
+			p.noteTaskTitle,
+			p.noteInput.View(),
+		)
+	}
+	if p.loadErr != nil {
+		return renderLoadError("today's tasks", p.loadErr)
+	}
+	if p.filtered() {
+		return p.filterBanner() + "\n" + p.tasks.View()
+	}
 	return p.tasks.View()
 }
 
+// DebugLayout implements LayoutDebugger.
+func (p *TodayPage) DebugLayout() []LayoutValue {
+	return []LayoutValue{
+		{"listW", p.tasks.Width()},
+		{"listH", p.tasks.Height()},
+	}
+}
+
+// Badge implements Badger with the number of tasks still to do today. Tasks
+// already satisfied for the week don't count.
+func (p *TodayPage) Badge() string {
+	if p.loadErr != nil {
+		return ""
+	}
+	n := 0
+	for _, item := range p.tasks.Items() {
+		if t, ok := item.(Task); ok && !t.completed && !t.satisfiedWeek {
+			n++
+		}
+	}
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
 func (p *TodayPage) KeyMap() []key.Binding {
-	return []key.Binding{
-		todayKeys.Toggle,
+	if p.promptingNote() {
+		return []key.Binding{
+			todayKeys.SaveNote,
+			todayKeys.CancelNote,
+		}
+	}
+	if p.loadErr != nil {
+		return []key.Binding{todayKeys.Retry}
+	}
+	if p.delegate.showNumbers {
+		return []key.Binding{
+			todayKeys.QuickComplete,
+			todayKeys.Toggle,
+			todayKeys.QuickNumbers,
+		}
+	}
+	keys := []key.Binding{todayKeys.Toggle}
+	item, selected := p.tasks.SelectedItem().(Task)
+	if selected && item.counter() {
+		keys = append(keys, todayKeys.CountDown)
+	}
+	keys = append(keys, todayKeys.WeekDone, todayKeys.Yesterday, todayKeys.QuickNumbers)
+	if selected && item.url != "" {
+		keys = append(keys, todayKeys.Open)
+	}
+	if p.tasks.FilterState() == list.FilterApplied {
+		keys = append(keys, todayKeys.ClearFilter)
 	}
+	return keys
 }
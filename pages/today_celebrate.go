@@ -0,0 +1,108 @@
+package pages
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Completing the last task on the Today page briefly swaps the list title
+// for a line of drifting confetti. Any key ends it early; STET_CELEBRATE=false
+// turns it off. Every completion also asks the app for a sound, which plays
+// if STET_COMPLETION_SOUND is set and do not disturb is off.
+
+const (
+	todayTitle = "Hit List"
+
+	celebrateFrames   = 12
+	celebrateInterval = 150 * time.Millisecond
+	confettiWidth     = 6
+)
+
+var confettiGlyphs = []rune("✦·✧*+·")
+
+// streakMilestones are the streak lengths, in days, that get their own sound.
+var streakMilestones = []int{7, 30, 100}
+
+// CompletionSoundMsg asks AppModel to play the completion sound, or the
+// milestone one when a streak has just reached a milestone.
+type CompletionSoundMsg struct {
+	Milestone bool
+}
+
+// completionSoundCmd requests the sound for t having just been completed.
+func completionSoundCmd(t Task) tea.Cmd {
+	msg := CompletionSoundMsg{Milestone: slices.Contains(streakMilestones, t.streak)}
+	return func() tea.Msg { return msg }
+}
+
+type celebrateTickMsg struct {
+	version int
+	frame   int
+}
+
+func celebrateTickCmd(version, frame int) tea.Cmd {
+	return tea.Tick(celebrateInterval, func(time.Time) tea.Msg {
+		return celebrateTickMsg{version: version, frame: frame}
+	})
+}
+
+// confetti returns frame's confetti strip; each frame shifts it one glyph.
+func confetti(frame int) string {
+	var b strings.Builder
+	for i := 0; i < confettiWidth; i++ {
+		b.WriteRune(confettiGlyphs[(i+frame)%len(confettiGlyphs)])
+	}
+	return b.String()
+}
+
+// allDone reports whether there are tasks and none are left to do today.
+// Tasks satisfied for the week count as done, as in Badge.
+func (p *TodayPage) allDone() bool {
+	items := p.tasks.Items()
+	for _, item := range items {
+		if t, ok := item.(Task); ok && !t.completed && !t.satisfiedWeek {
+			return false
+		}
+	}
+	return len(items) > 0
+}
+
+// startCelebration shows the first frame and schedules the rest.
+func (p *TodayPage) startCelebration() tea.Cmd {
+	if !p.celebrate {
+		return nil
+	}
+	p.celebrateVersion++
+	p.celebrating = true
+	p.setCelebrateFrame(0)
+	return celebrateTickCmd(p.celebrateVersion, 1)
+}
+
+// stopCelebration restores the list title.
+func (p *TodayPage) stopCelebration() {
+	if !p.celebrating {
+		return
+	}
+	p.celebrateVersion++ // drop ticks still in flight
+	p.celebrating = false
+	p.tasks.Title = todayTitle
+}
+
+func (p *TodayPage) setCelebrateFrame(frame int) {
+	p.tasks.Title = confetti(frame) + " All done! " + confetti(frame+confettiWidth/2)
+}
+
+func (p *TodayPage) updateCelebration(msg celebrateTickMsg) tea.Cmd {
+	if !p.celebrating || msg.version != p.celebrateVersion {
+		return nil
+	}
+	if msg.frame >= celebrateFrames {
+		p.stopCelebration()
+		return nil
+	}
+	p.setCelebrateFrame(msg.frame)
+	return celebrateTickCmd(msg.version, msg.frame+1)
+}
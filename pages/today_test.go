@@ -0,0 +1,153 @@
+package pages
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"stet.codes/tui/config"
+)
+
+// setTestHomeLocation sets the home zone for the rest of the test.
+func setTestHomeLocation(t *testing.T, name string) {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("no time zone data for %s: %v", name, err)
+	}
+	prev := homeLoc
+	SetHomeLocation(loc)
+	t.Cleanup(func() { SetHomeLocation(prev) })
+}
+
+// A completion made on the Today page is on the same day on the History
+// heatmap, whichever zone the days are counted in.
+func TestTodayCompletionShowsInHistory(t *testing.T) {
+	for _, zone := range []string{"UTC", "Pacific/Kiritimati", "Pacific/Pago_Pago"} {
+		t.Run(zone, func(t *testing.T) {
+			setTestHomeLocation(t, zone)
+			db := openTestDB(t)
+			addTestTask(t, db, "t1", "Read")
+
+			if msg := saveTaskCompletionCmd(db, "t1", "Read", true)(); msg != (taskCompletionSavedMsg{taskID: "t1", completed: true}) {
+				t.Fatalf("save: got %#v", msg)
+			}
+
+			tasks, err := loadTodayTasks(db)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tasks) != 1 || !tasks[0].completed {
+				t.Fatalf("Today: got %+v, want t1 completed", tasks)
+			}
+
+			today := todayKey()
+			msg := loadHistoryDataCmd(db, dateKey(addDays(homeNow(), -7)), today, nil, false)()
+			loaded, ok := msg.(historyDataLoadedMsg)
+			if !ok {
+				t.Fatalf("History: got %#v", msg)
+			}
+			if len(loaded.tasks) != 1 || !loaded.tasks[0].completions[today] {
+				t.Fatalf("History: got %+v, want t1 completed on %s", loaded.tasks, today)
+			}
+			if n := len(loaded.tasks[0].completions); n != 1 {
+				t.Errorf("History: %d days completed, want 1", n)
+			}
+		})
+	}
+}
+
+// Completing a task again, e.g. a retried or doubled toggle, leaves a
+// single row for the day.
+func TestCompleteTwiceKeepsOneRow(t *testing.T) {
+	db := openTestDB(t)
+	addTestTask(t, db, "t1", "Read")
+	today := todayKey()
+	yesterday := dateKey(addDays(homeNow(), -1))
+
+	for range 2 {
+		if msg := saveTaskCompletionCmd(db, "t1", "Read", true)(); msg != (taskCompletionSavedMsg{taskID: "t1", completed: true}) {
+			t.Fatalf("save: got %#v", msg)
+		}
+		if err := completeTaskDay(db, "t1", today, completedAtKey(homeNow())); err != nil {
+			t.Fatal(err)
+		}
+		writes := []historyWrite{{taskID: "t1", date: yesterday, completed: true}}
+		if err := saveHistoryCompletions(db, append(writes, writes...)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, date := range []string{today, yesterday} {
+		var n int
+		if err := db.QueryRow(`
+			SELECT count(*) FROM task_history WHERE task_id = 't1' AND completed_date = ?
+		`, date).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		if n != 1 {
+			t.Errorf("%s has %d rows, want 1", date, n)
+		}
+	}
+}
+
+// A note is only ever attached to an existing completion.
+func TestSaveCompletionNoteNeedsCompletion(t *testing.T) {
+	db := openTestDB(t)
+	addTestTask(t, db, "t1", "Read")
+
+	msg := saveCompletionNoteCmd(db, "t1", "a note")()
+	if failed, ok := msg.(completionNoteSaveFailedMsg); !ok || failed.err != errNoCompletion {
+		t.Fatalf("note without a completion: got %#v, want errNoCompletion", msg)
+	}
+	var n int
+	if err := db.QueryRow(`SELECT count(*) FROM task_history`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Fatalf("note without a completion added %d rows", n)
+	}
+
+	saveTaskCompletionCmd(db, "t1", "Read", true)()
+	if msg := saveCompletionNoteCmd(db, "t1", "a note")(); msg != (completionNoteSavedMsg{taskID: "t1"}) {
+		t.Fatalf("note on a completion: got %#v", msg)
+	}
+	var note string
+	if err := db.QueryRow(`SELECT note FROM task_history WHERE task_id = 't1'`).Scan(&note); err != nil {
+		t.Fatal(err)
+	}
+	if note != "a note" {
+		t.Errorf("note is %q, want %q", note, "a note")
+	}
+}
+
+// A completion that fails to save is undone entirely: its streak and
+// completion time go back with it, and the list is sorted again.
+func TestTodayRevertsFailedCompletion(t *testing.T) {
+	p := NewTodayPage(nil, config.Default())
+	p.Update(activeTasksLoadedMsg{tasks: []Task{
+		{id: "t1", title: "Read", streak: 3, target: 1},
+		{id: "t2", title: "Write", target: 1},
+	}})
+
+	p.toggleTask(0)
+	// Completed tasks sort after the rest
+	toggled := p.tasks.Items()[1].(Task)
+	if toggled.id != "t1" || !toggled.completed || toggled.streak != 4 {
+		t.Fatalf("after toggling, got %+v", toggled)
+	}
+
+	p.Update(taskCompletionSaveFailedMsg{taskID: "t1", completed: true, err: errors.New("disk full")})
+	var reverted Task
+	for _, item := range p.tasks.Items() {
+		if task := item.(Task); task.id == "t1" {
+			reverted = task
+		} else if task.completed {
+			t.Errorf("%s is completed after the revert", task.id)
+		}
+	}
+	if reverted.completed || reverted.streak != 3 || !reverted.completedAt.IsZero() {
+		t.Errorf("after the revert, got completed %v, streak %d, completedAt %v",
+			reverted.completed, reverted.streak, reverted.completedAt)
+	}
+}
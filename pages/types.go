@@ -41,6 +41,44 @@ type NavigationCapturer interface {
 	CapturesGlobalKeys() bool
 }
 
+// Leaver is an optional interface for pages that need to wrap up (e.g. write
+// buffered changes) when the user navigates to another page. The returned
+// command, if any, runs after the switch.
+type Leaver interface {
+	Leave() tea.Cmd
+}
+
+// Shutdowner is an optional interface for pages that hold unsaved state or
+// background work to wrap up before the app exits.
+type Shutdowner interface {
+	Shutdown() error
+}
+
+// Refresher is an optional interface for pages that can reload their data
+// on demand: with ctrl+r, or after the app has been left idle.
+type Refresher interface {
+	RefreshCmd() tea.Cmd
+}
+
+// Badger is an optional interface for pages that show a short actionable
+// count next to their name in the navigation bar, e.g. tasks left today.
+// An empty badge is not shown.
+type Badger interface {
+	Badge() string
+}
+
+// LayoutValue is a named layout measurement reported for debugging.
+type LayoutValue struct {
+	Name  string
+	Value int
+}
+
+// LayoutDebugger is an optional interface for pages that expose the layout
+// values they computed during SetSize, for the hidden debug overlay.
+type LayoutDebugger interface {
+	DebugLayout() []LayoutValue
+}
+
 // Page is the interface that all pages must implement.
 // Each page manages its own state, handles updates, and renders its content.
 type Page interface {
@@ -0,0 +1,24 @@
+package pages
+
+import (
+	"fmt"
+	"time"
+)
+
+// formatUpdated renders a fetch time for status lines: as a live relative age
+// ("updated 2m 05s ago") when relative is set, otherwise as a clock time in
+// timeLayout after label, e.g. "Last updated: 15:04:05".
+func formatUpdated(label string, at time.Time, relative bool, timeLayout string) string {
+	if !relative {
+		return label + ": " + at.Format(timeLayout)
+	}
+	age := max(time.Since(at).Truncate(time.Second), 0)
+	switch {
+	case age < time.Minute:
+		return fmt.Sprintf("Updated %ds ago", int(age.Seconds()))
+	case age < time.Hour:
+		return fmt.Sprintf("Updated %dm %02ds ago", int(age.Minutes()), int(age.Seconds())%60)
+	default:
+		return fmt.Sprintf("Updated %dh %02dm ago", int(age.Hours()), int(age.Minutes())%60)
+	}
+}
@@ -0,0 +1,78 @@
+package pages
+
+import (
+	"context"
+	"sync/atomic"
+
+	"stet.codes/tui/clients"
+)
+
+// webhookQueueSize is how many completions can wait to be posted; more are
+// dropped rather than hold up a save.
+const webhookQueueSize = 64
+
+// completionWebhook is told about each completion toggled on the Today page
+// or the Journal's checklist; it is disabled until SetCompletionWebhook is
+// called with a URL. Completions are posted one at a time, in order, from
+// webhookQueue.
+var (
+	completionWebhook *clients.Webhook
+	webhookQueue      chan queuedCompletion
+	completionSeq     atomic.Uint64
+)
+
+// queuedCompletion is a completion waiting to be posted. seq orders the
+// changes as they were made; see nextCompletionSeq.
+type queuedCompletion struct {
+	seq   uint64
+	event clients.CompletionEvent
+}
+
+// SetCompletionWebhook sets the webhook completions are posted to and
+// starts posting them.
+func SetCompletionWebhook(w *clients.Webhook) {
+	completionWebhook = w
+	if w.Enabled() && webhookQueue == nil {
+		webhookQueue = make(chan queuedCompletion, webhookQueueSize)
+		go postCompletions(w, webhookQueue)
+	}
+}
+
+// nextCompletionSeq numbers a completion change when it is made, before it
+// is saved. Saves run concurrently and can finish out of order; the number
+// tells which of two changes to the same day came last.
+func nextCompletionSeq() uint64 {
+	return completionSeq.Add(1)
+}
+
+// notifyCompletion queues a saved completion for the webhook. It never
+// holds up the save, and failures are only logged.
+func notifyCompletion(seq uint64, taskID, title, date string, completed bool) {
+	if !completionWebhook.Enabled() {
+		return
+	}
+	event := clients.CompletionEvent{TaskID: taskID, Title: title, Date: date, Completed: completed}
+	select {
+	case webhookQueue <- queuedCompletion{seq: seq, event: event}:
+	default:
+		logger.Warnf("webhook: queue full, dropped completion of task %s", taskID)
+	}
+}
+
+// postCompletions posts queued completions one at a time, in the order they
+// were queued. A change queued after a later one to the same task and day,
+// because its save finished last, is skipped: the endpoint already has the
+// newer state.
+func postCompletions(w *clients.Webhook, queue <-chan queuedCompletion) {
+	posted := make(map[string]uint64) // task id and date -> seq last posted
+	for c := range queue {
+		day := c.event.TaskID + " " + c.event.Date
+		if c.seq < posted[day] {
+			continue
+		}
+		posted[day] = c.seq
+		if err := w.PostCompletion(context.Background(), c.event); err != nil {
+			logger.Warnf("webhook: post completion of task %s: %v", c.event.TaskID, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package pages
+
+import "time"
+
+// homeLoc is the time zone days are counted in: which day it is, what
+// "today" means for tasks and the journal, and the wall-clock times stored
+// with completions. It is the system zone unless SetHomeLocation says
+// otherwise, so days don't shift while travelling.
+var homeLoc = time.Local
+
+// SetHomeLocation sets the zone days are counted in.
+func SetHomeLocation(loc *time.Location) {
+	homeLoc = loc
+}
+
+// clockLayout is how homeClock shows a time of day.
+var clockLayout = "15:04"
+
+// SetClockLayout sets the layout of times of day beside journal notes,
+// config.DateFormats.TimeShort.
+func SetClockLayout(layout string) {
+	clockLayout = layout
+}
+
+// homeNow returns the current time in the home zone. Anything that decides
+// which day it is starts from this rather than time.Now.
+func homeNow() time.Time {
+	return time.Now().In(homeLoc)
+}
+
+// sqliteTimestamp is the layout of SQLite's CURRENT_TIMESTAMP columns
+// (created_at, updated_at), which hold UTC. Select them through
+// strftime('%Y-%m-%d %H:%M:%S', ...) so the driver returns this text.
+const sqliteTimestamp = "2006-01-02 15:04:05"
+
+// fromUTC parses a sqliteTimestamp and returns it in the home zone.
+func fromUTC(s string) (time.Time, bool) {
+	t, err := time.ParseInLocation(sqliteTimestamp, s, time.UTC)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.In(homeLoc), true
+}
+
+// homeClock returns a UTC sqliteTimestamp's home-zone time of day, in
+// clockLayout, or "" if it doesn't parse.
+func homeClock(s string) string {
+	t, ok := fromUTC(s)
+	if !ok {
+		return ""
+	}
+	return t.Format(clockLayout)
+}
+
+// weekStart returns midnight on the Monday of the week containing t, in t's
+// location. Weeks run Monday through Sunday.
+func weekStart(t time.Time) time.Time {
+	return weekStartOn(t, time.Monday)
+}
+
+// weekStartOn returns midnight on the first day of the week containing t,
+// for weeks starting on first.
+func weekStartOn(t time.Time, first time.Weekday) time.Time {
+	offset := (int(t.Weekday()) - int(first) + 7) % 7 // days since first
+	return addDays(t, -offset)
+}
+
+// currentWeekKey returns the current week's start date as stored in the
+// database ("YYYY-MM-DD").
+func currentWeekKey() string {
+	return weekStart(homeNow()).Format("2006-01-02")
+}
+
+// dateKey returns t's calendar date as stored in the database
+// ("YYYY-MM-DD"), e.g. in task_history.completed_date.
+func dateKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// todayKey returns today's date key, in the home zone. Queries take it as a
+// parameter rather than using SQLite's date('now', 'localtime'), which
+// follows the system zone, so every page agrees on the day.
+func todayKey() string {
+	return dateKey(homeNow())
+}
+
+// completedAtKey returns t as stored in task_history.completed_at.
+func completedAtKey(t time.Time) string {
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// startOfDay returns midnight on t's calendar date, in t's location.
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return midnight(y, m, d, t.Location())
+}
+
+// addDays returns midnight n calendar days after day's date, in day's
+// location. The date is worked out in UTC, where every day is 24 hours
+// long, so a DST change can't land it on the wrong day.
+func addDays(day time.Time, n int) time.Time {
+	y, m, d := day.Date()
+	y, m, d = time.Date(y, m, d+n, 0, 0, 0, 0, time.UTC).Date()
+	return midnight(y, m, d, day.Location())
+}
+
+// midnight returns the start of the given (normalized) date in loc. Where a
+// DST change skips midnight, time.Date may resolve it to the previous
+// evening, so this moves forward to the first hour actually on the date.
+func midnight(y int, m time.Month, d int, loc *time.Location) time.Time {
+	t := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	for t.Day() != d {
+		t = t.Add(time.Hour)
+	}
+	return t
+}
+
+// dateKeysBack returns n dates ("YYYY-MM-DD") counting back one calendar
+// day at a time from newest's date, each exactly once regardless of DST.
+func dateKeysBack(newest time.Time, n int) []string {
+	y, m, d := newest.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = day.AddDate(0, 0, -i).Format("2006-01-02")
+	}
+	return keys
+}
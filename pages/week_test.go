@@ -0,0 +1,146 @@
+package pages
+
+import (
+	"testing"
+	"time"
+)
+
+// dstTransitions are days a clock change falls on. São Paulo's 2018 change
+// skipped midnight itself.
+var dstTransitions = []struct {
+	zone string
+	day  string // "YYYY-MM-DD"
+}{
+	{"America/New_York", "2024-03-10"}, // 02:00 -> 03:00
+	{"America/New_York", "2024-11-03"}, // 02:00 -> 01:00
+	{"America/New_York", "2025-03-09"},
+	{"America/New_York", "2025-11-02"},
+	{"America/Sao_Paulo", "2018-11-04"}, // 00:00 -> 01:00
+}
+
+// checkConsecutive fails unless keys are one per calendar day, each the day
+// after the one before when step is 1, or before when step is -1.
+func checkConsecutive(t *testing.T, keys []string, step int) {
+	t.Helper()
+	seen := make(map[string]bool)
+	for i, key := range keys {
+		if seen[key] {
+			t.Errorf("%s appears twice in %v", key, keys)
+		}
+		seen[key] = true
+		if i == 0 {
+			continue
+		}
+		prev, err := time.Parse("2006-01-02", keys[i-1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := prev.AddDate(0, 0, step).Format("2006-01-02"); key != want {
+			t.Errorf("key %d is %s, want %s, in %v", i, key, want, keys)
+		}
+	}
+}
+
+func TestStartOfDayAcrossDST(t *testing.T) {
+	for _, tt := range dstTransitions {
+		t.Run(tt.zone+" "+tt.day, func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.zone)
+			if err != nil {
+				t.Skipf("no time zone data: %v", err)
+			}
+			day, err := time.ParseInLocation("2006-01-02", tt.day, loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Every half hour from the day before to the day after
+			for at := day.Add(-24 * time.Hour); at.Before(day.Add(48 * time.Hour)); at = at.Add(30 * time.Minute) {
+				start := startOfDay(at)
+				if dateKey(start) != dateKey(at) {
+					t.Errorf("startOfDay(%v) = %v, on another day", at, start)
+				}
+				if start.After(at) {
+					t.Errorf("startOfDay(%v) = %v, after it", at, start)
+				}
+				if before := start.Add(-time.Minute); dateKey(before) == dateKey(at) {
+					t.Errorf("startOfDay(%v) = %v, but %v is the same day", at, start, before)
+				}
+			}
+		})
+	}
+}
+
+func TestAddDaysAcrossDST(t *testing.T) {
+	for _, tt := range dstTransitions {
+		t.Run(tt.zone+" "+tt.day, func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.zone)
+			if err != nil {
+				t.Skipf("no time zone data: %v", err)
+			}
+			transition, err := time.ParseInLocation("2006-01-02", tt.day, loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, hour := range []int{0, 1, 12, 23} {
+				from := startOfDay(addDays(transition, -5)).Add(time.Duration(hour) * time.Hour)
+
+				// Counting from a fixed day
+				var keys []string
+				for n := range 11 {
+					day := addDays(from, n)
+					if !day.Equal(startOfDay(day)) {
+						t.Errorf("addDays(%v, %d) = %v, not the start of a day", from, n, day)
+					}
+					keys = append(keys, dateKey(day))
+				}
+				checkConsecutive(t, keys, 1)
+
+				// Stepping a day at a time, forwards then back
+				keys = keys[:0]
+				day := from
+				for range 11 {
+					keys = append(keys, dateKey(day))
+					day = addDays(day, 1)
+				}
+				checkConsecutive(t, keys, 1)
+				keys = keys[:0]
+				for range 11 {
+					day = addDays(day, -1)
+					keys = append(keys, dateKey(day))
+				}
+				checkConsecutive(t, keys, -1)
+			}
+		})
+	}
+}
+
+func TestDateKeysBackAcrossDST(t *testing.T) {
+	for _, tt := range dstTransitions {
+		t.Run(tt.zone+" "+tt.day, func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.zone)
+			if err != nil {
+				t.Skipf("no time zone data: %v", err)
+			}
+			transition, err := time.ParseInLocation("2006-01-02", tt.day, loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, newest := range []time.Time{
+				startOfDay(transition),
+				transition.Add(23*time.Hour + 30*time.Minute),
+				startOfDay(addDays(transition, 1)).Add(30 * time.Minute),
+				addDays(transition, 3).Add(-time.Minute),
+			} {
+				keys := dateKeysBack(newest, 10)
+				if len(keys) != 10 {
+					t.Fatalf("dateKeysBack(%v, 10) returned %d keys", newest, len(keys))
+				}
+				if keys[0] != dateKey(newest) {
+					t.Errorf("dateKeysBack(%v, 10) starts at %s", newest, keys[0])
+				}
+				checkConsecutive(t, keys, -1)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// exitDataDirUnwritable is the exit code when the data directory can't be
+// created or written to.
+const exitDataDirUnwritable = 3
+
+// checkDataDir makes sure dir exists and a file can be created in it, so a
+// missing permission or read-only filesystem is reported up front instead
+// of as a failure to open the database (or silently lost log lines).
+func checkDataDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return dataDirError("cannot create data directory", dir, err)
+	}
+	f, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return dataDirError("cannot write to data directory", dir, err)
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return nil
+}
+
+// dataDirError explains a data directory failure with a suggested fix.
+func dataDirError(what, dir string, err error) error {
+	var fix string
+	switch {
+	case errors.Is(err, fs.ErrPermission):
+		fix = fmt.Sprintf("Your user needs write and execute permission on %s\n"+
+			"and its parent directories (e.g. chmod u+wx %s)", dir, dir)
+	case errors.Is(err, syscall.EROFS):
+		fix = fmt.Sprintf("%s is on a read-only filesystem", dir)
+	case errors.Is(err, syscall.ENOTDIR):
+		fix = fmt.Sprintf("A file is in the way of a directory on the path %s", dir)
+	default:
+		fix = "Check that the path is valid and the disk isn't full"
+	}
+	return fmt.Errorf("%s %s: %w\n\n%s, or set XDG_DATA_HOME to a writable directory;\n"+
+		"stet then keeps its data in $XDG_DATA_HOME/stet.", what, dir, err, fix)
+}
@@ -0,0 +1,58 @@
+package sound
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+)
+
+// Event is what a sound marks.
+type Event int
+
+const (
+	// Complete marks a task being completed.
+	Complete Event = iota
+	// Milestone marks a streak reaching a milestone, e.g. 30 days.
+	Milestone
+)
+
+// Bell rings the terminal bell by writing BEL to w, usually the terminal.
+func Bell(w io.Writer) error {
+	_, err := io.WriteString(w, "\a")
+	return err
+}
+
+// Play plays a short system sound for e and waits for it to finish.
+func Play(e Event) error {
+	var cmd string
+	var args []string
+
+	switch runtime.GOOS {
+	case "darwin":
+		name := "Glass"
+		if e == Milestone {
+			name = "Hero"
+		}
+		cmd = "afplay"
+		args = []string{"/System/Library/Sounds/" + name + ".aiff"}
+	case "linux":
+		name := "complete"
+		if e == Milestone {
+			name = "bell"
+		}
+		cmd = "paplay"
+		args = []string{"/usr/share/sounds/freedesktop/stereo/" + name + ".oga"}
+	case "windows":
+		name := "Asterisk"
+		if e == Milestone {
+			name = "Exclamation"
+		}
+		cmd = "powershell"
+		args = []string{"-NoProfile", "-Command", "[System.Media.SystemSounds]::" + name + ".Play()"}
+	default:
+		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+
+	return exec.Command(cmd, args...).Run()
+}
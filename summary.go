@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"stet.codes/tui/clients"
+	"stet.codes/tui/config"
+	"stet.codes/tui/logging"
+	"stet.codes/tui/pages"
+)
+
+// runSummary prints a plain-text summary of the day to stdout and returns the
+// process exit code. Integration sections are skipped when not configured and
+// reported as unavailable when their fetch fails; only a database failure is
+// fatal.
+func runSummary(fileLogger *logging.Logger, cfg config.Config, ouraClient *clients.OuraClient, plantaClient *clients.PlantaClient) int {
+	db, err := openDB(fileLogger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stet: cannot open database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	tasks, err := pages.LoadTaskSummary(db)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stet: cannot load tasks: %v\n", err)
+		return 1
+	}
+
+	w := os.Stdout
+	fmt.Fprintf(w, "%s\n\n", time.Now().In(cfg.HomeZone).Format(cfg.Formats.DateLong))
+	writeTaskSummary(w, tasks)
+	writePlantaSummary(w, fileLogger, plantaClient, cfg.PlantaTimeouts.Background)
+	writeOuraSummary(w, fileLogger, ouraClient, cfg.OuraTimeouts.Background)
+	return 0
+}
+
+func writeTaskSummary(w io.Writer, tasks []pages.TaskSummary) {
+	done := 0
+	for _, t := range tasks {
+		if t.Completed {
+			done++
+		}
+	}
+	fmt.Fprintf(w, "Tasks: %d/%d done\n", done, len(tasks))
+	for _, t := range tasks {
+		if !t.Completed {
+			fmt.Fprintf(w, "  [ ] %s\n", t.Title)
+		}
+	}
+
+	var atRisk []pages.TaskSummary
+	for _, t := range tasks {
+		if !t.Completed && t.Streak > 0 {
+			atRisk = append(atRisk, t)
+		}
+	}
+	if len(atRisk) > 0 {
+		fmt.Fprintln(w, "\nStreaks at risk:")
+		for _, t := range atRisk {
+			fmt.Fprintf(w, "  %s (%d days)\n", t.Title, t.Streak)
+		}
+	}
+}
+
+func writePlantaSummary(w io.Writer, fileLogger *logging.Logger, client *clients.PlantaClient, timeout time.Duration) {
+	if !client.Auth().HasCredentials() {
+		return
+	}
+	fmt.Fprintln(w)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := client.EnsureAuthenticated(ctx); err != nil {
+		fileLogger.Warnf("summary: planta auth: %v", err)
+		fmt.Fprintln(w, "Plants: unavailable")
+		return
+	}
+	tasks, err := client.GetDueTasks(ctx, 0)
+	if err != nil {
+		fileLogger.Warnf("summary: planta fetch: %v", err)
+		fmt.Fprintln(w, "Plants: unavailable")
+		return
+	}
+
+	fmt.Fprintf(w, "Plants due: %d\n", len(tasks))
+	for _, t := range tasks {
+		suffix := ""
+		if t.IsOverdue {
+			suffix = " (overdue)"
+		}
+		fmt.Fprintf(w, "  %s: %s%s\n", t.PlantName, t.ActionType, suffix)
+	}
+}
+
+func writeOuraSummary(w io.Writer, fileLogger *logging.Logger, client *clients.OuraClient, timeout time.Duration) {
+	if !client.Auth().HasCredentials() || !client.IsAuthenticated() {
+		return
+	}
+	fmt.Fprintln(w)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	readiness, err := client.GetTodayReadiness(ctx)
+	switch {
+	case err != nil:
+		fileLogger.Warnf("summary: oura fetch: %v", err)
+		fmt.Fprintln(w, "Readiness: unavailable")
+	case readiness == nil:
+		fmt.Fprintln(w, "Readiness: no data yet today")
+	default:
+		fmt.Fprintf(w, "Readiness: %d\n", readiness.Score)
+	}
+}
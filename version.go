@@ -0,0 +1,152 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"stet.codes/tui/config"
+
+	"github.com/pressly/goose/v3"
+)
+
+// version is the release, set with -ldflags "-X main.version=v1.2.3" when
+// building one. Otherwise the module version and VCS details Go records in
+// the binary are shown.
+var version = ""
+
+// buildVersion describes the binary, e.g. "v1.2.3" or
+// "(devel) 1a2b3c4d5e6f 2026-10-01T12:00:00Z (modified)".
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	v := version
+	if v == "" {
+		v = "unknown"
+		if ok {
+			v = info.Main.Version
+		}
+	}
+	if !ok {
+		return v
+	}
+	var revision, when string
+	modified := false
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value[:min(len(s.Value), 12)]
+		case "vcs.time":
+			when = s.Value
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	// A pseudo-version like v0.0.0-20261001120000-1a2b3c4d5e6f already has it
+	if revision != "" && !strings.Contains(v, revision) {
+		v += " " + revision
+	}
+	if when != "" {
+		v += " " + when
+	}
+	if modified {
+		v += " (modified)"
+	}
+	return v
+}
+
+// latestMigration returns the version of the newest migration built in.
+func latestMigration() (int64, error) {
+	goose.SetBaseFS(embedMigrations)
+	migrations, err := goose.CollectMigrations("migrations", 0, goose.MaxVersion)
+	if err != nil {
+		return 0, err
+	}
+	last, err := migrations.Last()
+	if err != nil {
+		return 0, err
+	}
+	return last.Version, nil
+}
+
+// appliedMigration returns the newest migration applied to db, or 0 if
+// none are. Unlike goose.GetDBVersion it doesn't create goose's table, so it
+// can look at a database without changing it.
+func appliedMigration(db *sql.DB) (int64, error) {
+	var tracked bool
+	err := db.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'goose_db_version')
+	`).Scan(&tracked)
+	if err != nil || !tracked {
+		return 0, err
+	}
+	var applied int64
+	err = db.QueryRow(`
+		SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied
+	`).Scan(&applied)
+	return applied, err
+}
+
+// newerSchemaError reports a database migrated by a newer build than this
+// one, which may store things this build doesn't know to keep.
+func newerSchemaError(applied, latest int64) error {
+	return fmt.Errorf("the database is at migration %d, newer than this build of stet supports (%d).\n"+
+		"It was last opened by a newer version; update stet to use it", applied, latest)
+}
+
+// runVersion prints the binary's version, the database's migration next to
+// the one this build expects, and where stet keeps its files, for bug
+// reports. The database is only read, not migrated.
+func runVersion() int {
+	fmt.Printf("stet %s (%s, %s/%s)\n\n", buildVersion(), runtime.Version(), runtime.GOOS, runtime.GOARCH)
+
+	dbPath := filepath.Join(config.DataDir(), dbName)
+	fmt.Printf("database  %s\n", dbPath)
+	latest, err := latestMigration()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stet: read built-in migrations: %v\n", err)
+		return 1
+	}
+	fmt.Printf("schema    %s\n", schemaStatus(dbPath, latest))
+
+	fmt.Printf("config    %s%s\n", config.ConfigFile(), missing(config.ConfigFile()))
+	fmt.Printf("data dir  %s\n", config.DataDir())
+	fmt.Printf("log       %s\n", filepath.Join(config.DataDir(), logName))
+	return 0
+}
+
+// schemaStatus describes the migration the database at dbPath is at
+// against latest, the newest this build has.
+func schemaStatus(dbPath string, latest int64) string {
+	if _, err := os.Stat(dbPath); errors.Is(err, os.ErrNotExist) {
+		return fmt.Sprintf("no database yet; this build creates it at migration %d", latest)
+	}
+	db, err := sql.Open("sqlite", "file:"+dbPath+"?mode=ro")
+	if err != nil {
+		return fmt.Sprintf("cannot open: %v", err)
+	}
+	defer db.Close()
+
+	applied, err := appliedMigration(db)
+	switch {
+	case err != nil:
+		return fmt.Sprintf("cannot read: %v", err)
+	case applied == latest:
+		return fmt.Sprintf("migration %d (up to date)", applied)
+	case applied < latest:
+		return fmt.Sprintf("migration %d, this build expects %d (migrated on next start)", applied, latest)
+	}
+	return fmt.Sprintf("migration %d, newer than this build supports (%d); update stet", applied, latest)
+}
+
+// missing notes when path doesn't exist.
+func missing(path string) string {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return " (not found)"
+	}
+	return ""
+}